@@ -0,0 +1,14 @@
+// Command richtextescape reports text written to a richtext stream without
+// being escaped. It runs on its own, or as a tool of go vet:
+//
+//	go vet -vettool=$(which richtextescape) ./...
+package main
+
+import (
+	"github.com/bitwizeshift/go-cli/analysis/richtextescape"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(richtextescape.Analyzer)
+}
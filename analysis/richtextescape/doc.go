@@ -0,0 +1,8 @@
+// Package richtextescape defines an [Analyzer] that reports text written to a
+// richtext stream without being escaped. Inside a runner,
+// [github.com/bitwizeshift/go-cli.OutStream] and
+// [github.com/bitwizeshift/go-cli.ErrStream] parse richtext markup, so a value
+// such as a file name that contains "[fg:red]" is rendered as a tag unless it
+// is passed through [github.com/bitwizeshift/go-cli/richtext.Escape] or
+// formatted with [github.com/bitwizeshift/go-cli/richtext.Fprintf].
+package richtextescape
@@ -0,0 +1,182 @@
+package richtextescape
+
+import (
+	"go/ast"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const (
+	cliPath      = "github.com/bitwizeshift/go-cli"
+	richtextPath = "github.com/bitwizeshift/go-cli/richtext"
+)
+
+// Analyzer reports calls to [fmt.Fprintf], [fmt.Fprint], and [fmt.Fprintln]
+// that write a value which may hold text, such as a string, an error, or a
+// [fmt.Stringer], to a richtext stream without escaping it. A richtext stream
+// is the result of cli.OutStream or cli.ErrStream, a variable assigned one, or
+// a *richtext.Writer. Constants are the caller's own markup and are not
+// reported, nor are the results of richtext.Escape and richtext.Sprintf. The
+// format given to fmt.Fprintf is checked too, so only a constant format, or
+// one built by richtext.Sprintf, goes unreported.
+var Analyzer = &analysis.Analyzer{
+	Name:     "richtextescape",
+	Doc:      "report text written to a richtext stream without being escaped",
+	URL:      "https://pkg.go.dev/github.com/bitwizeshift/go-cli/analysis/richtextescape",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// printers maps each checked function of package fmt to the index of its first
+// argument that is printed. The format of Fprintf is checked on its own, since
+// it is written as it is, markup and all, unless it is a constant.
+var printers = map[string]int{
+	"Fprintf":  2,
+	"Fprint":   1,
+	"Fprintln": 1,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	in := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	streams := streamVariables(pass, in)
+
+	in.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		fn := typeutil.StaticCallee(pass.TypesInfo, call)
+		if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != "fmt" {
+			return
+		}
+		first, ok := printers[fn.Name()]
+		if !ok || len(call.Args) < first || !isStream(pass, streams, call.Args[0]) {
+			return
+		}
+		if fn.Name() == "Fprintf" && !isSafe(pass, call.Args[1]) {
+			pass.ReportRangef(call.Args[1], "fmt.Fprintf writes the format %s to a richtext stream unescaped, so markup in it is rendered; use a constant format",
+				types.ExprString(call.Args[1]))
+		}
+		if call.Ellipsis.IsValid() {
+			return
+		}
+		for _, arg := range call.Args[first:] {
+			if isSafe(pass, arg) {
+				continue
+			}
+			pass.ReportRangef(arg, "fmt.%s writes %s to a richtext stream unescaped, so markup in it is rendered; use richtext.Fprintf or richtext.Escape",
+				fn.Name(), types.ExprString(arg))
+		}
+	})
+	return nil, nil
+}
+
+// streamVariables returns the variables assigned the result of cli.OutStream
+// or cli.ErrStream anywhere in the package.
+func streamVariables(pass *analysis.Pass, in *inspector.Inspector) map[types.Object]bool {
+	streams := make(map[types.Object]bool)
+	record := func(lhs *ast.Ident, rhs ast.Expr) {
+		if obj := pass.TypesInfo.ObjectOf(lhs); obj != nil && isStreamCall(pass, rhs) {
+			streams[obj] = true
+		}
+	}
+	in.Preorder([]ast.Node{(*ast.AssignStmt)(nil), (*ast.ValueSpec)(nil)}, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if len(n.Lhs) != len(n.Rhs) {
+				return
+			}
+			for i, lhs := range n.Lhs {
+				if id, ok := lhs.(*ast.Ident); ok {
+					record(id, n.Rhs[i])
+				}
+			}
+		case *ast.ValueSpec:
+			if len(n.Names) != len(n.Values) {
+				return
+			}
+			for i, name := range n.Names {
+				record(name, n.Values[i])
+			}
+		}
+	})
+	return streams
+}
+
+// isStream reports whether e writes to a richtext stream.
+func isStream(pass *analysis.Pass, streams map[types.Object]bool, e ast.Expr) bool {
+	e = ast.Unparen(e)
+	if id, ok := e.(*ast.Ident); ok && streams[pass.TypesInfo.ObjectOf(id)] {
+		return true
+	}
+	if ptr, ok := pass.TypesInfo.TypeOf(e).(*types.Pointer); ok && isNamed(ptr.Elem(), richtextPath, "Writer") {
+		return true
+	}
+	return isStreamCall(pass, e)
+}
+
+// isStreamCall reports whether e is a call to cli.OutStream or cli.ErrStream.
+func isStreamCall(pass *analysis.Pass, e ast.Expr) bool {
+	return isCallTo(pass, e, cliPath, "OutStream", "ErrStream")
+}
+
+// isSafe reports whether e needs no escaping: it is a constant, it cannot
+// format to text, or it is already escaped.
+func isSafe(pass *analysis.Pass, e ast.Expr) bool {
+	tv, ok := pass.TypesInfo.Types[e]
+	if !ok || tv.Value != nil || !mayHoldText(tv.Type) {
+		return true
+	}
+	return isCallTo(pass, e, richtextPath, "Escape", "Sprintf")
+}
+
+// mayHoldText reports whether a value of type t may format to arbitrary text:
+// a string or byte or rune slice, an error, a fmt.Stringer, or an interface
+// that could hold any of these.
+func mayHoldText(t types.Type) bool {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		return u.Info()&types.IsString != 0
+	case *types.Slice:
+		elem, ok := u.Elem().Underlying().(*types.Basic)
+		return ok && (elem.Kind() == types.Byte || elem.Kind() == types.Rune)
+	case *types.Interface:
+		return true
+	}
+	return hasMethod(t, "Error") || hasMethod(t, "String")
+}
+
+// hasMethod reports whether t, or a pointer to it, has a method called name
+// that takes nothing and returns a string.
+func hasMethod(t types.Type, name string) bool {
+	obj, _, _ := types.LookupFieldOrMethod(t, true, nil, name)
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+	sig := fn.Signature()
+	return sig.Params().Len() == 0 && sig.Results().Len() == 1 && types.Identical(sig.Results().At(0).Type(), types.Typ[types.String])
+}
+
+// isCallTo reports whether e is a call to one of the functions names of the
+// package at path.
+func isCallTo(pass *analysis.Pass, e ast.Expr, path string, names ...string) bool {
+	call, ok := ast.Unparen(e).(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	fn := typeutil.StaticCallee(pass.TypesInfo, call)
+	return fn != nil && fn.Pkg() != nil && fn.Pkg().Path() == path && slices.Contains(names, fn.Name())
+}
+
+// isNamed reports whether t is the named type name of the package at path.
+func isNamed(t types.Type, path, name string) bool {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == path && obj.Name() == name
+}
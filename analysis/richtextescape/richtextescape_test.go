@@ -0,0 +1,14 @@
+package richtextescape_test
+
+import (
+	"testing"
+
+	"github.com/bitwizeshift/go-cli/analysis/richtextescape"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	t.Parallel()
+
+	analysistest.Run(t, analysistest.TestData(), richtextescape.Analyzer, "a")
+}
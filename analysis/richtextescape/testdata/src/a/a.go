@@ -0,0 +1,59 @@
+package a
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/bitwizeshift/go-cli"
+	"github.com/bitwizeshift/go-cli/richtext"
+)
+
+type name string
+
+func (n name) String() string { return string(n) }
+
+type count int
+
+const greeting = "[theme:title]hello[/theme]"
+
+func direct(ctx context.Context, file string, err error) {
+	fmt.Fprintf(cli.OutStream(ctx), "opened %s\n", file)              // want `fmt.Fprintf writes file to a richtext stream unescaped`
+	fmt.Fprintf(cli.ErrStream(ctx), "error: %v\n", err)               // want `fmt.Fprintf writes err to a richtext stream unescaped`
+	fmt.Fprintln(cli.OutStream(ctx), "file:", file)                   // want `fmt.Fprintln writes file to a richtext stream unescaped`
+	fmt.Fprint((cli.OutStream(ctx)), []byte(file))                    // want `fmt.Fprint writes \[\]byte\(file\) to a richtext stream unescaped`
+	fmt.Fprintf(cli.OutStream(ctx), "%s %v\n", name(file), any(file)) // want `writes name\(file\)` `writes any\(file\)`
+}
+
+func formats(ctx context.Context, format string, args []any) {
+	fmt.Fprintf(cli.OutStream(ctx), format)               // want `fmt.Fprintf writes the format format to a richtext stream unescaped`
+	fmt.Fprintf(cli.ErrStream(ctx), format+"\n", args...) // want `fmt.Fprintf writes the format format \+ "\\n" to a richtext stream unescaped`
+	fmt.Fprintf(cli.OutStream(ctx), richtext.Sprintf("[fg:red]%s[/fg]", format))
+}
+
+func variables(ctx context.Context, file string) {
+	w := cli.OutStream(ctx)
+	fmt.Fprintf(w, "opened %s\n", file) // want `fmt.Fprintf writes file to a richtext stream unescaped`
+
+	var e = cli.ErrStream(ctx)
+	fmt.Fprintln(e, file) // want `fmt.Fprintln writes file to a richtext stream unescaped`
+
+	rw := &richtext.Writer{}
+	fmt.Fprintf(rw, "%s", file) // want `fmt.Fprintf writes file to a richtext stream unescaped`
+}
+
+func safe(ctx context.Context, file string, n int, c count, args []any) {
+	w := cli.OutStream(ctx)
+	fmt.Fprintf(w, "opened %s\n", richtext.Escape(file))
+	fmt.Fprintln(w, richtext.Sprintf("[fg:red]%s[/fg]", file))
+	fmt.Fprintf(w, "%s %d %v\n", greeting, n, c)
+	fmt.Fprintln(w, "[theme:title]done[/theme]", errors.New("constant").Error() == "")
+	fmt.Fprintf(w, "%s %s\n", args...)
+}
+
+func otherWriters(ctx context.Context, file string, out io.Writer) {
+	fmt.Fprintf(os.Stdout, "%s\n", file)
+	fmt.Fprintf(out, "%s\n", file)
+}
@@ -0,0 +1,10 @@
+package cli
+
+import (
+	"context"
+	"io"
+)
+
+func OutStream(ctx context.Context) io.Writer { return nil }
+
+func ErrStream(ctx context.Context) io.Writer { return nil }
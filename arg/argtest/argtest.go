@@ -12,8 +12,9 @@ import (
 )
 
 // Parse parses args into cl, binding both the registered flags and the
-// registered positional and unmatched arguments. It fails the test via
-// [testing.TB.Fatalf] if parsing or binding returns an error.
+// registered positional, unmatched, and passthrough arguments, then enforcing
+// any conditional constraints and running every registered [arg.Validator]. It
+// fails the test via [testing.TB.Fatalf] if any of these steps returns an error.
 func Parse(t testing.TB, cl *arg.CommandLine, args ...string) {
 	t.Helper()
 
@@ -24,6 +25,12 @@ func Parse(t testing.TB, cl *arg.CommandLine, args ...string) {
 	if err := argdef.Bind(ctx, (*argdef.CommandLine)(cl), cl.FlagSet().Args()); err != nil {
 		t.Fatalf("Parse(...): unexpected error: %v", err)
 	}
+	if err := argdef.Enforce((*argdef.CommandLine)(cl)); err != nil {
+		t.Fatalf("Parse(...): unexpected error: %v", err)
+	}
+	if err := argdef.Validate(ctx, (*argdef.CommandLine)(cl)); err != nil {
+		t.Fatalf("Parse(...): unexpected error: %v", err)
+	}
 }
 
 // Flag is a small wrapper around the values assigned to a registered flag, for
@@ -141,3 +148,25 @@ func GetUnmatched(cl *arg.CommandLine) *Unmatched {
 		Required: u.Required,
 	}
 }
+
+// Passthrough is a small wrapper around a registered passthrough binding, for
+// property-testing purposes.
+type Passthrough struct {
+	Name     string
+	Usage    string
+	Required bool
+}
+
+// GetPassthrough returns the [Passthrough] binding registered in cl, or nil if
+// cl has none.
+func GetPassthrough(cl *arg.CommandLine) *Passthrough {
+	p := argdef.GetPassthrough((*argdef.CommandLine)(cl))
+	if p == nil {
+		return nil
+	}
+	return &Passthrough{
+		Name:     p.Name,
+		Usage:    p.Usage,
+		Required: p.Required,
+	}
+}
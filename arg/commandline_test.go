@@ -9,6 +9,7 @@ import (
 
 	"github.com/bitwizeshift/go-cli/arg"
 	"github.com/bitwizeshift/go-cli/arg/argtest"
+	"github.com/bitwizeshift/go-cli/internal/argdef"
 )
 
 func TestCommandLine_Flags(t *testing.T) {
@@ -217,3 +218,39 @@ func TestCommandLine_Groups(t *testing.T) {
 		})
 	}
 }
+
+func TestCommandLine_Reset_ParsesAgain(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	cl := argtest.NewCommandLine()
+	name := "default"
+	var (
+		tags []string
+		pod  string
+	)
+	cl.Add(
+		arg.Flag("name", &name),
+		arg.Flag("tag", &tags),
+		arg.Positional("pod", 0, &pod),
+	)
+	argtest.Parse(t, cl, "--name", "first", "--tag", "a", "web")
+
+	// Act
+	argdef.Reset((*argdef.CommandLine)(cl))
+	argtest.Parse(t, cl, "--tag", "b")
+
+	// Assert
+	if got, want := name, "default"; got != want {
+		t.Errorf("flag name = %q, want %q", got, want)
+	}
+	if got, want := tags, []string{"b"}; !cmp.Equal(got, want) {
+		t.Errorf("flag tag = %v, want %v", got, want)
+	}
+	if got, want := pod, ""; got != want {
+		t.Errorf("positional pod = %q, want %q", got, want)
+	}
+	if got, want := cl.FlagSet().Lookup("name").Changed, false; got != want {
+		t.Errorf("flag name changed = %t, want %t", got, want)
+	}
+}
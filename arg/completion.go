@@ -1,44 +1,55 @@
 package arg
 
 import (
+	"context"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/bitwizeshift/go-cli/internal/argdef"
+	"github.com/bitwizeshift/go-cli/internal/clictx"
 	"github.com/bitwizeshift/go-cli/internal/completion"
+	"github.com/spf13/pflag"
 )
 
-// completerFunc computes shell completion candidates for the partial word
-// toComplete, returning the candidates and a directive describing how the shell
-// should treat them.
-type completerFunc func(toComplete string) ([]string, completion.Directive)
+// completerFunc computes shell completion candidates for a request, returning
+// the candidates and a directive describing how the shell should treat them.
+type completerFunc = completion.Func
 
 // CompleterFunc completes the argument with the candidates returned by fn for the
 // word being completed. File completion is suppressed so only fn's candidates
 // are offered.
 func CompleterFunc(fn func(toComplete string) []string) Option {
-	return completionOption(func(toComplete string) ([]string, completion.Directive) {
-		return fn(toComplete), completion.NoFileComp
+	return completionOption(func(_ context.Context, req completion.Request) ([]string, completion.Directive) {
+		return fn(req.ToComplete), completion.NoFileComp
 	})
 }
 
 // CompleteFrom completes the argument with the members of options that are prefixed
 // by the word being completed. File completion is suppressed so only the given
-// options are offered.
+// options are offered. An argument prompted for by [PromptIfMissing] is chosen
+// from a menu of options.
 func CompleteFrom(options ...string) Option {
-	return completionOption(func(toComplete string) ([]string, completion.Directive) {
+	complete := completionOption(func(_ context.Context, req completion.Request) ([]string, completion.Directive) {
 		var matches []string
 		for _, option := range options {
-			if strings.HasPrefix(option, toComplete) {
+			if strings.HasPrefix(option, req.ToComplete) {
 				matches = append(matches, option)
 			}
 		}
 		return matches, completion.NoFileComp
 	})
+	choices := slices.Clone(options)
+	return option(func(c *config) {
+		complete.apply(c)
+		c.choices = choices
+	})
 }
 
 // CompleteFiles completes the argument with file names, deferring to the shell's
 // default file completion.
 func CompleteFiles() Option {
-	return completionOption(func(string) ([]string, completion.Directive) {
+	return completionOption(func(context.Context, completion.Request) ([]string, completion.Directive) {
 		return nil, completion.Default
 	})
 }
@@ -51,18 +62,173 @@ func CompleteFilesMatching(exts ...string) Option {
 	for i, ext := range exts {
 		normalized[i] = strings.TrimPrefix(ext, ".")
 	}
-	return completionOption(func(string) ([]string, completion.Directive) {
+	return completionOption(func(context.Context, completion.Request) ([]string, completion.Directive) {
 		return normalized, completion.FilterFileExt
 	})
 }
 
 // CompleteDirs completes the argument with directory names only.
 func CompleteDirs() Option {
-	return completionOption(func(string) ([]string, completion.Directive) {
+	return completionOption(func(context.Context, completion.Request) ([]string, completion.Directive) {
 		return nil, completion.FilterDirs
 	})
 }
 
+// Candidate is a completion candidate offered by a [CompleteWith] function.
+type Candidate struct {
+	// Value is the word the shell inserts when the candidate is chosen.
+	Value string
+
+	// Description explains the candidate, and is shown beside it by shells that
+	// support descriptions. It may be empty.
+	Description string
+}
+
+// CompletionContext describes the command line being completed, as seen by a
+// [CompleteWith] function.
+type CompletionContext struct {
+	// ToComplete is the partial word being completed.
+	ToComplete string
+
+	// Args are the positional arguments preceding the word being completed, in
+	// command-line order.
+	Args []string
+
+	// CommandPath is the space-delimited path of the command being completed,
+	// such as "app remote add".
+	CommandPath string
+
+	flags *pflag.FlagSet
+}
+
+// Flag returns the value of the flag named name, and reports whether it was
+// specified, either on the command line typed so far or by one of its
+// fallbacks. An unspecified flag reports its default; an unknown flag reports
+// an empty string.
+func (c CompletionContext) Flag(name string) (string, bool) {
+	if c.flags == nil {
+		return "", false
+	}
+	f := c.flags.Lookup(name)
+	if f == nil {
+		return "", false
+	}
+	return f.Value.String(), argdef.FlagSource(f).Specified()
+}
+
+// CompleteWith completes the argument with the candidates returned by fn. File
+// completion is suppressed so only fn's candidates are offered, and no
+// candidates are offered when fn returns an error.
+//
+// fn receives the context the command would run under, carrying the
+// application's storage, and a [CompletionContext] describing what was typed
+// ahead of the word being completed. Before fn runs, the arguments already
+// typed are resolved exactly as they would be for an invocation -- fallbacks
+// applied and positional arguments bound -- so destinations registered
+// alongside the argument hold their values. The command's builder and runner
+// are never invoked.
+//
+// Candidates are offered as returned; fn is responsible for matching them
+// against [CompletionContext.ToComplete].
+func CompleteWith(fn func(ctx context.Context, c CompletionContext) ([]Candidate, error)) Option {
+	return completionOption(func(ctx context.Context, req completion.Request) ([]string, completion.Directive) {
+		candidates, err := fn(ctx, completionContext(req))
+		if err != nil {
+			return nil, completion.Error
+		}
+		values := make([]string, 0, len(candidates))
+		for _, candidate := range candidates {
+			if candidate.Description == "" {
+				values = append(values, candidate.Value)
+				continue
+			}
+			values = append(values, candidate.Value+"\t"+candidate.Description)
+		}
+		return values, completion.NoFileComp
+	})
+}
+
+// CacheCompletion caches the candidates offered by the argument's completion
+// option under the application's cache storage, so a slow completer is not
+// consulted on every keystroke. It has no effect on an argument without a
+// completion option, or when completing outside of a running application.
+//
+// Candidates are cached under the key returned by keyFunc, which should capture
+// everything the candidates depend on -- a flag selecting a remote, say -- and
+// may be shared by arguments that offer the same candidates. keyFunc must not
+// be nil.
+//
+// Cached candidates are offered as-is for ttl. For a further ttl they are still
+// offered, and are recomputed after the completion is written, for the
+// completions that follow; beyond that they are recomputed before being
+// offered. A completer that fails leaves any cached candidates intact. A runner
+// that changes what would be offered calls [InvalidateCompletion] so the next
+// completion recomputes its candidates.
+//
+// Shells completing concurrently may share a cache entry: each entry is
+// replaced atomically, so a completion never observes a partial write.
+func CacheCompletion(ttl time.Duration, keyFunc func(c CompletionContext) string) Option {
+	if keyFunc == nil {
+		panic("arg: CacheCompletion requires a key function")
+	}
+	return option(func(c *config) {
+		c.cache = &completionCache{ttl: ttl, key: keyFunc}
+	})
+}
+
+// InvalidateCompletion discards the candidates cached by [CacheCompletion]
+// under each of keys, so the next completion recomputes them. It is not an
+// error for no candidates to be cached under a key, or for ctx to carry no
+// application storage.
+func InvalidateCompletion(ctx context.Context, keys ...string) error {
+	app := clictx.Storage(ctx)
+	if app == nil {
+		return nil
+	}
+	return completion.Invalidate(app.Cache, keys...)
+}
+
+// completionCache holds the settings of a [CacheCompletion] option.
+type completionCache struct {
+	ttl time.Duration
+	key func(c CompletionContext) string
+}
+
+// complete returns the argument's completer, wrapped in the cache configured by
+// [CacheCompletion] if one was given. It returns nil when the argument has no
+// completer.
+func (c *config) complete() completerFunc {
+	if c.completer == nil || c.cache == nil {
+		return c.completer
+	}
+	cache := &completion.Cache{
+		Func: c.completer,
+		Key: func(_ context.Context, req completion.Request) string {
+			return c.cache.key(completionContext(req))
+		},
+		TTL: c.cache.ttl,
+		Store: func(ctx context.Context) completion.Store {
+			if app := clictx.Storage(ctx); app != nil {
+				return app.Cache
+			}
+			return nil
+		},
+		Now:        time.Now,
+		Revalidate: completion.Defer,
+	}
+	return cache.Complete
+}
+
+// completionContext describes req as a [CompletionContext].
+func completionContext(req completion.Request) CompletionContext {
+	return CompletionContext{
+		ToComplete:  req.ToComplete,
+		Args:        req.Args,
+		CommandPath: req.CommandPath,
+		flags:       req.Flags,
+	}
+}
+
 // completionOption builds an [Option] that assigns complete as the argument's
 // completer, panicking if a completer was already assigned.
 func completionOption(complete completerFunc) Option {
@@ -1,14 +1,20 @@
 package arg_test
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 
 	"github.com/bitwizeshift/go-cli/arg"
 	"github.com/bitwizeshift/go-cli/arg/argtest"
+	"github.com/bitwizeshift/go-cli/internal/argdef"
+	"github.com/bitwizeshift/go-cli/internal/clictx"
 	"github.com/bitwizeshift/go-cli/internal/completion"
+	"github.com/bitwizeshift/go-cli/internal/storage/storagetest"
 )
 
 // offered is what an argument offers for a word being completed: the candidates it
@@ -29,7 +35,7 @@ func completionOf(t testing.TB, option arg.Option, toComplete string) offered {
 	if complete == nil {
 		t.Fatalf("Add(...) registered no completion function, want one")
 	}
-	candidates, directive := complete(toComplete)
+	candidates, directive := complete(context.Background(), completion.Request{ToComplete: toComplete})
 	return offered{
 		Candidates: candidates,
 		Directive:  directive,
@@ -173,3 +179,211 @@ func TestCompletionOptions_Conflict_Panics(t *testing.T) {
 		})
 	}
 }
+
+func TestCompleteWith(t *testing.T) {
+	t.Parallel()
+
+	errLookup := errors.New("lookup failed")
+
+	testCases := []struct {
+		name       string
+		candidates []arg.Candidate
+		err        error
+		want       offered
+	}{
+		{
+			name:       "ValueOnly",
+			candidates: []arg.Candidate{{Value: "prod"}},
+			want: offered{
+				Candidates: []string{"prod"},
+				Directive:  completion.NoFileComp,
+			},
+		}, {
+			name: "DescriptionFollowsTab",
+			candidates: []arg.Candidate{
+				{Value: "prod", Description: "production cluster"},
+				{Value: "dev"},
+			},
+			want: offered{
+				Candidates: []string{"prod\tproduction cluster", "dev"},
+				Directive:  completion.NoFileComp,
+			},
+		}, {
+			name:       "ErrorOffersNothing",
+			candidates: []arg.Candidate{{Value: "prod"}},
+			err:        errLookup,
+			want: offered{
+				Candidates: nil,
+				Directive:  completion.Error,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			sut := arg.CompleteWith(func(context.Context, arg.CompletionContext) ([]arg.Candidate, error) {
+				return tc.candidates, tc.err
+			})
+
+			// Act
+			offer := completionOf(t, sut, "")
+
+			// Assert
+			if got, want := offer, tc.want; !cmp.Equal(got, want, cmpopts.EquateEmpty()) {
+				t.Errorf("Add(..., option) completion = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestCompleteWith_ReceivesCompletionContext(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+	cl := argtest.NewCommandLine()
+	var cluster, pod string
+	namespace := "default"
+	var got arg.CompletionContext
+	var gotValue any
+	cl.Add(
+		arg.Flag("cluster", &cluster),
+		arg.Flag("namespace", &namespace),
+		arg.Positional("pod", 0, &pod, arg.CompleteWith(func(ctx context.Context, c arg.CompletionContext) ([]arg.Candidate, error) {
+			got, gotValue = c, ctx.Value(ctxKey{})
+			return nil, nil
+		})),
+	)
+	if err := cl.FlagSet().Parse([]string{"--cluster", "prod"}); err != nil {
+		t.Fatalf("Parse(...) = %v, want nil", err)
+	}
+	complete := argdef.PositionalCompletions((*argdef.CommandLine)(cl))[0]
+
+	// Act
+	complete(ctx, completion.Request{
+		ToComplete:  "we",
+		Args:        []string{"first"},
+		Flags:       cl.FlagSet(),
+		CommandPath: "app logs",
+	})
+
+	// Assert
+	if got, want := got.ToComplete, "we"; got != want {
+		t.Errorf("CompletionContext.ToComplete = %q, want %q", got, want)
+	}
+	if got, want := got.Args, []string{"first"}; !cmp.Equal(got, want) {
+		t.Errorf("CompletionContext.Args = %v, want %v", got, want)
+	}
+	if got, want := got.CommandPath, "app logs"; got != want {
+		t.Errorf("CompletionContext.CommandPath = %q, want %q", got, want)
+	}
+	if value, set := got.Flag("cluster"); value != "prod" || !set {
+		t.Errorf("CompletionContext.Flag(cluster) = %q, %t, want %q, %t", value, set, "prod", true)
+	}
+	if value, set := got.Flag("namespace"); value != "default" || set {
+		t.Errorf("CompletionContext.Flag(namespace) = %q, %t, want %q, %t", value, set, "default", false)
+	}
+	if value, set := got.Flag("missing"); value != "" || set {
+		t.Errorf("CompletionContext.Flag(missing) = %q, %t, want %q, %t", value, set, "", false)
+	}
+	if got, want := gotValue, any("value"); got != want {
+		t.Errorf("ctx.Value(...) = %v, want %v", got, want)
+	}
+}
+
+func TestCacheCompletion(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		invalidate []string
+		want       []string
+		wantCalls  int
+	}{
+		{
+			name:      "CachedCandidatesAreOffered",
+			want:      []string{"origin"},
+			wantCalls: 1,
+		}, {
+			name:       "InvalidateRecomputes",
+			invalidate: []string{"remotes"},
+			want:       []string{"origin", "upstream"},
+			wantCalls:  2,
+		}, {
+			name:       "InvalidateOtherKeyKeepsCache",
+			invalidate: []string{"branches"},
+			want:       []string{"origin"},
+			wantCalls:  1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			ctx := clictx.WithStorage(t.Context(), storagetest.NewAppStorage())
+			remotes := []string{"origin"}
+			calls := 0
+			var remote string
+			f := addFlag(argtest.NewCommandLine(), "remote", &remote,
+				arg.CacheCompletion(time.Hour, func(arg.CompletionContext) string { return "remotes" }),
+				arg.CompleterFunc(func(string) []string {
+					calls++
+					return remotes
+				}),
+			)
+			complete := completion.FlagFunc(f.Flag())
+			_, _ = complete(ctx, completion.Request{})
+			remotes = []string{"origin", "upstream"}
+
+			// Act
+			err := arg.InvalidateCompletion(ctx, tc.invalidate...)
+			got, _ := complete(ctx, completion.Request{})
+
+			// Assert
+			if got, want := err, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("InvalidateCompletion(...) = %v, want %v", got, want)
+			}
+			if want := tc.want; !cmp.Equal(got, want) {
+				t.Errorf("complete(...) = %v, want %v", got, want)
+			}
+			if got, want := calls, tc.wantCalls; got != want {
+				t.Errorf("complete(...) called the completer %d times, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestCacheCompletion_NoStorage_CompletesUncached(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	calls := 0
+	var remote string
+	f := addFlag(argtest.NewCommandLine(), "remote", &remote,
+		arg.CompleterFunc(func(string) []string {
+			calls++
+			return []string{"origin"}
+		}),
+		arg.CacheCompletion(time.Hour, func(arg.CompletionContext) string { return "remotes" }),
+	)
+	complete := completion.FlagFunc(f.Flag())
+
+	// Act
+	_, _ = complete(t.Context(), completion.Request{})
+	_, _ = complete(t.Context(), completion.Request{})
+	err := arg.InvalidateCompletion(t.Context(), "remotes")
+
+	// Assert
+	if got, want := calls, 2; got != want {
+		t.Errorf("complete(...) called the completer %d times, want %d", got, want)
+	}
+	if got, want := err, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Errorf("InvalidateCompletion(...) = %v, want %v", got, want)
+	}
+}
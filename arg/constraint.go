@@ -0,0 +1,125 @@
+package arg
+
+import (
+	"github.com/bitwizeshift/go-cli/internal/argdef"
+)
+
+// Ref is an argument that a [Condition] or conditional constraint may refer to.
+// It is implemented by [*FlagArg], [*PositionalArg], [*UnmatchedArg], and
+// [*PassthroughArg].
+type Ref interface {
+	Arg
+	ref() argdef.Ref
+}
+
+func (f *FlagArg) ref() argdef.Ref        { return argdef.Ref{Flag: f.Flag()} }
+func (p *PositionalArg) ref() argdef.Ref  { return argdef.Ref{Positional: p.positional} }
+func (u *UnmatchedArg) ref() argdef.Ref   { return argdef.Ref{Unmatched: u.unmatched} }
+func (p *PassthroughArg) ref() argdef.Ref { return argdef.Ref{Passthrough: p.passthrough} }
+
+var (
+	_ Ref = (*FlagArg)(nil)
+	_ Ref = (*PositionalArg)(nil)
+	_ Ref = (*UnmatchedArg)(nil)
+	_ Ref = (*PassthroughArg)(nil)
+)
+
+// Condition is a predicate over a command's resolved arguments that gates a
+// conditional constraint. Conditions are evaluated once fallbacks have been
+// applied and positional arguments bound, so a value sourced from
+// [DefaultFromEnv] or [DefaultFromFunc] counts as specified.
+type Condition struct {
+	cond argdef.Condition
+}
+
+// IsSet is a [Condition] that holds when r was specified, either on the command
+// line or by a fallback.
+func IsSet(r Ref) Condition {
+	ref := r.ref()
+	return Condition{cond: argdef.Condition{
+		Description: ref.String() + " is specified",
+		Holds: func() bool {
+			_, set := ref.Resolved()
+			return set
+		},
+	}}
+}
+
+// Equals is a [Condition] that holds when the resolved value of r, rendered as
+// a string, is value. An unspecified flag is compared by its default, so
+// Equals(verbose, "false") holds for a bool flag that was never given.
+func Equals(r Ref, value string) Condition {
+	ref := r.ref()
+	return Condition{cond: argdef.Condition{
+		Description: ref.String() + " is " + value,
+		Holds: func() bool {
+			got, _ := ref.Resolved()
+			return got == value
+		},
+	}}
+}
+
+// ConstraintArg is a conditional constraint produced by [RequiredIf],
+// [ExcludedIf], or [Implies]. It is registered on a [CommandLine] with
+// [CommandLine.Add], and is enforced when the command runs, before the command's
+// builder is invoked. Violations are reported as usage errors, and every
+// registered constraint is described in the command's help.
+type ConstraintArg struct {
+	constraint *argdef.Constraint
+}
+
+// RequiredIf constructs a constraint demanding that each of args be specified
+// whenever when holds. For example, a certificate that is only needed with TLS
+// enabled:
+//
+//	arg.RequiredIf(arg.Equals(tls, "true"), tlsCert)
+func RequiredIf(when Condition, args ...Ref) *ConstraintArg {
+	return newConstraintArg(argdef.ConstraintRequired, when, "", args)
+}
+
+// ExcludedIf constructs a constraint demanding that none of args be given
+// whenever when holds. Only a value given on the command line or at a prompt
+// violates it; one supplied by an environment variable or other fallback, which
+// the user may not have meant for this command, does not. For example, an
+// output file that is meaningless for tabular output:
+//
+//	arg.ExcludedIf(arg.Equals(output, "table"), outputFile)
+func ExcludedIf(when Condition, args ...Ref) *ConstraintArg {
+	return newConstraintArg(argdef.ConstraintExcluded, when, "", args)
+}
+
+// Implies constructs a constraint that assigns value to target whenever when
+// holds and target was not otherwise specified. The value is decoded as if it
+// had been given on the command line. For example, forcing implies consent:
+//
+//	arg.Implies(arg.Equals(force, "true"), yes, "true")
+func Implies(when Condition, target Ref, value string) *ConstraintArg {
+	return newConstraintArg(argdef.ConstraintImplies, when, value, []Ref{target})
+}
+
+// newConstraintArg builds a constraint of kind over args.
+func newConstraintArg(kind argdef.ConstraintKind, when Condition, value string, args []Ref) *ConstraintArg {
+	targets := make([]argdef.Ref, 0, len(args))
+	for _, a := range args {
+		targets = append(targets, a.ref())
+	}
+	return &ConstraintArg{constraint: &argdef.Constraint{
+		Kind:    kind,
+		When:    when.cond,
+		Targets: targets,
+		Value:   value,
+	}}
+}
+
+// register records the constraint on cl.
+func (c *ConstraintArg) register(cl *CommandLine) {
+	argdef.AddConstraint((*argdef.CommandLine)(cl), c.constraint)
+}
+
+// String describes the constraint as it appears in help output, such as
+// "--tls-cert is required when --tls is true".
+func (c *ConstraintArg) String() string {
+	return c.constraint.String()
+}
+
+var _ Arg = (*ConstraintArg)(nil)
@@ -0,0 +1,195 @@
+package arg_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/bitwizeshift/go-cli/arg"
+	"github.com/bitwizeshift/go-cli/arg/argtest"
+	"github.com/bitwizeshift/go-cli/internal/argdef"
+)
+
+// tlsArgs is a registrar with conditional constraints between its arguments,
+// mirroring a command that serves over optional TLS.
+type tlsArgs struct {
+	tls    bool
+	cert   string
+	output string
+	file   string
+	force  bool
+	yes    bool
+	dst    string
+}
+
+func (ta *tlsArgs) RegisterArgs(cl *arg.CommandLine) {
+	tls := arg.Flag("tls", &ta.tls)
+	cert := arg.Flag("tls-cert", &ta.cert)
+	output := arg.Flag("output", &ta.output)
+	file := arg.Flag("output-file", &ta.file)
+	force := arg.Flag("force", &ta.force)
+	yes := arg.Flag("yes", &ta.yes)
+	dst := arg.Positional("dst", 0, &ta.dst)
+	cl.Add(
+		tls, cert, output, file, force, yes, dst,
+		arg.RequiredIf(arg.Equals(tls, "true"), cert),
+		arg.ExcludedIf(arg.Equals(output, "table"), file),
+		arg.Implies(arg.Equals(force, "true"), yes, "true"),
+		arg.RequiredIf(arg.IsSet(file), dst),
+	)
+}
+
+// enforce parses and binds args into cl, returning the result of enforcing the
+// conditional constraints registered on it.
+func enforce(t *testing.T, cl *arg.CommandLine, args ...string) error {
+	t.Helper()
+
+	if err := cl.FlagSet().Parse(args); err != nil {
+		t.Fatalf("Parse(...) = %v, want nil", err)
+	}
+	if err := argdef.Bind(t.Context(), (*argdef.CommandLine)(cl), cl.FlagSet().Args()); err != nil {
+		t.Fatalf("Bind(...) = %v, want nil", err)
+	}
+	return argdef.Enforce((*argdef.CommandLine)(cl))
+}
+
+func TestConstraints(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		args    []string
+		wantErr error
+	}{
+		{
+			name:    "NoConditionsHold",
+			args:    nil,
+			wantErr: nil,
+		}, {
+			name:    "RequiredIfSatisfied",
+			args:    []string{"--tls", "--tls-cert", "c.pem"},
+			wantErr: nil,
+		}, {
+			name:    "RequiredIfViolated",
+			args:    []string{"--tls"},
+			wantErr: argdef.ErrRequiredIf,
+		}, {
+			name:    "ExcludedIfViolated",
+			args:    []string{"--output", "table", "--output-file", "out.txt", "dst"},
+			wantErr: argdef.ErrExcludedIf,
+		}, {
+			name:    "ExcludedIfInactive",
+			args:    []string{"--output", "json", "--output-file", "out.txt", "dst"},
+			wantErr: nil,
+		}, {
+			name:    "RequiredIfPositionalViolated",
+			args:    []string{"--output-file", "out.txt"},
+			wantErr: argdef.ErrRequiredIf,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			cl := argtest.NewCommandLine()
+			arg.Register(cl, &tlsArgs{})
+
+			// Act
+			err := enforce(t, cl, tc.args...)
+
+			// Assert
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Errorf("Enforce(...) = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestImplies(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		args []string
+		want bool
+	}{
+		{
+			name: "ConditionHoldsAssignsTarget",
+			args: []string{"--force"},
+			want: true,
+		}, {
+			name: "ConditionInactiveLeavesTarget",
+			args: nil,
+			want: false,
+		}, {
+			name: "ExplicitTargetIsKept",
+			args: []string{"--force", "--yes=false"},
+			want: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			sut := &tlsArgs{}
+			cl := argtest.NewCommandLine()
+			arg.Register(cl, sut)
+
+			// Act
+			argtest.Parse(t, cl, tc.args...)
+
+			// Assert
+			if got, want := sut.yes, tc.want; got != want {
+				t.Errorf("Parse(...) yes = %t, want %t", got, want)
+			}
+		})
+	}
+}
+
+func TestConstraintArg_String(t *testing.T) {
+	t.Parallel()
+
+	var tls bool
+	var cert string
+	tlsFlag := arg.Flag("tls", &tls)
+	certFlag := arg.Flag("tls-cert", &cert)
+
+	testCases := []struct {
+		name string
+		sut  *arg.ConstraintArg
+		want string
+	}{
+		{
+			name: "RequiredIf",
+			sut:  arg.RequiredIf(arg.Equals(tlsFlag, "true"), certFlag),
+			want: "--tls-cert is required when --tls is true",
+		}, {
+			name: "ExcludedIf",
+			sut:  arg.ExcludedIf(arg.IsSet(tlsFlag), certFlag),
+			want: "--tls-cert is not allowed when --tls is specified",
+		}, {
+			name: "Implies",
+			sut:  arg.Implies(arg.IsSet(certFlag), tlsFlag, "true"),
+			want: "--tls defaults to true when --tls-cert is specified",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			got := tc.sut.String()
+
+			// Assert
+			if want := tc.want; got != want {
+				t.Errorf("String() = %q, want %q", got, want)
+			}
+		})
+	}
+}
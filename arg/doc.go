@@ -1,6 +1,6 @@
 // Package arg manages command-line argument registration.
 //
-// Arguments come in three different and distinct forms:
+// Arguments come in four different and distinct forms:
 //
 //   - Flags: these are posix-style long and short flags (e.g. --foo, -f, etc)
 //     and are backed by the [github.com/spf13/pflag] library. These are
@@ -13,6 +13,10 @@
 //     does nat satisfy one of the above two category. These are constructed
 //     with [Unmatched].
 //
+//   - Passthrough: this is always a []string, and contains every argument
+//     following "--", handed verbatim to a command being wrapped. These are
+//     constructed with [Passthrough].
+//
 // All forms of arguments are strongly-typed, are automatically populated as
 // part of command invocations, and offer a variety of settings, including:
 //
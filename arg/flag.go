@@ -44,6 +44,7 @@ func Flag[T any](name string, v *T, options ...FlagOption) *FlagArg {
 		limit = cfg.maxCount
 	}
 	count := 0
+	initial := *v
 	val := &value{
 		set: func(s string) error {
 			if limit > 0 && count >= limit {
@@ -71,6 +72,10 @@ func Flag[T any](name string, v *T, options ...FlagOption) *FlagArg {
 		},
 		str: func() string { return defaultString(v) },
 		typ: func() string { return cfg.typeName(v) },
+		reset: func() {
+			*v = initial
+			count = 0
+		},
 	}
 	return newFlagArg[T](val, name, cfg)
 }
@@ -215,7 +220,7 @@ func flagsOf(fs *pflag.FlagSet) []*FlagArg {
 // newFlagArg builds the underlying [pflag.Flag] for val and applies every
 // configured annotation, without inserting it into any flag set. Insertion is
 // deferred to [FlagArg.register]. It applies the bool bare-flag default for an
-// unnamed bool T.
+// unnamed bool T, or an [Optional] holding one.
 func newFlagArg[T any](val *value, name string, cfg *flagConfig) *FlagArg {
 	f := &pflag.Flag{
 		Name:      name,
@@ -228,7 +233,16 @@ func newFlagArg[T any](val *value, name string, cfg *flagConfig) *FlagArg {
 	if cfg.required {
 		argdef.MarkRequired(f)
 	}
-	if isBuiltin[T]() && reflect.TypeFor[T]().Kind() == reflect.Bool {
+	if cfg.prompt {
+		argdef.MarkPrompt(f)
+	}
+	if cfg.secret {
+		argdef.MarkSecret(f)
+	}
+	if cfg.choices != nil {
+		argdef.SetChoices(f, cfg.choices...)
+	}
+	if isBoolKinded[T]() {
 		f.NoOptDefVal = "true"
 	}
 	for _, env := range cfg.envs {
@@ -237,8 +251,8 @@ func newFlagArg[T any](val *value, name string, cfg *flagConfig) *FlagArg {
 	for _, fn := range cfg.custom {
 		argdef.AddFuncFallback(f, fn)
 	}
-	if cfg.completer != nil {
-		completion.AddFlag(f, cfg.completer)
+	if complete := cfg.complete(); complete != nil {
+		completion.AddFlag(f, complete)
 	}
 	return &FlagArg{flag: f}
 }
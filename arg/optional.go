@@ -0,0 +1,119 @@
+package arg
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/bitwizeshift/go-cli/internal/argdef"
+)
+
+// Source describes where an argument's value was resolved from: the command
+// line, an environment variable, a fallback function, an [Implies] constraint,
+// an interactive prompt, or the argument's default.
+type Source = argdef.Source
+
+// SourceKind identifies the kind of place an argument's value was resolved
+// from.
+type SourceKind = argdef.SourceKind
+
+const (
+	// SourceDefault indicates the argument was never specified, and holds its
+	// default value.
+	SourceDefault = argdef.SourceDefault
+
+	// SourceCommandLine indicates the argument was given on the command line.
+	SourceCommandLine = argdef.SourceCommandLine
+
+	// SourceEnv indicates the argument was supplied by a [DefaultFromEnv]
+	// fallback. The [Source] names the variable that supplied it.
+	SourceEnv = argdef.SourceEnv
+
+	// SourceFunc indicates the argument was supplied by a [DefaultFromFunc]
+	// fallback.
+	SourceFunc = argdef.SourceFunc
+
+	// SourceImplied indicates the argument was assigned by an [Implies]
+	// constraint.
+	SourceImplied = argdef.SourceImplied
+
+	// SourcePrompt indicates the argument was answered at a [PromptIfMissing]
+	// prompt.
+	SourcePrompt = argdef.SourcePrompt
+)
+
+// Optional is an argument destination that records whether a value was ever
+// assigned, distinguishing an argument that was left unspecified from one that
+// was explicitly given its zero value.
+//
+// An Optional is bound like any other destination, and decodes its value as T
+// would be decoded:
+//
+//	var timeout arg.Optional[time.Duration]
+//	cl.Add(arg.Flag("timeout", &timeout))
+//
+// A value supplied by a [DefaultFromEnv] or [DefaultFromFunc] fallback counts
+// as set; where the value came from is reported by cli.Provenance. An
+// Optional[bool] flag may be given bare, as a bool flag may.
+type Optional[T any] struct {
+	value T
+	set   bool
+}
+
+// Some returns an [Optional] holding value.
+func Some[T any](value T) Optional[T] {
+	return Optional[T]{value: value, set: true}
+}
+
+// Get returns the held value, and reports whether one was set.
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.set
+}
+
+// IsSet reports whether a value was set.
+func (o Optional[T]) IsSet() bool {
+	return o.set
+}
+
+// ValueOr returns the held value, or fallback if no value was set.
+func (o Optional[T]) ValueOr(fallback T) T {
+	if o.set {
+		return o.value
+	}
+	return fallback
+}
+
+// String renders the held value, or an empty string if no value was set.
+func (o Optional[T]) String() string {
+	if !o.set {
+		return ""
+	}
+	return fmt.Sprintf("%v", o.value)
+}
+
+// UnmarshalArg decodes data into the held value with [Unmarshal], marking the
+// value as set on success.
+func (o *Optional[T]) UnmarshalArg(data []byte) error {
+	var value T
+	if err := Unmarshal(&value, data); err != nil {
+		return err
+	}
+	o.value, o.set = value, true
+	return nil
+}
+
+// elemType returns the type of the held value.
+func (*Optional[T]) elemType() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+// optional is implemented by every [Optional] instantiation, exposing the type
+// it holds so that the type name and bare-flag behavior follow T.
+type optional interface {
+	elemType() reflect.Type
+}
+
+var (
+	_ Unmarshaler  = (*Optional[int])(nil)
+	_ fmt.Stringer = Optional[int]{}
+	_ optional     = (*Optional[int])(nil)
+)
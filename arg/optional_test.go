@@ -0,0 +1,138 @@
+package arg_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/bitwizeshift/go-cli/arg"
+	"github.com/bitwizeshift/go-cli/arg/argtest"
+)
+
+func TestOptional_Flag(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		args      []string
+		wantValue int
+		wantSet   bool
+	}{
+		{
+			name:      "UnspecifiedIsUnset",
+			args:      nil,
+			wantValue: 0,
+			wantSet:   false,
+		}, {
+			name:      "ExplicitZeroIsSet",
+			args:      []string{"--count", "0"},
+			wantValue: 0,
+			wantSet:   true,
+		}, {
+			name:      "ValueIsDecoded",
+			args:      []string{"--count", "0x10"},
+			wantValue: 16,
+			wantSet:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			var sut arg.Optional[int]
+			cl := argtest.NewCommandLine()
+			cl.Add(arg.Flag("count", &sut))
+
+			// Act
+			argtest.Parse(t, cl, tc.args...)
+
+			// Assert
+			value, set := sut.Get()
+			if got, want := set, tc.wantSet; got != want {
+				t.Errorf("Get() set = %t, want %t", got, want)
+			}
+			if got, want := value, tc.wantValue; got != want {
+				t.Errorf("Get() value = %d, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestOptional_BoolFlagMayBeBare(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	var sut arg.Optional[bool]
+	cl := argtest.NewCommandLine()
+	cl.Add(arg.Flag("verbose", &sut))
+
+	// Act
+	argtest.Parse(t, cl, "--verbose")
+
+	// Assert
+	if got, want := sut, arg.Some(true); !cmp.Equal(got, want, cmp.AllowUnexported(sut)) {
+		t.Errorf("Parse(...) = %v, want %v", got, want)
+	}
+}
+
+func TestOptional_TypeNamedForHeldType(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	var sut arg.Optional[string]
+	cl := argtest.NewCommandLine()
+
+	// Act
+	cl.Add(arg.Flag("name", &sut))
+
+	// Assert
+	if got, want := argtest.AllFlags(cl)[0].Type, "string"; got != want {
+		t.Errorf("Flag(...) type = %q, want %q", got, want)
+	}
+}
+
+func TestOptional_Positional(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	var sut arg.Optional[string]
+	cl := argtest.NewCommandLine()
+	cl.Add(arg.Positional("dst", 0, &sut))
+
+	// Act
+	argtest.Parse(t, cl, "out")
+
+	// Assert
+	if got, want := sut.ValueOr("fallback"), "out"; got != want {
+		t.Errorf("ValueOr(...) = %q, want %q", got, want)
+	}
+}
+
+func TestOptional_ValueOr(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		sut  arg.Optional[string]
+		want string
+	}{
+		{name: "UnsetUsesFallback", sut: arg.Optional[string]{}, want: "fallback"},
+		{name: "SetUsesValue", sut: arg.Some(""), want: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			got := tc.sut.ValueOr("fallback")
+
+			// Assert
+			if want := tc.want; got != want {
+				t.Errorf("ValueOr(...) = %q, want %q", got, want)
+			}
+		})
+	}
+}
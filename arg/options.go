@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/bitwizeshift/go-cli/internal/argdef"
 	"github.com/bitwizeshift/go-cli/internal/strcase"
 	"github.com/spf13/pflag"
 )
@@ -13,9 +14,12 @@ import (
 // typeToName converts the underlying object's value to a pflag "type" name
 // by converting the Go reflect-API's type-identifier to a kebab-case. This
 // includes the package the object comes from in the name, so `mips.OpCode`
-// will become `mips-op-code`.
+// will become `mips-op-code`. An [Optional] is named for the type it holds.
 func typeToName(v any) string {
 	rt := reflect.TypeOf(v)
+	if o, ok := v.(optional); ok {
+		rt = o.elemType()
+	}
 	for rt.Kind() == reflect.Pointer {
 		rt = rt.Elem()
 	}
@@ -62,6 +66,14 @@ type config struct {
 	// required marks the argument as one that must be supplied.
 	required bool
 
+	// prompt asks for a required argument left without a value, and secret
+	// marks the value as sensitive.
+	prompt bool
+	secret bool
+
+	// choices is the fixed set of values the argument is chosen from.
+	choices []string
+
 	// Fallbacks
 
 	envs   []string
@@ -69,6 +81,7 @@ type config struct {
 
 	// Shell completion.
 	completer completerFunc
+	cache     *completionCache
 }
 
 type flagConfig struct {
@@ -128,6 +141,29 @@ func Required() Option {
 	return option(func(c *config) { c.required = true })
 }
 
+// PromptIfMissing asks for a [Required] flag or positional argument at an
+// interactive prompt when it is left without a value once its fallbacks have
+// been consulted, rather than failing the command. An argument with a
+// [CompleteFrom] option is chosen from a menu of its options, and a [Secret]
+// one is read without echoing it. The answer is decoded and validated as
+// though it had been given on the command line.
+//
+// A prompt is not shown when input was disabled with --no-input, when running
+// under continuous integration, or when standard input or the error stream
+// prompts are written to is not a terminal; a missing argument then fails the
+// command as it would without this option. It has no effect on an argument
+// that is not required.
+func PromptIfMissing() Option {
+	return option(func(c *config) { c.prompt = true })
+}
+
+// Secret marks the argument as holding a sensitive value, such as a password
+// or token. It is read without echo when prompted for by [PromptIfMissing],
+// and masked wherever resolved arguments are shown.
+func Secret() Option {
+	return option(func(c *config) { c.secret = true })
+}
+
 // ValueLabel overrides the reported flag value name, bypassing the default
 // kebab-case name derived from the Go type. This is used to control what
 // appears in help usage, in the form of `--flag=<value label>`.
@@ -272,16 +308,21 @@ func invokeCallback(fn reflect.Value, arg reflect.Value) error {
 
 // value is a closure-backed [pflag.Value] used by [Flag].
 type value struct {
-	set func(string) error
-	str func() string
-	typ func() string
+	set   func(string) error
+	str   func() string
+	typ   func() string
+	reset func()
 }
 
 func (v *value) Set(s string) error { return v.set(s) }
 func (v *value) String() string     { return v.str() }
 func (v *value) Type() string       { return v.typ() }
+func (v *value) Reset()             { v.reset() }
 
-var _ pflag.Value = (*value)(nil)
+var (
+	_ pflag.Value     = (*value)(nil)
+	_ argdef.Resetter = (*value)(nil)
+)
 
 // isBuiltin reports whether T is a predeclared or composite type (such as bool
 // or []string) rather than a defined type such as `type Foo []string`. Defined
@@ -290,6 +331,16 @@ func isBuiltin[T any]() bool {
 	return reflect.TypeFor[T]().PkgPath() == ""
 }
 
+// isBoolKinded reports whether T is a builtin bool, or an [Optional] holding
+// one. Such flags may be given bare, with no value.
+func isBoolKinded[T any]() bool {
+	rt := reflect.TypeFor[T]()
+	if o, ok := any(new(T)).(optional); ok {
+		rt = o.elemType()
+	}
+	return rt.PkgPath() == "" && rt.Kind() == reflect.Bool
+}
+
 // appendInto appends the elements of add onto the slice addressed by dst.
 func appendInto[T any](dst *T, add T) {
 	sv := reflect.ValueOf(dst).Elem()
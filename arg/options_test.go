@@ -926,6 +926,59 @@ func TestRequired(t *testing.T) {
 	}
 }
 
+func TestPromptOptions(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		options     []arg.FlagOption
+		wantPrompt  bool
+		wantSecret  bool
+		wantChoices []string
+	}{
+		{
+			name:       "PromptIfMissingMarksFlagPrompted",
+			options:    []arg.FlagOption{arg.Required(), arg.PromptIfMissing()},
+			wantPrompt: true,
+		}, {
+			name:       "SecretMarksFlagSecret",
+			options:    []arg.FlagOption{arg.Secret()},
+			wantSecret: true,
+		}, {
+			name:        "CompleteFromRecordsChoices",
+			options:     []arg.FlagOption{arg.CompleteFrom("dev", "prod")},
+			wantChoices: []string{"dev", "prod"},
+		}, {
+			name:    "DefaultMarksNothing",
+			options: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			cl := argtest.NewCommandLine()
+			var dst string
+
+			// Act
+			f := addFlag(cl, "flag", &dst, tc.options...)
+
+			// Assert
+			if got, want := argdef.IsPrompt(f.Flag()), tc.wantPrompt; got != want {
+				t.Errorf("Add(...) prompt = %t, want %t", got, want)
+			}
+			if got, want := argdef.IsSecret(f.Flag()), tc.wantSecret; got != want {
+				t.Errorf("Add(...) secret = %t, want %t", got, want)
+			}
+			if got, want := argdef.Choices(f.Flag()), tc.wantChoices; !cmp.Equal(got, want) {
+				t.Errorf("Add(...) choices = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
 func TestDefaultFromEnv(t *testing.T) {
 	// Arrange
 	cl := argtest.NewCommandLine()
@@ -0,0 +1,50 @@
+package arg
+
+import (
+	"github.com/bitwizeshift/go-cli/internal/argdef"
+)
+
+// PassthroughArg is a passthrough binding produced by [Passthrough]. It is
+// registered on a [CommandLine] with [CommandLine.Add].
+type PassthroughArg struct {
+	passthrough *argdef.Passthrough
+}
+
+// Passthrough constructs a binding for the arguments a command hands verbatim
+// to a command it wraps, assigning them to out in command-line order when the
+// command runs. The returned [PassthroughArg] is registered on a [CommandLine]
+// with [CommandLine.Add].
+//
+// The binding claims every argument following "--", which are never parsed as
+// flags:
+//
+//	app exec -- kubectl get pods -o yaml
+//
+// A command whose specification sets "interspersed: false" stops parsing flags
+// at its first positional argument; there the binding also claims every
+// argument beyond those the [Positional] bindings claim, so the "--" may be
+// omitted. Arguments before the passthrough are left to the [Positional] and
+// [Unmatched] bindings.
+//
+// Only the [Usage] and [Required] options apply; a required binding fails the
+// command when no argument is handed to it.
+func Passthrough(name string, out *[]string, options ...Option) *PassthroughArg {
+	cfg := newConfig(options...)
+	return &PassthroughArg{passthrough: &argdef.Passthrough{
+		Name:     name,
+		Usage:    cfg.usage,
+		Required: cfg.required,
+		Set: func(values []string) error {
+			*out = values
+			return nil
+		},
+	}}
+}
+
+// register records the passthrough binding on cl. It panics if cl already
+// carries one.
+func (p *PassthroughArg) register(cl *CommandLine) {
+	argdef.SetPassthrough((*argdef.CommandLine)(cl), p.passthrough)
+}
+
+var _ Arg = (*PassthroughArg)(nil)
@@ -0,0 +1,130 @@
+package arg_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/bitwizeshift/go-cli/arg"
+	"github.com/bitwizeshift/go-cli/arg/argtest"
+	"github.com/bitwizeshift/go-cli/internal/argdef"
+)
+
+// execArgs is a registrar for a command that runs a wrapped command against a
+// target, in the manner of "app exec <target> -- <cmd> [args...]".
+type execArgs struct {
+	verbose bool
+	target  string
+	command []string
+}
+
+func (ea *execArgs) RegisterArgs(cl *arg.CommandLine) {
+	cl.Add(
+		arg.Flag("verbose", &ea.verbose),
+		arg.Positional("target", 0, &ea.target),
+		arg.Passthrough("cmd", &ea.command, arg.Usage("the command to run")),
+	)
+}
+
+func TestPassthrough(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		noIntersperse bool
+		args          []string
+		want          execArgs
+	}{
+		{
+			name: "ArgumentsAfterDashAreVerbatim",
+			args: []string{"--verbose", "prod", "--", "kubectl", "get", "pods", "-o", "yaml"},
+			want: execArgs{
+				verbose: true,
+				target:  "prod",
+				command: []string{"kubectl", "get", "pods", "-o", "yaml"},
+			},
+		}, {
+			name: "FlagsMayFollowPositionals",
+			args: []string{"prod", "--verbose", "--", "ls"},
+			want: execArgs{
+				verbose: true,
+				target:  "prod",
+				command: []string{"ls"},
+			},
+		}, {
+			name:          "NoIntersperseStopsAtFirstPositional",
+			noIntersperse: true,
+			args:          []string{"--verbose", "prod", "kubectl", "--verbose"},
+			want: execArgs{
+				verbose: true,
+				target:  "prod",
+				command: []string{"kubectl", "--verbose"},
+			},
+		}, {
+			name: "NoneGivenLeavesEmpty",
+			args: []string{"prod"},
+			want: execArgs{
+				target: "prod",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			sut := &execArgs{}
+			cl := argtest.NewCommandLine()
+			argdef.SetInterspersed((*argdef.CommandLine)(cl), !tc.noIntersperse)
+			arg.Register(cl, sut)
+
+			// Act
+			argtest.Parse(t, cl, tc.args...)
+
+			// Assert
+			if got, want := *sut, tc.want; !cmp.Equal(got, want, cmp.AllowUnexported(execArgs{}), cmpopts.EquateEmpty()) {
+				t.Errorf("Parse(...) mismatch (-want +got):\n%s", cmp.Diff(want, got, cmp.AllowUnexported(execArgs{}), cmpopts.EquateEmpty()))
+			}
+		})
+	}
+}
+
+func TestPassthrough_Registers(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	var command []string
+	cl := argtest.NewCommandLine()
+
+	// Act
+	cl.Add(arg.Passthrough("cmd", &command, arg.Usage("the command to run"), arg.Required()))
+
+	// Assert
+	want := &argtest.Passthrough{Name: "cmd", Usage: "the command to run", Required: true}
+	if got := argtest.GetPassthrough(cl); !cmp.Equal(got, want) {
+		t.Errorf("GetPassthrough(...) = %+v, want %+v", got, want)
+	}
+}
+
+func TestPassthrough_AddedTwice_Panics(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	var command []string
+	cl := argtest.NewCommandLine()
+	cl.Add(arg.Passthrough("cmd", &command))
+
+	// Act
+	panicked := func() (panicked bool) {
+		defer func() { panicked = recover() != nil }()
+		cl.Add(arg.Passthrough("other", &command))
+		return
+	}()
+
+	// Assert
+	if got, want := panicked, true; got != want {
+		t.Errorf("Add(...) panicked = %t, want %t", got, want)
+	}
+}
@@ -20,12 +20,14 @@ type PositionalArg struct {
 // Positional arguments are drawn from the command line after flags are parsed.
 // If no argument occupies index when the command runs, v is left unchanged.
 // Marking the argument [Required] instead demands that the command line reach
-// index, so v is always assigned before the command runs.
+// index, so v is always assigned before the command runs. [PromptIfMissing]
+// relaxes that demand, asking for the value instead when it can.
 //
 // By default the value is decoded with [Unmarshal] and reports a kebab-case type
 // name derived from T; both may be adjusted with [Option] values.
 func Positional[T any](name string, index int, v *T, options ...Option) *PositionalArg {
 	cfg := newConfig(options...)
+	initial := *v
 	fallbackFuncs := make([]argdef.FallbackFunc, 0, len(cfg.custom))
 	for _, f := range cfg.custom {
 		fallbackFuncs = append(fallbackFuncs, f)
@@ -35,9 +37,13 @@ func Positional[T any](name string, index int, v *T, options ...Option) *Positio
 		Name:          name,
 		Usage:         cfg.usage,
 		Required:      cfg.required,
-		Complete:      cfg.completer,
+		Prompt:        cfg.prompt,
+		Secret:        cfg.secret,
+		Choices:       cfg.choices,
+		Complete:      cfg.complete(),
 		EnvFallbacks:  cfg.envs,
 		FuncFallbacks: fallbackFuncs,
+		Reset:         func() { *v = initial },
 		Set: func(s string) error {
 			var tmp T
 			if err := cfg.set(&tmp, []byte(s)); err != nil {
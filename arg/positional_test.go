@@ -31,7 +31,7 @@ func positionalCompletionOf(t testing.TB, cl *arg.CommandLine, index int, toComp
 	if !ok {
 		t.Fatalf("Add(...) registered no completion at index %d, want one", index)
 	}
-	candidates, directive := fn(toComplete)
+	candidates, directive := fn(context.Background(), completion.Request{ToComplete: toComplete})
 	return offered{
 		Candidates: candidates,
 		Directive:  directive,
@@ -1,6 +1,7 @@
 package arg
 
 import (
+	"context"
 	"reflect"
 	"strings"
 	"unsafe"
@@ -17,6 +18,19 @@ type Registrar interface {
 	RegisterArgs(cl *CommandLine)
 }
 
+// Validator is implemented by [Registrar] types that check their arguments
+// once every argument of the command has been resolved.
+//
+// ValidateArgs is called on each registered Validator after fallbacks have been
+// applied, positional arguments bound, and conditional constraints enforced,
+// but before the command's builder is invoked. This is the place for checks
+// across several arguments, such as a range whose bounds must be ordered. An
+// error returned from ValidateArgs is reported as a usage error, so the
+// command's usage is shown alongside it.
+type Validator interface {
+	ValidateArgs(ctx context.Context) error
+}
+
 // Register adds all arguments associated to v into cl.
 //
 // If v implements [Registrar] directly, it will be registered immediately.
@@ -26,6 +40,10 @@ type Registrar interface {
 // for whether it implements [Registrar], and any discovered fields will be
 // registered.
 //
+// Every registered [Registrar] that also implements [Validator] is recorded on
+// cl, after any registrars it registers in turn, so that components are
+// validated before the registrars composed of them.
+//
 // Note:
 // If an object implements [Registrar] and contains fields of other types
 // that may be [Registrar] types, the object is responsible for manually
@@ -69,6 +87,9 @@ func register(cl *CommandLine, rv reflect.Value, rt reflect.Type) {
 				visited[id] = struct{}{}
 			}
 			registrar.RegisterArgs(cl)
+			if validator, ok := registrar.(Validator); ok {
+				argdef.AddValidator((*argdef.CommandLine)(cl), validator.ValidateArgs)
+			}
 			return
 		}
 		kind := rt.Kind()
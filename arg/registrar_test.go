@@ -1,6 +1,8 @@
 package arg_test
 
 import (
+	"context"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -9,6 +11,7 @@ import (
 
 	"github.com/bitwizeshift/go-cli/arg"
 	"github.com/bitwizeshift/go-cli/arg/argtest"
+	"github.com/bitwizeshift/go-cli/internal/argdef"
 )
 
 // boolFlag is a [arg.Registrar] that registers a single bool flag identified
@@ -239,3 +242,78 @@ func TestRegister_UniqueInstances(t *testing.T) {
 		})
 	}
 }
+
+// rangeArgs is a [arg.Registrar] and [arg.Validator] whose bounds must be
+// ordered, recording each validation into log under name.
+type rangeArgs struct {
+	name     string
+	min, max int
+	log      *[]string
+	children []*rangeArgs
+}
+
+func (r *rangeArgs) RegisterArgs(cl *arg.CommandLine) {
+	cl.Add(
+		arg.Flag(r.name+"-min", &r.min),
+		arg.Flag(r.name+"-max", &r.max),
+	)
+	for _, child := range r.children {
+		arg.Register(cl, child)
+	}
+}
+
+func (r *rangeArgs) ValidateArgs(context.Context) error {
+	*r.log = append(*r.log, r.name)
+	if r.min > r.max {
+		return fmt.Errorf("--%s-min %d exceeds --%s-max %d", r.name, r.min, r.name, r.max)
+	}
+	return nil
+}
+
+var (
+	_ arg.Registrar = (*rangeArgs)(nil)
+	_ arg.Validator = (*rangeArgs)(nil)
+)
+
+func TestRegister_Validator_ChildrenValidatedFirst(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	var log []string
+	child := &rangeArgs{name: "child", log: &log}
+	sut := &rangeArgs{name: "parent", log: &log, children: []*rangeArgs{child, child}}
+	cl := argtest.NewCommandLine()
+
+	// Act
+	arg.Register(cl, sut)
+	argtest.Parse(t, cl)
+
+	// Assert
+	if got, want := log, []string{"child", "parent"}; !cmp.Equal(got, want) {
+		t.Errorf("ValidateArgs order = %v, want %v", got, want)
+	}
+}
+
+func TestRegister_Validator_ReportsError(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	var log []string
+	sut := &rangeArgs{name: "port", log: &log}
+	cl := argtest.NewCommandLine()
+	arg.Register(cl, sut)
+	if err := cl.FlagSet().Parse([]string{"--port-min", "10", "--port-max", "1"}); err != nil {
+		t.Fatalf("Parse(...) = %v, want nil", err)
+	}
+
+	// Act
+	err := argdef.Validate(t.Context(), (*argdef.CommandLine)(cl))
+
+	// Assert
+	if err == nil {
+		t.Fatalf("Validate(...) = nil, want error")
+	}
+	if got, want := err.Error(), "--port-min 10 exceeds --port-max 1"; got != want {
+		t.Errorf("Validate(...) = %q, want %q", got, want)
+	}
+}
@@ -32,7 +32,7 @@ func Unmatched[T any](name string, out *[]T, options ...Option) *UnmatchedArg {
 		Name:          name,
 		Usage:         cfg.usage,
 		Required:      cfg.required,
-		Complete:      cfg.completer,
+		Complete:      cfg.complete(),
 		EnvFallbacks:  cfg.envs,
 		FuncFallbacks: fallbackFuncs,
 		Set: func(values []string) error {
@@ -30,7 +30,7 @@ func unmatchedCompletionOf(t testing.TB, cl *arg.CommandLine, toComplete string)
 	if fn == nil {
 		t.Fatalf("Add(...) registered no unmatched completion, want one")
 	}
-	candidates, directive := fn(toComplete)
+	candidates, directive := fn(context.Background(), completion.Request{ToComplete: toComplete})
 	return offered{
 		Candidates: candidates,
 		Directive:  directive,
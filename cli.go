@@ -29,7 +29,9 @@ import (
 //
 // If the [Builder] type optionally implements [arg.Registrar], the flags will
 // be added to the overall command's construction -- which is what enables the
-// composition of flag-based factories.
+// composition of flag-based factories. Checks across several arguments belong
+// in [arg.Validator] rather than in Build, so that a failure is reported as a
+// usage error alongside the command's usage.
 type Builder interface {
 	// Build takes the application context and attempts to construct a [Runner].
 	Build(ctx context.Context) (Runner, error)
@@ -70,10 +72,13 @@ type CLI struct {
 func FromReader(r io.Reader, options ...Option) *CLI {
 	cfg := newConfig(options...)
 	cmd, err := spec.Build(r, spec.Options{
-		Builders: cfg.builders,
-		Theme:    cfg.theme,
-		Colour:   cfg.colour,
-		Version:  cfg.buildVersion,
+		Builders:   cfg.builders,
+		Theme:      cfg.theme,
+		Colour:     cfg.colour,
+		Background: cfg.background,
+		Version:    cfg.buildVersion,
+		Shell:      cfg.shell,
+		Prompt:     cfg.prompt,
 		Update: spec.UpdateOptions{
 			Version:   cfg.buildVersion,
 			Source:    cfg.buildSource,
@@ -121,7 +126,11 @@ func (c *CLI) Run(ctx context.Context) exit.Code {
 
 // Execute runs the application against a background context and terminates the
 // process with the resulting [ExitCode]. It does not return.
+//
+// Since the process exits at once, stale cached completions offered to a shell
+// are recomputed by a detached copy of the process, so the shell is not kept
+// waiting for them; [CLI.Run] recomputes them before it returns.
 func (c *CLI) Execute() {
-	ctx := context.Background()
+	ctx := spec.WithDetachedRevalidation(context.Background())
 	c.Run(ctx).Exit()
 }
@@ -171,6 +171,14 @@ func TestFromReader_StyleOptions_Build(t *testing.T) {
 			name:    "ForceColour",
 			options: []cli.Option{cli.ForceColour()},
 		},
+		{
+			name:    "LightBackground",
+			options: []cli.Option{cli.LightBackground()},
+		},
+		{
+			name:    "DarkBackground",
+			options: []cli.Option{cli.DarkBackground()},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -231,6 +239,46 @@ func TestFromReader_ConflictingColourOptions_Panics(t *testing.T) {
 	}
 }
 
+func TestFromReader_ConflictingBackgroundOptions_Panics(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		options []cli.Option
+	}{
+		{
+			name:    "LightThenDark",
+			options: []cli.Option{cli.LightBackground(), cli.DarkBackground()},
+		},
+		{
+			name:    "DarkThenLight",
+			options: []cli.Option{cli.DarkBackground(), cli.LightBackground()},
+		},
+		{
+			name:    "LightTwice",
+			options: []cli.Option{cli.LightBackground(), cli.LightBackground()},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			recovered := recoverPanic(func() {
+				cli.FromBytes([]byte("name: root\n"), tc.options...)
+			})
+
+			// Assert
+			const substr = "background already set"
+			message, _ := recovered.(string)
+			if got, want := strings.Contains(message, substr), true; got != want {
+				t.Fatalf("recovered panic = %q, want to contain %q", message, substr)
+			}
+		})
+	}
+}
+
 func TestFromReader_InvalidSizeOptions_Panics(t *testing.T) {
 	t.Parallel()
 
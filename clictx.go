@@ -4,9 +4,19 @@ import (
 	"context"
 	"io"
 
+	"github.com/bitwizeshift/go-cli/arg"
+	"github.com/bitwizeshift/go-cli/internal/argdef"
 	"github.com/bitwizeshift/go-cli/internal/clictx"
+	"github.com/bitwizeshift/go-cli/internal/session"
 )
 
+// InStream returns the input reader from the application's context. Prompts
+// made through [github.com/bitwizeshift/go-cli/prompt.From] read from it too,
+// so a runner should read from it rather than [os.Stdin] directly.
+func InStream(ctx context.Context) io.Reader {
+	return clictx.Input(ctx)
+}
+
 // OutStream returns the output writer from the application's context.
 func OutStream(ctx context.Context) io.Writer {
 	stdout, _ := clictx.Writers(ctx)
@@ -25,3 +35,45 @@ func ErrStream(ctx context.Context) io.Writer {
 func StreamColumns(ctx context.Context, w io.Writer) int {
 	return clictx.Columns(ctx, w)
 }
+
+// Provenance reports where the value of the running command's argument name was
+// resolved from: the command line, an environment variable such as
+// "env APP_TOKEN", a fallback function, an implication, or the default. Flags
+// are named by their long name, and positional arguments by the name they are
+// shown under in help.
+//
+// It reports false when ctx carries no running command, or the command has no
+// argument called name.
+func Provenance(ctx context.Context, name string) (arg.Source, bool) {
+	cl := clictx.Args(ctx)
+	if cl == nil {
+		return arg.Source{}, false
+	}
+	ref, ok := argdef.Lookup(cl, name)
+	if !ok {
+		return arg.Source{}, false
+	}
+	return ref.Source(), true
+}
+
+// Session is a store of values shared by every command run from one
+// interactive shell, with cleanup that runs when the shell exits. See
+// [EnableShell].
+type Session = session.Session
+
+// SessionFrom returns the [Session] carried by the application's context, or
+// nil when the running command was not started from an interactive shell.
+func SessionFrom(ctx context.Context) *Session {
+	return clictx.Session(ctx)
+}
+
+// Interactive reports whether the running command may prompt the user. It is
+// false when input was disabled with --no-input, when running under continuous
+// integration (CI=true), or when standard input or standard error, where
+// prompts are written, is not a terminal; a prompt made then fails with
+// [github.com/bitwizeshift/go-cli/prompt.ErrNonInteractive].
+//
+// It reports true when ctx carries no running command.
+func Interactive(ctx context.Context) bool {
+	return clictx.InteractionPolicy(ctx).Interactive
+}
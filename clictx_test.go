@@ -4,12 +4,16 @@ import (
 	"bytes"
 	"context"
 	"io"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/pflag"
 
 	"github.com/bitwizeshift/go-cli"
+	"github.com/bitwizeshift/go-cli/internal/argdef"
 	"github.com/bitwizeshift/go-cli/internal/clictx"
+	"github.com/bitwizeshift/go-cli/internal/session"
 	"github.com/bitwizeshift/go-cli/internal/term"
 )
 
@@ -19,6 +23,22 @@ var sameWriter = cmp.Comparer(func(lhs, rhs io.Writer) bool {
 	return lhs == rhs
 })
 
+func TestInStream(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	stdin := strings.NewReader("input")
+	ctx := clictx.WithInput(context.Background(), stdin)
+
+	// Act
+	reader := cli.InStream(ctx)
+
+	// Assert
+	if got, want := reader, io.Reader(stdin); got != want {
+		t.Errorf("InStream(ctx) = %v, want %v", got, want)
+	}
+}
+
 func TestOutStream(t *testing.T) {
 	t.Parallel()
 
@@ -67,3 +87,136 @@ func TestStreamColumns(t *testing.T) {
 		t.Errorf("StreamColumns(ctx) = %v, want %v", got, want)
 	}
 }
+
+func TestProvenance(t *testing.T) {
+	t.Setenv("PROVENANCE_TOKEN", "secret")
+
+	// Arrange
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	fs.String("token", "", "")
+	fs.String("name", "", "")
+	fs.String("unused", "", "")
+	argdef.AddEnvFallback(fs.Lookup("token"), "PROVENANCE_TOKEN")
+	cl := argdef.FromFlagSet(fs)
+	argdef.AddPositional(cl, &argdef.Positional{Name: "src", Set: func(string) error { return nil }})
+	if err := fs.Parse([]string{"--name", "bob", "file.txt"}); err != nil {
+		t.Fatalf("Parse(...) = %v, want nil", err)
+	}
+	if err := argdef.SetFlagFallbacks(context.Background(), fs); err != nil {
+		t.Fatalf("SetFlagFallbacks(...) = %v, want nil", err)
+	}
+	if err := argdef.Bind(context.Background(), cl, fs.Args()); err != nil {
+		t.Fatalf("Bind(...) = %v, want nil", err)
+	}
+	ctx := clictx.WithArgs(context.Background(), cl)
+
+	testCases := []struct {
+		name   string
+		arg    string
+		want   string
+		wantOK bool
+	}{
+		{name: "CommandLineFlag", arg: "name", want: "command line", wantOK: true},
+		{name: "EnvFlag", arg: "token", want: "env PROVENANCE_TOKEN", wantOK: true},
+		{name: "DefaultFlag", arg: "unused", want: "default", wantOK: true},
+		{name: "Positional", arg: "src", want: "command line", wantOK: true},
+		{name: "Unknown", arg: "nope", want: "default", wantOK: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			source, ok := cli.Provenance(ctx, tc.arg)
+
+			// Assert
+			if got, want := ok, tc.wantOK; got != want {
+				t.Fatalf("Provenance(ctx, %q) ok = %t, want %t", tc.arg, got, want)
+			}
+			if got, want := source.String(), tc.want; got != want {
+				t.Errorf("Provenance(ctx, %q) = %q, want %q", tc.arg, got, want)
+			}
+		})
+	}
+}
+
+func TestProvenance_NoRunningCommand(t *testing.T) {
+	t.Parallel()
+
+	// Act
+	_, ok := cli.Provenance(context.Background(), "token")
+
+	// Assert
+	if got, want := ok, false; got != want {
+		t.Errorf("Provenance(ctx, ...) ok = %t, want %t", got, want)
+	}
+}
+
+func TestSessionFrom(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		sess *cli.Session
+	}{
+		{name: "InShell", sess: session.New()},
+		{name: "OutsideShell", sess: nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			ctx := context.Background()
+			if tc.sess != nil {
+				ctx = clictx.WithSession(ctx, tc.sess)
+			}
+
+			// Act
+			got := cli.SessionFrom(ctx)
+
+			// Assert
+			if want := tc.sess; got != want {
+				t.Errorf("SessionFrom(ctx) = %p, want %p", got, want)
+			}
+		})
+	}
+}
+
+func TestInteractive(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		ctx  context.Context
+		want bool
+	}{
+		{
+			name: "Interactive",
+			ctx:  clictx.WithInteraction(context.Background(), clictx.Interaction{Interactive: true}),
+			want: true,
+		}, {
+			name: "NotInteractive",
+			ctx:  clictx.WithInteraction(context.Background(), clictx.Interaction{}),
+			want: false,
+		}, {
+			name: "OutsideCommand",
+			ctx:  context.Background(),
+			want: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			got := cli.Interactive(tc.ctx)
+
+			// Assert
+			if want := tc.want; got != want {
+				t.Errorf("Interactive(ctx) = %t, want %t", got, want)
+			}
+		})
+	}
+}
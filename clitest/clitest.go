@@ -1,6 +1,7 @@
 package clitest
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"io"
@@ -56,6 +57,17 @@ func WithWriters(ctx context.Context, stdout, stderr io.Writer) context.Context
 	return clictx.WithWriters(ctx, stdout, stderr)
 }
 
+// WithInput returns a context whose input stream, as returned by
+// [cli.InStream], reads from in. Prompts made through the context read their
+// replies from it too, as from a terminal that is not interactive.
+//
+// The stream is buffered once, unless it is already a [bufio.Reader], so
+// the input that one prompt reads ahead is left for the next prompt, or for a
+// read from [cli.InStream], rather than lost.
+func WithInput(ctx context.Context, in io.Reader) context.Context {
+	return clictx.WithInput(ctx, bufio.NewReader(in))
+}
+
 // WithStorage returns a context carrying in-memory application storage, along
 // with the [cli.AppStorage] handle backed by it. The roots are isolated from
 // one another and from the real filesystem; data written through the returned
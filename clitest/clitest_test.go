@@ -1,11 +1,15 @@
 package clitest_test
 
 import (
+	"bufio"
 	"context"
+	"io"
+	"strings"
 	"testing"
 
 	"github.com/bitwizeshift/go-cli"
 	"github.com/bitwizeshift/go-cli/clitest"
+	"github.com/bitwizeshift/go-cli/prompt"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 )
@@ -46,3 +50,95 @@ func TestWithStorage_PopulatesContext(t *testing.T) {
 		t.Errorf("StorageFrom(ctx) present = %t, want %t", got, want)
 	}
 }
+
+func TestWithInput_AnswersPrompts(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	ctx, output := clitest.WithCaptureWriters(context.Background())
+	ctx = clitest.WithInput(ctx, strings.NewReader("bob\n"))
+
+	// Act
+	name, err := prompt.Line(ctx, "[theme:label]Name:[/theme] ")
+
+	// Assert
+	if got, want := err, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Fatalf("prompt.Line(...) = %v, want %v", got, want)
+	}
+	if got, want := name, "bob"; got != want {
+		t.Errorf("prompt.Line(...) = %q, want %q", got, want)
+	}
+	if got, want := output.Stderr.String(), "Name: "; got != want {
+		t.Errorf("stderr = %q, want %q", got, want)
+	}
+	if got, want := output.Stdout.String(), ""; got != want {
+		t.Errorf("stdout = %q, want %q", got, want)
+	}
+}
+
+func TestWithInput_AnswersConsecutivePrompts(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	ctx, _ := clitest.WithCaptureWriters(context.Background())
+	ctx = clitest.WithInput(ctx, strings.NewReader("alice\ny\nrest"))
+
+	// Act
+	name, lineErr := prompt.Line(ctx, "Name: ")
+	ok, confirmErr := prompt.Confirm(ctx, "Continue?")
+	rest, readErr := io.ReadAll(cli.InStream(ctx))
+
+	// Assert
+	if got, want := lineErr, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Fatalf("prompt.Line(...) = %v, want %v", got, want)
+	}
+	if got, want := confirmErr, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Fatalf("prompt.Confirm(...) = %v, want %v", got, want)
+	}
+	if got, want := readErr, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Fatalf("io.ReadAll(cli.InStream(ctx)) = %v, want %v", got, want)
+	}
+	if got, want := name, "alice"; got != want {
+		t.Errorf("prompt.Line(...) = %q, want %q", got, want)
+	}
+	if got, want := ok, true; got != want {
+		t.Errorf("prompt.Confirm(...) = %t, want %t", got, want)
+	}
+	if got, want := string(rest), "rest"; got != want {
+		t.Errorf("io.ReadAll(cli.InStream(ctx)) = %q, want %q", got, want)
+	}
+}
+
+func TestWithInput_SetsInStream(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	in := strings.NewReader("data")
+
+	// Act
+	ctx := clitest.WithInput(context.Background(), in)
+
+	// Assert
+	data, err := io.ReadAll(cli.InStream(ctx))
+	if got, want := err, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Fatalf("io.ReadAll(cli.InStream(ctx)) = %v, want %v", got, want)
+	}
+	if got, want := string(data), "data"; got != want {
+		t.Errorf("io.ReadAll(cli.InStream(ctx)) = %q, want %q", got, want)
+	}
+}
+
+func TestWithInput_KeepsBufferedReader(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	in := bufio.NewReader(strings.NewReader("data"))
+
+	// Act
+	ctx := clitest.WithInput(context.Background(), in)
+
+	// Assert
+	if got, want := cli.InStream(ctx), io.Reader(in); got != want {
+		t.Errorf("cli.InStream(ctx) = %v, want %v", got, want)
+	}
+}
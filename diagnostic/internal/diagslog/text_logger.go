@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"net/url"
+	"path/filepath"
 	"strings"
 
 	"github.com/bitwizeshift/go-cli/internal/format"
@@ -72,7 +74,7 @@ func (h *TextHandler) Handle(_ context.Context, r slog.Record) error {
 			h.w,
 			"  %s %s\n",
 			accentFmt.Format("-->"),
-			linkFmt.Format("%s", location),
+			linkFmt.Format("%s", fileLink(diagnostic.File, location)),
 		)
 	}
 	if title != "" && message != "" && title != message {
@@ -139,6 +141,26 @@ func (h *TextHandler) formatLocation(file string, lineStart int64, lineEnd int64
 	}
 	return b.String()
 }
+
+// fileLink wraps text in a link to the file at path, resolved against the
+// working directory, so terminals that render hyperlinks can open it. It
+// returns text unchanged when path is empty or cannot be resolved.
+func fileLink(path, text string) string {
+	if path == "" {
+		return text
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return text
+	}
+	abs = filepath.ToSlash(abs)
+	if !strings.HasPrefix(abs, "/") {
+		abs = "/" + abs
+	}
+	target := url.URL{Scheme: "file", Path: abs}
+	return fmt.Sprintf("[link:%s]%s[/link]", target.String(), text)
+}
+
 func (h *TextHandler) firstNonEmpty(values ...string) string {
 	for _, v := range values {
 		if v != "" {
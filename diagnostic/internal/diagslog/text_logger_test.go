@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"log/slog"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -57,6 +58,16 @@ func rawTag(s string) string {
 	return "[richtext:off]" + s + "[/richtext]"
 }
 
+// fileLinkTag renders the richtext link markup the handler emits around a
+// location in file, mirroring the handler's internal fileLink.
+func fileLinkTag(file, s string) string {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		panic(err)
+	}
+	return "[link:file://" + filepath.ToSlash(abs) + "]" + s + "[/link]"
+}
+
 // idHeader renders the leading "severity[id]" header markup for a diagnostic
 // that carries an ID, styled with the given severity theme.
 func idHeader(severityTheme, severityText, id string) string {
@@ -158,7 +169,7 @@ func TestTextHandler_Handle_Location(t *testing.T) {
 
 	base := idHeader("error", "error", "E1") + ": t\n"
 	locLine := func(loc string) string {
-		return "  " + themeTag("error", "-->") + " " + themeTag("url", loc) + "\n"
+		return "  " + themeTag("error", "-->") + " " + themeTag("url", fileLinkTag("a.cpp", loc)) + "\n"
 	}
 
 	testCases := []struct {
@@ -53,11 +53,11 @@ func (*lineRunner) Run(ctx context.Context) error {
 	return nil
 }
 
-// confirmRunner backs "confirm" and reads a yes/no answer.
+// confirmRunner backs "confirm" and reads a yes/no answer, defaulting to yes.
 type confirmRunner struct{}
 
 func (*confirmRunner) Run(ctx context.Context) error {
-	ok, err := prompt.Confirm(ctx, "Do you want to continue?")
+	ok, err := prompt.Confirm(ctx, "Do you want to continue?", prompt.Default(true))
 	if err != nil {
 		return err
 	}
@@ -78,14 +78,15 @@ func (*secretRunner) Run(ctx context.Context) error {
 }
 
 // valueRunner backs each "value <type>" subcommand, reading a value of type T
-// via prompt.Value and printing the parsed result.
+// via prompt.Value and printing the parsed result. An unparseable reply is
+// re-prompted for up to three times.
 type valueRunner[T any] struct {
 	label string
 }
 
 func (r *valueRunner[T]) Run(ctx context.Context) error {
 	var value T
-	if err := prompt.Value(ctx, r.label, &value); err != nil {
+	if err := prompt.Value(ctx, r.label, &value, prompt.MaxAttempts(3)); err != nil {
 		return err
 	}
 	fmt.Printf("value = %v\n", value)
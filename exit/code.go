@@ -13,6 +13,8 @@ import (
 	"runtime"
 	"strconv"
 	"syscall"
+
+	"github.com/bitwizeshift/go-cli/internal/interact"
 )
 
 // Code is a process exit status produced by running a [cli.CLI].
@@ -145,13 +147,18 @@ var _ Classifier = (FallbackClassifier)(nil)
 
 // POSIXClassifier is a [Classifier] that translates errors produced by the
 // standard library into the sysexits.h POSIX [Code] that most closely describes
-// them.
+// them. A prompt that failed with
+// [github.com/bitwizeshift/go-cli/prompt.ErrNonInteractive] is classified as
+// [CodeUsage], since the command needed input it was told not to ask for.
 var POSIXClassifier = ClassifierFunc(classifyError)
 
 func classifyError(err error) Code {
 	if err == nil {
 		return CodeSuccess
 	}
+	if errors.Is(err, interact.ErrNonInteractive) {
+		return CodeUsage
+	}
 	if code := classifySentinel(err); code != CodeUnknown {
 		return code
 	}
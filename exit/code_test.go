@@ -15,6 +15,7 @@ import (
 	"testing"
 
 	"github.com/bitwizeshift/go-cli/exit"
+	"github.com/bitwizeshift/go-cli/prompt"
 	"github.com/google/go-cmp/cmp"
 )
 
@@ -151,6 +152,10 @@ func TestPOSIXClassifier(t *testing.T) {
 			name: "SystemError",
 			err:  fmt.Errorf("mmap: %w", syscall.ENOMEM),
 			want: exit.CodeOSErr,
+		}, {
+			name: "NonInteractivePrompt",
+			err:  fmt.Errorf("asking for name: %w", prompt.ErrNonInteractive),
+			want: exit.CodeUsage,
 		},
 	}
 
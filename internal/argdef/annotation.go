@@ -45,6 +45,10 @@ const (
 	// AnnotationIssueURL is the pflag annotation for assigning a single flag's
 	// issue URL for filing bugs.
 	AnnotationIssueURL = "annotation://cli.cmd_issue_url"
+
+	// AnnotationCompactUsage is the cobra annotation holding the compact usage
+	// line a command falls back to when its full synopsis is too wide to show.
+	AnnotationCompactUsage = "annotation://cli.cmd_compact_usage"
 )
 
 // groupSeparator joins the members of a constraint group into a single stable
@@ -120,14 +124,16 @@ func OneRequired(f *pflag.Flag) []string {
 }
 
 // ConfigureFlags walks through all flags to convert our local annotations into
-// cobra's mechanism for upholding the flag requirements.
+// cobra's mechanism for upholding the flag requirements. A required flag that
+// may be prompted for is left to be checked once the command runs; see
+// [Missing].
 func ConfigureFlags(cmd *cobra.Command) {
 	requiredTogether := map[string][]string{}
 	mutuallyExclusive := map[string][]string{}
 	oneRequired := map[string][]string{}
 
 	cmd.Flags().VisitAll(func(f *pflag.Flag) {
-		if IsRequired(f) {
+		if IsRequired(f) && !IsPrompt(f) {
 			// The flag is guaranteed to exist since it is being visited from
 			// this same set, so this error cannot occur.
 			_ = cmd.MarkFlagRequired(f.Name)
@@ -174,6 +180,21 @@ func addIssueURL(cmd *cobra.Command, issueURL string, visited map[*cobra.Command
 	}
 }
 
+// SetCompactUsage records use as the usage line cmd falls back to when its
+// [cobra.Command.Use] is too wide to show.
+func SetCompactUsage(cmd *cobra.Command, use string) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[AnnotationCompactUsage] = use
+}
+
+// CompactUsage returns the usage line cmd falls back to when its
+// [cobra.Command.Use] is too wide to show, or an empty string if it has none.
+func CompactUsage(cmd *cobra.Command) string {
+	return cmd.Annotations[AnnotationCompactUsage]
+}
+
 // setAnnotation assigns value as the sole value of key on f, initializing the
 // annotation map if necessary.
 func setAnnotation(f *pflag.Flag, key, value string) {
@@ -270,7 +291,8 @@ func AddFuncFallback(f *pflag.Flag, fallback FallbackFunc) {
 }
 
 // SetFlagFallbacks goes through all unset flags and sets fallback values that
-// come from either the Environment, or through fallback functions.
+// come from either the Environment, or through fallback functions. The source
+// of each assigned value is recorded, and is reported by [FlagSource].
 //
 // Failures encountered while setting fallbacks are joined and returned together.
 // Every flag is visited and computed in this process.
@@ -280,6 +302,7 @@ func SetFlagFallbacks(ctx context.Context, fs *pflag.FlagSet) error {
 		if f.Changed {
 			return
 		}
+		delete(f.Annotations, AnnotationSource)
 		visited, err := runEnvFlagFallback(f)
 		errs = append(errs, err)
 		if visited {
@@ -301,7 +324,9 @@ func runEnvFlagFallback(f *pflag.Flag) (visited bool, err error) {
 			visited = true
 			if err != nil {
 				err = fmt.Errorf("%w: $%v: %w", ErrSettingEnvFlag, key, err)
+				return
 			}
+			setFlagSource(f, Source{Kind: SourceEnv, Env: key})
 			return
 		}
 	}
@@ -331,7 +356,9 @@ func runFuncFlagFallback(ctx context.Context, f *pflag.Flag) (visited bool, err
 		visited = true
 		if err != nil {
 			err = fmt.Errorf("%w: %w", ErrSettingFuncFlag, err)
+			return
 		}
+		setFlagSource(f, Source{Kind: SourceFunc})
 		return
 	}
 	return false, nil
@@ -227,6 +227,7 @@ func TestConfigureFlags(t *testing.T) {
 	testCases := []struct {
 		name              string
 		required          []string
+		prompt            []string
 		requiredTogether  []string
 		mutuallyExclusive []string
 		oneRequired       []string
@@ -250,6 +251,13 @@ func TestConfigureFlags(t *testing.T) {
 			args:     []string{"--a"},
 			wantErr:  nil,
 		},
+		{
+			name:     "RequiredPromptedIsLeftUnchecked",
+			required: []string{"a"},
+			prompt:   []string{"a"},
+			args:     nil,
+			wantErr:  nil,
+		},
 		{
 			name:             "RequiredTogetherPartialFails",
 			requiredTogether: []string{"a", "b"},
@@ -301,6 +309,7 @@ func TestConfigureFlags(t *testing.T) {
 			// Arrange
 			cmd := newCommand()
 			argdef.MarkRequired(lookupAll(cmd.Flags(), tc.required)...)
+			argdef.MarkPrompt(lookupAll(cmd.Flags(), tc.prompt)...)
 			argdef.MarkRequiredTogether(lookupAll(cmd.Flags(), tc.requiredTogether)...)
 			argdef.MarkMutuallyExclusive(lookupAll(cmd.Flags(), tc.mutuallyExclusive)...)
 			argdef.MarkOneRequired(lookupAll(cmd.Flags(), tc.oneRequired)...)
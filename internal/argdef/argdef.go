@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"unsafe"
 
 	"github.com/bitwizeshift/go-cli/internal/arity"
@@ -25,12 +26,20 @@ var (
 	// ErrSettingFuncFlag indicates that a value produced by a fallback function
 	// could not be assigned to its flag.
 	ErrSettingFuncFlag = errors.New("setting flag custom default")
+
+	// ErrMissingPassthrough indicates that a required [Passthrough] binding was
+	// given no arguments.
+	ErrMissingPassthrough = errors.New("missing passthrough arguments")
 )
 
 // FallbackFunc computes a fallback default for an arg that was not set
 // during the CLI invocation, returning the value to assign or an error.
 type FallbackFunc = func(ctx context.Context) (string, error)
 
+// ValidateFunc checks the resolved arguments of a command line, returning an
+// error describing any problem found.
+type ValidateFunc = func(ctx context.Context) error
+
 // CommandLine is the opaque command-line destination threaded through
 // registration.
 //
@@ -40,6 +49,13 @@ type CommandLine struct {
 	visited     map[unsafe.Pointer]struct{}
 	positionals []*Positional
 	unmatched   *Unmatched
+	passthrough *Passthrough
+	constraints []*Constraint
+	validators  []ValidateFunc
+
+	// interspersed reports whether flags may follow positional arguments, as
+	// opposed to flag parsing stopping at the first positional.
+	interspersed bool
 }
 
 // Positional is a registered positional-argument binding.
@@ -52,12 +68,31 @@ type Positional struct {
 	// Required marks the argument as one the command cannot run without.
 	Required bool
 
+	// Prompt marks a required argument as one that may be prompted for when
+	// it is left without a value.
+	Prompt bool
+
+	// Secret marks the argument as holding a sensitive value.
+	Secret bool
+
+	// Choices is the fixed set of values the argument is chosen from, or nil
+	// when it may hold any value.
+	Choices []string
+
 	EnvFallbacks  []string
 	FuncFallbacks []FallbackFunc
 
 	// Complete offers shell-completion candidates for this argument, or is nil
 	// when the argument offers none.
 	Complete completion.Func
+
+	// Reset restores the destination to the value it held when the binding was
+	// constructed, or is nil when there is nothing to restore.
+	Reset func()
+
+	// value and source record the argument as resolved by the last [Bind].
+	value  string
+	source Source
 }
 
 // Unmatched is a registered binding for every argument not claimed by a
@@ -76,6 +111,26 @@ type Unmatched struct {
 	// Complete offers shell-completion candidates for every argument index no
 	// [Positional] claims, or is nil when the binding offers none.
 	Complete completion.Func
+
+	// values and source record the binding as resolved by the last [Bind].
+	values []string
+	source Source
+}
+
+// Passthrough is a registered binding for the arguments handed verbatim to a
+// wrapped command: every argument following "--", or, when flag parsing stops
+// at the first positional, every argument beyond those the positionals claim.
+type Passthrough struct {
+	Name  string
+	Usage string
+	Set   func(values []string) error
+
+	// Required marks the binding as one that must claim at least one argument.
+	Required bool
+
+	// values and source record the binding as resolved by the last [Bind].
+	values []string
+	source Source
 }
 
 // New returns a newly constructed [CommandLine]. This is to enable creating
@@ -88,7 +143,7 @@ func New() *CommandLine {
 // FromFlagSet constructs a [CommandLine] from a [pflag.FlagSet]. This is used in
 // the real CLI construction.
 func FromFlagSet(flags *pflag.FlagSet) *CommandLine {
-	return &CommandLine{flags: flags, visited: map[unsafe.Pointer]struct{}{}}
+	return &CommandLine{flags: flags, visited: map[unsafe.Pointer]struct{}{}, interspersed: true}
 }
 
 // The below functions exist so that other exported packages are able to access
@@ -146,6 +201,83 @@ func GetUnmatched(reg *CommandLine) *Unmatched {
 	return reg.unmatched
 }
 
+// SetPassthrough records p as the passthrough binding on reg.
+//
+// It panics if reg already carries a passthrough binding, since the arguments
+// a second binding would claim are already spoken for.
+func SetPassthrough(reg *CommandLine, p *Passthrough) {
+	if reg.passthrough != nil {
+		panic("arg: passthrough argument bound more than once")
+	}
+	reg.passthrough = p
+}
+
+// GetPassthrough returns the passthrough binding registered on reg, or nil if
+// none was registered.
+func GetPassthrough(reg *CommandLine) *Passthrough {
+	return reg.passthrough
+}
+
+// SetInterspersed selects whether flags may follow positional arguments on
+// reg. When they may not, flag parsing stops at the first positional argument
+// and every argument after it is left for the positional bindings, so flags
+// meant for a wrapped command need no "--" to reach it.
+func SetInterspersed(reg *CommandLine, interspersed bool) {
+	reg.interspersed = interspersed
+	reg.flags.SetInterspersed(interspersed)
+}
+
+// Interspersed reports whether flags may follow positional arguments on reg.
+func Interspersed(reg *CommandLine) bool {
+	return reg.interspersed
+}
+
+// Split divides the positional arguments of a parsed command line into the
+// operands claimed by the positional and unmatched bindings, and the arguments
+// claimed by the [Passthrough] binding. Without a passthrough binding every
+// argument is an operand.
+//
+// The passthrough arguments are those following "--". If no "--" was given and
+// flag parsing stops at the first positional, they are instead the arguments
+// beyond those the positionals claim.
+func Split(reg *CommandLine, args []string) (operands, passthrough []string) {
+	if reg.passthrough == nil {
+		return args, nil
+	}
+	at := len(args)
+	if dash := reg.flags.ArgsLenAtDash(); dash >= 0 {
+		at = min(dash, len(args))
+	} else if !reg.interspersed {
+		at = min(positionalWidth(reg), len(args))
+	}
+	return args[:at], args[at:]
+}
+
+// InPassthrough reports whether the argument following args, which is the one
+// being completed, would be claimed by the [Passthrough] binding registered on
+// reg. reg must have parsed the flags preceding args.
+func InPassthrough(reg *CommandLine, args []string) bool {
+	_, rest := Split(reg, append(args[:len(args):len(args)], ""))
+	return len(rest) > 0
+}
+
+// AddValidator records validate as a check to run over the resolved arguments
+// of reg, as performed by [Validate].
+func AddValidator(reg *CommandLine, validate ValidateFunc) {
+	reg.validators = append(reg.validators, validate)
+}
+
+// Validate runs every check recorded on reg by [AddValidator], in the order
+// they were recorded, and must run once reg's arguments are resolved. Every
+// check runs; their failures are joined and returned together.
+func Validate(ctx context.Context, reg *CommandLine) error {
+	var errs []error
+	for _, validate := range reg.validators {
+		errs = append(errs, validate(ctx))
+	}
+	return errors.Join(errs...)
+}
+
 // VerifyPositionals checks that the positional arguments registered on reg
 // claim a contiguous run of indices starting at zero. Two bindings may share an
 // index, but an index no binding claims leaves an argument slot that can never
@@ -180,11 +312,27 @@ func VerifyPositionals(reg *CommandLine) {
 // a required [Unmatched] binding demands one argument beyond those the
 // positionals claim. The count is capped at the positionals registered, unless
 // an [Unmatched] binding claims the remaining arguments.
+//
+// A required positional that may be prompted for demands nothing, since it is
+// left to be resolved once the command runs; see [Missing].
 func Arity(reg *CommandLine) arity.Arity {
+	return arityOf(reg, false)
+}
+
+// RequiredArity behaves as [Arity], except that every required positional
+// demands that the command line reach its index, including one that may be
+// prompted for.
+func RequiredArity(reg *CommandLine) arity.Arity {
+	return arityOf(reg, true)
+}
+
+// arityOf returns the positional-argument counts reg permits, counting a
+// required positional that may be prompted for only when strict is set.
+func arityOf(reg *CommandLine, strict bool) arity.Arity {
 	width := positionalWidth(reg)
 	low := 0
 	for _, p := range reg.positionals {
-		if p.Required {
+		if p.Required && (strict || !p.Prompt) {
 			low = max(low, p.Index+1)
 		}
 	}
@@ -217,10 +365,11 @@ func UnmatchedCompletion(reg *CommandLine) completion.Func {
 	return reg.unmatched.Complete
 }
 
-// Bind assigns args to the positional and unmatched bindings registered on reg.
-// A [Positional] whose index falls outside args is skipped, leaving its
-// destination unchanged. Every argument not claimed by a [Positional] is passed,
-// in command-line order, to an [Unmatched] binding.
+// Bind assigns args to the bindings registered on reg. args are first divided
+// by [Split], and those claimed by a [Passthrough] binding are passed to it
+// verbatim. Of the rest, a [Positional] whose index falls outside them is
+// skipped, leaving its destination unchanged, and every argument not claimed by
+// a [Positional] is passed, in command-line order, to an [Unmatched] binding.
 //
 // A binding left without a value falls back to the first of its environment
 // variables that is set, then to the first of its fallback functions that
@@ -230,16 +379,23 @@ func UnmatchedCompletion(reg *CommandLine) completion.Func {
 //
 // It returns the first error reported by a binding.
 func Bind(ctx context.Context, reg *CommandLine, args []string) error {
+	args, rest := Split(reg, args)
+	if reg.passthrough != nil {
+		if err := bindPassthrough(reg.passthrough, rest); err != nil {
+			return err
+		}
+	}
 	claimed := make(map[int]struct{})
 	for _, p := range reg.positionals {
+		p.value, p.source = "", Source{}
 		if p.Index < 0 || p.Index >= len(args) {
-			if _, err := setFallback(ctx, p.EnvFallbacks, p.FuncFallbacks, p.Set); err != nil {
+			if _, err := setFallback(ctx, p.EnvFallbacks, p.FuncFallbacks, p.assign); err != nil {
 				return err
 			}
 			continue
 		}
 		claimed[p.Index] = struct{}{}
-		if err := p.Set(args[p.Index]); err != nil {
+		if err := p.assign(args[p.Index], Source{Kind: SourceCommandLine}); err != nil {
 			return err
 		}
 	}
@@ -249,6 +405,62 @@ func Bind(ctx context.Context, reg *CommandLine, args []string) error {
 	return bindUnmatched(ctx, reg.unmatched, unclaimed(args, claimed))
 }
 
+// Resetter is implemented by a flag value that can restore the value it held
+// when the flag was constructed, forgetting every occurrence since.
+type Resetter interface {
+	Reset()
+}
+
+// Reset returns every argument registered on reg to the state it held before
+// the command line was first parsed, so that reg can be parsed and bound again:
+// flags as described by [ResetFlags], and positional destinations restored.
+func Reset(reg *CommandLine) {
+	ResetFlags(reg.flags)
+	for _, p := range reg.positionals {
+		p.value, p.source = "", Source{}
+		if p.Reset != nil {
+			p.Reset()
+		}
+	}
+	if reg.unmatched != nil {
+		reg.unmatched.values, reg.unmatched.source = nil, Source{}
+	}
+	if reg.passthrough != nil {
+		reg.passthrough.values, reg.passthrough.source = nil, Source{}
+	}
+}
+
+// ResetFlags returns every flag in flags to its default, marking it unchanged
+// and forgetting where a fallback or implication sourced its value from. A
+// value implementing [Resetter] is reset; any other value is set from the
+// flag's default, with a [pflag.SliceValue] replaced by the elements of its
+// default rather than appended to.
+func ResetFlags(flags *pflag.FlagSet) {
+	flags.VisitAll(func(f *pflag.Flag) {
+		switch value := f.Value.(type) {
+		case Resetter:
+			value.Reset()
+		case pflag.SliceValue:
+			_ = value.Replace(sliceDefault(f.DefValue))
+		default:
+			// The default was rendered by the value itself, so it parses back.
+			_ = value.Set(f.DefValue)
+		}
+		f.Changed = false
+		delete(f.Annotations, AnnotationSource)
+	})
+}
+
+// sliceDefault splits the default of a slice flag, rendered as "[a,b]", into
+// its elements.
+func sliceDefault(def string) []string {
+	def = strings.TrimSuffix(strings.TrimPrefix(def, "["), "]")
+	if def == "" {
+		return nil
+	}
+	return strings.Split(def, ",")
+}
+
 // unclaimed returns the arguments whose index is absent from claimed, in
 // command-line order.
 func unclaimed(args []string, claimed map[int]struct{}) []string {
@@ -265,64 +477,95 @@ func unclaimed(args []string, claimed map[int]struct{}) []string {
 // bindUnmatched assigns rest to u, sourcing a fallback set instead when no
 // argument went unclaimed.
 func bindUnmatched(ctx context.Context, u *Unmatched, rest []string) error {
+	u.values, u.source = nil, Source{}
 	if len(rest) == 0 {
-		visited, err := setFallback(ctx, u.EnvFallbacks, u.FuncFallbacks, u.setFields)
-		if visited || err != nil {
+		source, err := setFallback(ctx, u.EnvFallbacks, u.FuncFallbacks, u.setFields)
+		if source.Specified() || err != nil {
 			return err
 		}
+		return u.Set(rest)
 	}
-	return u.Set(rest)
+	return u.assign(rest, Source{Kind: SourceCommandLine})
+}
+
+// bindPassthrough assigns rest to p, failing if p is required and rest is
+// empty.
+func bindPassthrough(p *Passthrough, rest []string) error {
+	p.values, p.source = nil, Source{}
+	if len(rest) == 0 {
+		if p.Required {
+			return fmt.Errorf("%w: <%s>", ErrMissingPassthrough, p.Name)
+		}
+		return p.Set(rest)
+	}
+	return p.assign(rest, Source{Kind: SourceCommandLine})
+}
+
+// assign sets p to values, recording source as where they came from.
+func (p *Passthrough) assign(values []string, source Source) error {
+	p.values, p.source = values, source
+	return p.Set(values)
+}
+
+// assign sets p to value, recording source as where it came from.
+func (p *Positional) assign(value string, source Source) error {
+	p.value, p.source = value, source
+	return p.Set(value)
+}
+
+// assign sets u to values, recording source as where they came from.
+func (u *Unmatched) assign(values []string, source Source) error {
+	u.values, u.source = values, source
+	return u.Set(values)
 }
 
 // setFields assigns value to u as the comma-separated fields it holds.
-func (u *Unmatched) setFields(value string) error {
+func (u *Unmatched) setFields(value string, source Source) error {
 	fields, err := csvfield.Split(value)
 	if err != nil {
 		return err
 	}
-	return u.Set(fields)
+	return u.assign(fields, source)
 }
 
 // setFallback assigns the first available fallback value to set, preferring an
-// environment variable over a fallback function. It reports whether a fallback
-// supplied a value.
-func setFallback(ctx context.Context, envs []string, funcs []FallbackFunc, set func(string) error) (visited bool, err error) {
-	visited, err = envFallback(envs, set)
-	if visited || err != nil {
-		return visited, err
+// environment variable over a fallback function. It reports the source that
+// supplied a value, which is [SourceDefault] when none did.
+func setFallback(ctx context.Context, envs []string, funcs []FallbackFunc, set func(string, Source) error) (Source, error) {
+	source, err := envFallback(envs, set)
+	if source.Specified() || err != nil {
+		return source, err
 	}
 	return funcFallback(ctx, funcs, set)
 }
 
-func envFallback(envs []string, set func(string) error) (visited bool, err error) {
+func envFallback(envs []string, set func(string, Source) error) (Source, error) {
 	for _, key := range envs {
 		if value, ok := os.LookupEnv(key); ok && value != "" {
-			err = set(value)
-			visited = true
-			if err != nil {
-				err = fmt.Errorf("%w: $%v: %w", ErrSettingEnvFlag, key, err)
+			source := Source{Kind: SourceEnv, Env: key}
+			if err := set(value, source); err != nil {
+				return source, fmt.Errorf("%w: $%v: %w", ErrSettingEnvFlag, key, err)
 			}
-			return
+			return source, nil
 		}
 	}
-	return false, nil
+	return Source{}, nil
 }
 
-func funcFallback(ctx context.Context, funcs []FallbackFunc, set func(string) error) (visited bool, err error) {
+func funcFallback(ctx context.Context, funcs []FallbackFunc, set func(string, Source) error) (Source, error) {
+	source := Source{Kind: SourceFunc}
 	for _, fn := range funcs {
 		value, ferr := fn(ctx)
 		if ferr != nil {
-			return true, fmt.Errorf("%w: %w", ErrComputingFuncFlag, ferr)
+			return source, fmt.Errorf("%w: %w", ErrComputingFuncFlag, ferr)
 		}
 		if value == "" {
 			continue
 		}
-		err = set(value)
-		visited = true
-		if err != nil {
-			err = fmt.Errorf("%w: %w", ErrSettingFuncFlag, err)
+		if err := set(value, source); err != nil {
+			return source, fmt.Errorf("%w: %w", ErrSettingFuncFlag, err)
 		}
-		return
+		return source, nil
 	}
-	return
+	return Source{}, nil
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/spf13/pflag"
 
 	"github.com/bitwizeshift/go-cli/internal/argdef"
 	"github.com/bitwizeshift/go-cli/internal/completion"
@@ -559,14 +560,14 @@ func candidateOf(fn completion.Func) string {
 	if fn == nil {
 		return ""
 	}
-	candidates, _ := fn("")
+	candidates, _ := fn(context.Background(), completion.Request{})
 	return candidates[0]
 }
 
 // completerOf returns a completion function offering candidate alone, so that a
 // collected completion can be identified by the value it returns.
 func completerOf(candidate string) completion.Func {
-	return func(string) ([]string, completion.Directive) {
+	return func(context.Context, completion.Request) ([]string, completion.Directive) {
 		return []string{candidate}, completion.NoFileComp
 	}
 }
@@ -577,7 +578,7 @@ func completerOf(candidate string) completion.Func {
 func candidatesOf(fns map[int]completion.Func) map[int]string {
 	result := map[int]string{}
 	for index, fn := range fns {
-		candidates, _ := fn("")
+		candidates, _ := fn(context.Background(), completion.Request{})
 		result[index] = candidates[0]
 	}
 	return result
@@ -751,6 +752,14 @@ func TestArity(t *testing.T) {
 			},
 			unmatched: &argdef.Unmatched{Required: true},
 			want:      "at least 3 arguments",
+		}, {
+			name: "PromptedPositionalIsNotDemanded",
+			positionals: []*argdef.Positional{
+				{Index: 0, Required: true},
+				{Index: 1, Required: true, Prompt: true},
+			},
+			unmatched: nil,
+			want:      "between 1 and 2 arguments",
 		},
 	}
 
@@ -778,6 +787,57 @@ func TestArity(t *testing.T) {
 	}
 }
 
+func TestRequiredArity(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		positionals []*argdef.Positional
+		want        string
+	}{
+		{
+			name: "OptionalPositionalIsNotDemanded",
+			positionals: []*argdef.Positional{
+				{Index: 0},
+			},
+			want: "at most 1 argument",
+		}, {
+			name: "RequiredPositionalIsDemanded",
+			positionals: []*argdef.Positional{
+				{Index: 0, Required: true},
+			},
+			want: "exactly 1 argument",
+		}, {
+			name: "PromptedPositionalIsDemanded",
+			positionals: []*argdef.Positional{
+				{Index: 0, Required: true},
+				{Index: 1, Required: true, Prompt: true},
+			},
+			want: "exactly 2 arguments",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			cl := argdef.New()
+			for _, p := range tc.positionals {
+				argdef.AddPositional(cl, p)
+			}
+
+			// Act
+			permitted := argdef.RequiredArity(cl)
+
+			// Assert
+			if got, want := permitted.String(), tc.want; !cmp.Equal(got, want) {
+				t.Errorf("RequiredArity(...) = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
 func TestVerifyPositionals(t *testing.T) {
 	t.Parallel()
 
@@ -838,3 +898,234 @@ func TestVerifyPositionals(t *testing.T) {
 		})
 	}
 }
+
+func TestBind_Passthrough(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name            string
+		noIntersperse   bool
+		args            []string
+		wantBound       map[int]string
+		wantUnmatched   []string
+		wantPassthrough []string
+	}{
+		{
+			name:            "ClaimsArgumentsAfterDash",
+			args:            []string{"target", "--", "kubectl", "get", "-o", "yaml"},
+			wantBound:       map[int]string{0: "target"},
+			wantPassthrough: []string{"kubectl", "get", "-o", "yaml"},
+		}, {
+			name:          "NoDashClaimsNothing",
+			args:          []string{"target", "extra"},
+			wantBound:     map[int]string{0: "target"},
+			wantUnmatched: []string{"extra"},
+		}, {
+			name:            "ExtraOperandsBeforeDashAreUnmatched",
+			args:            []string{"target", "extra", "--", "ls"},
+			wantBound:       map[int]string{0: "target"},
+			wantUnmatched:   []string{"extra"},
+			wantPassthrough: []string{"ls"},
+		}, {
+			name:            "DashBeforePositionalsLeavesThemUnbound",
+			args:            []string{"--", "ls", "-la"},
+			wantPassthrough: []string{"ls", "-la"},
+		}, {
+			name:            "NoIntersperseClaimsBeyondPositionals",
+			noIntersperse:   true,
+			args:            []string{"target", "kubectl", "--namespace", "dev"},
+			wantBound:       map[int]string{0: "target"},
+			wantPassthrough: []string{"kubectl", "--namespace", "dev"},
+		}, {
+			name:            "NoIntersperseStillHonoursDash",
+			noIntersperse:   true,
+			args:            []string{"--", "kubectl"},
+			wantPassthrough: []string{"kubectl"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			cl := argdef.New()
+			argdef.SetInterspersed(cl, !tc.noIntersperse)
+			bound := map[int]string{}
+			argdef.AddPositional(cl, &argdef.Positional{
+				Index: 0,
+				Set:   func(value string) error { bound[0] = value; return nil },
+			})
+			var rest, passthrough []string
+			argdef.SetUnmatched(cl, &argdef.Unmatched{
+				Set: func(values []string) error { rest = values; return nil },
+			})
+			argdef.SetPassthrough(cl, &argdef.Passthrough{
+				Set: func(values []string) error { passthrough = values; return nil },
+			})
+			fs := argdef.Flags(cl)
+			if err := fs.Parse(tc.args); err != nil {
+				t.Fatalf("Parse(...) = %v, want nil", err)
+			}
+			ctx := context.Background()
+
+			// Act
+			err := argdef.Bind(ctx, cl, fs.Args())
+
+			// Assert
+			if got, want := err, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("Bind(...) = %v, want %v", got, want)
+			}
+			if got, want := bound, tc.wantBound; !cmp.Equal(got, want, cmpopts.EquateEmpty()) {
+				t.Errorf("Bind(...) bound = %v, want %v", got, want)
+			}
+			if got, want := rest, tc.wantUnmatched; !cmp.Equal(got, want, cmpopts.EquateEmpty()) {
+				t.Errorf("Bind(...) unmatched = %v, want %v", got, want)
+			}
+			if got, want := passthrough, tc.wantPassthrough; !cmp.Equal(got, want, cmpopts.EquateEmpty()) {
+				t.Errorf("Bind(...) passthrough = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestBind_RequiredPassthroughMissing(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	cl := argdef.New()
+	argdef.SetPassthrough(cl, &argdef.Passthrough{
+		Name:     "cmd",
+		Required: true,
+		Set:      func([]string) error { return nil },
+	})
+	ctx := context.Background()
+
+	// Act
+	err := argdef.Bind(ctx, cl, nil)
+
+	// Assert
+	if got, want := err, argdef.ErrMissingPassthrough; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Errorf("Bind(...) = %v, want %v", got, want)
+	}
+}
+
+func TestSetPassthrough_BoundTwice_Panics(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	cl := argdef.New()
+	noop := func([]string) error { return nil }
+	argdef.SetPassthrough(cl, &argdef.Passthrough{Set: noop})
+
+	// Act
+	panicked := recovered(func() { argdef.SetPassthrough(cl, &argdef.Passthrough{Set: noop}) })
+
+	// Assert
+	if got, want := panicked, true; !cmp.Equal(got, want) {
+		t.Errorf("SetPassthrough(...) panicked = %t, want %t", got, want)
+	}
+}
+
+func TestInPassthrough(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		passthrough   bool
+		noIntersperse bool
+		args          []string
+		want          bool
+	}{
+		{
+			name:        "NoPassthroughBinding",
+			passthrough: false,
+			args:        []string{"--", "ls"},
+			want:        false,
+		}, {
+			name:        "BeforeDash",
+			passthrough: true,
+			args:        []string{"target"},
+			want:        false,
+		}, {
+			name:        "AfterDash",
+			passthrough: true,
+			args:        []string{"target", "--"},
+			want:        true,
+		}, {
+			name:          "NoIntersperseWithinPositionals",
+			passthrough:   true,
+			noIntersperse: true,
+			args:          nil,
+			want:          false,
+		}, {
+			name:          "NoIntersperseBeyondPositionals",
+			passthrough:   true,
+			noIntersperse: true,
+			args:          []string{"target"},
+			want:          true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			cl := argdef.New()
+			argdef.SetInterspersed(cl, !tc.noIntersperse)
+			argdef.AddPositional(cl, &argdef.Positional{Index: 0, Set: func(string) error { return nil }})
+			if tc.passthrough {
+				argdef.SetPassthrough(cl, &argdef.Passthrough{Set: func([]string) error { return nil }})
+			}
+			fs := argdef.Flags(cl)
+			if err := fs.Parse(tc.args); err != nil {
+				t.Fatalf("Parse(...) = %v, want nil", err)
+			}
+
+			// Act
+			got := argdef.InPassthrough(cl, fs.Args())
+
+			// Assert
+			if want := tc.want; got != want {
+				t.Errorf("InPassthrough(...) = %t, want %t", got, want)
+			}
+		})
+	}
+}
+
+func TestResetFlags(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	fs.Bool("verbose", false, "")
+	fs.String("name", "default", "")
+	fs.StringSlice("tags", []string{"a", "b"}, "")
+	fs.String("func", "", "")
+	argdef.AddFuncFallback(fs.Lookup("func"), constantFallback("func-value"))
+	if err := fs.Parse([]string{"--verbose", "--name", "given", "--tags", "c"}); err != nil {
+		t.Fatalf("Parse(...) = %v, want nil", err)
+	}
+	if err := argdef.SetFlagFallbacks(context.Background(), fs); err != nil {
+		t.Fatalf("SetFlagFallbacks(...) = %v, want nil", err)
+	}
+
+	// Act
+	argdef.ResetFlags(fs)
+
+	// Assert
+	got := map[string]string{}
+	fs.VisitAll(func(f *pflag.Flag) {
+		got[f.Name] = f.Value.String() + " " + argdef.FlagSource(f).String()
+	})
+	want := map[string]string{
+		"verbose": "false default",
+		"name":    "default default",
+		"tags":    "[a,b] default",
+		"func":    " default",
+	}
+	if !cmp.Equal(got, want) {
+		t.Errorf("ResetFlags(...) flags = %v, want %v\n%s", got, want, cmp.Diff(want, got))
+	}
+}
@@ -0,0 +1,264 @@
+package argdef
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+var (
+	// ErrRequiredIf indicates an argument was omitted while a condition that
+	// requires it held.
+	ErrRequiredIf = errors.New("missing required argument")
+
+	// ErrExcludedIf indicates an argument was specified while a condition that
+	// excludes it held.
+	ErrExcludedIf = errors.New("argument not allowed")
+)
+
+// Ref identifies a single argument that a [Condition] or [Constraint] refers
+// to. Exactly one of its fields is set.
+type Ref struct {
+	Flag        *pflag.Flag
+	Positional  *Positional
+	Unmatched   *Unmatched
+	Passthrough *Passthrough
+}
+
+// String returns the argument as it is named in usage: "--name" for a flag,
+// "<name>" for a positional, and "<name>..." for an unmatched or passthrough
+// binding.
+func (r Ref) String() string {
+	switch {
+	case r.Flag != nil:
+		return "--" + r.Flag.Name
+	case r.Positional != nil:
+		return "<" + r.Positional.Name + ">"
+	case r.Unmatched != nil:
+		return "<" + r.Unmatched.Name + ">..."
+	case r.Passthrough != nil:
+		return "<" + r.Passthrough.Name + ">..."
+	}
+	return ""
+}
+
+// Synopsis returns the argument as the usage synopsis shows it: as
+// [Ref.String] does, except that an optional positional or unmatched binding
+// is bracketed, as in "[name]" or "[name...]".
+func (r Ref) Synopsis() string {
+	switch {
+	case r.Positional != nil:
+		return positionalUsage(r.Positional)
+	case r.Unmatched != nil:
+		return unmatchedUsage(r.Unmatched)
+	}
+	return r.String()
+}
+
+// Hidden reports whether the argument is omitted from help output. Only flags
+// may be hidden.
+func (r Ref) Hidden() bool {
+	return r.Flag != nil && r.Flag.Hidden
+}
+
+// Resolved returns the argument's value once fallbacks have been applied and
+// positional arguments bound, and reports whether it was specified -- on the
+// command line, by a fallback, or by an implication. An unspecified flag
+// reports its default; any other unspecified argument reports an empty string.
+// The arguments of a passthrough binding are joined by spaces, as they were
+// given.
+func (r Ref) Resolved() (value string, set bool) {
+	switch {
+	case r.Flag != nil:
+		return r.Flag.Value.String(), r.Source().Specified()
+	case r.Positional != nil:
+		return r.Positional.value, r.Positional.source.Specified()
+	case r.Unmatched != nil:
+		return strings.Join(r.Unmatched.values, ","), r.Unmatched.source.Specified()
+	case r.Passthrough != nil:
+		return strings.Join(r.Passthrough.values, " "), r.Passthrough.source.Specified()
+	}
+	return "", false
+}
+
+// Source returns where the argument's value was resolved from.
+func (r Ref) Source() Source {
+	switch {
+	case r.Flag != nil:
+		return FlagSource(r.Flag)
+	case r.Positional != nil:
+		return r.Positional.source
+	case r.Unmatched != nil:
+		return r.Unmatched.source
+	case r.Passthrough != nil:
+		return r.Passthrough.source
+	}
+	return Source{}
+}
+
+// imply assigns value to the argument, recording it as implied.
+func (r Ref) imply(value string) error {
+	source := Source{Kind: SourceImplied}
+	switch {
+	case r.Flag != nil:
+		if err := r.Flag.Value.Set(value); err != nil {
+			return err
+		}
+		setFlagSource(r.Flag, source)
+		return nil
+	case r.Positional != nil:
+		return r.Positional.assign(value, source)
+	case r.Unmatched != nil:
+		return r.Unmatched.setFields(value, source)
+	case r.Passthrough != nil:
+		return r.Passthrough.assign([]string{value}, source)
+	}
+	return nil
+}
+
+// Condition is a described predicate over the resolved arguments of a command
+// line.
+type Condition struct {
+	// Description phrases the condition for messages, such as "--tls is true".
+	Description string
+
+	// Holds reports whether the condition is satisfied.
+	Holds func() bool
+}
+
+// ConstraintKind selects how a [Constraint] acts on its targets when its
+// condition holds.
+type ConstraintKind int
+
+const (
+	// ConstraintRequired demands that every target be specified.
+	ConstraintRequired ConstraintKind = iota
+
+	// ConstraintExcluded demands that no target be specified.
+	ConstraintExcluded
+
+	// ConstraintImplies assigns a value to every target left unspecified.
+	ConstraintImplies
+)
+
+// Constraint is a rule over a command's arguments that applies only while its
+// condition holds.
+type Constraint struct {
+	Kind    ConstraintKind
+	When    Condition
+	Targets []Ref
+
+	// Value is assigned to the targets of a [ConstraintImplies] constraint.
+	Value string
+}
+
+// String describes the constraint as it is shown in help output, such as
+// "--tls-cert is required when --tls is true".
+func (c *Constraint) String() string {
+	targets := c.targetNames()
+	switch c.Kind {
+	case ConstraintRequired:
+		return fmt.Sprintf("%s %s required when %s", targets, c.verb(), c.When.Description)
+	case ConstraintExcluded:
+		return fmt.Sprintf("%s %s not allowed when %s", targets, c.verb(), c.When.Description)
+	default:
+		return fmt.Sprintf("%s %s %s when %s", targets, c.verb(), c.Value, c.When.Description)
+	}
+}
+
+// Hidden reports whether the constraint refers to a hidden argument, and so is
+// omitted from help output.
+func (c *Constraint) Hidden() bool {
+	for _, target := range c.Targets {
+		if target.Hidden() {
+			return true
+		}
+	}
+	return false
+}
+
+// verb returns the verb phrase agreeing with the number of targets.
+func (c *Constraint) verb() string {
+	plural := len(c.Targets) > 1
+	switch {
+	case c.Kind == ConstraintImplies && plural:
+		return "default to"
+	case c.Kind == ConstraintImplies:
+		return "defaults to"
+	case plural:
+		return "are"
+	}
+	return "is"
+}
+
+// targetNames joins the usage names of the constraint's targets.
+func (c *Constraint) targetNames() string {
+	names := make([]string, 0, len(c.Targets))
+	for _, target := range c.Targets {
+		names = append(names, target.String())
+	}
+	return strings.Join(names, ", ")
+}
+
+// AddConstraint records c as a conditional constraint on reg.
+func AddConstraint(reg *CommandLine, c *Constraint) {
+	reg.constraints = append(reg.constraints, c)
+}
+
+// Constraints returns the conditional constraints registered on reg, in
+// registration order.
+func Constraints(reg *CommandLine) []*Constraint {
+	return reg.constraints
+}
+
+// Enforce applies the conditional constraints registered on reg, and must run
+// once fallbacks are applied and positional arguments bound.
+//
+// Implications are applied first, so that an implied value participates in the
+// checks that follow. They are applied repeatedly until none assigns anything
+// more, so a chain of implications takes effect whatever order it was
+// registered in; each target is implied at most once, so a cycle ends too.
+// Every violated requirement or exclusion is then reported, joined into a
+// single error that wraps [ErrRequiredIf] or [ErrExcludedIf] for each
+// violation. An exclusion is only violated by a value the user gave, on the
+// command line or at a prompt, not by a fallback or implication.
+func Enforce(reg *CommandLine) error {
+	implied := map[Ref]bool{}
+	for changed := true; changed; {
+		changed = false
+		for _, c := range reg.constraints {
+			if c.Kind != ConstraintImplies || !c.When.Holds() {
+				continue
+			}
+			for _, target := range c.Targets {
+				if _, set := target.Resolved(); set || implied[target] {
+					continue
+				}
+				if err := target.imply(c.Value); err != nil {
+					return fmt.Errorf("%s: %w", target, err)
+				}
+				implied[target] = true
+				changed = true
+			}
+		}
+	}
+
+	var errs []error
+	for _, c := range reg.constraints {
+		if c.Kind == ConstraintImplies || !c.When.Holds() {
+			continue
+		}
+		for _, target := range c.Targets {
+			_, set := target.Resolved()
+			switch {
+			case c.Kind == ConstraintRequired && !set:
+				errs = append(errs, fmt.Errorf("%w: %s is required when %s", ErrRequiredIf, target, c.When.Description))
+			case c.Kind == ConstraintExcluded && target.Source().Given():
+				errs = append(errs, fmt.Errorf("%w: %s is not allowed when %s", ErrExcludedIf, target, c.When.Description))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
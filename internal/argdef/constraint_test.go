@@ -0,0 +1,378 @@
+package argdef_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/spf13/pflag"
+
+	"github.com/bitwizeshift/go-cli/internal/argdef"
+)
+
+// isSet returns a condition that holds when ref was specified.
+func isSet(ref argdef.Ref) argdef.Condition {
+	return argdef.Condition{
+		Description: ref.String() + " is specified",
+		Holds: func() bool {
+			_, set := ref.Resolved()
+			return set
+		},
+	}
+}
+
+func TestEnforce(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		kind    argdef.ConstraintKind
+		args    []string
+		want    string
+		wantErr error
+	}{
+		{
+			name:    "RequiredSatisfied",
+			kind:    argdef.ConstraintRequired,
+			args:    []string{"--tls", "--cert", "c.pem"},
+			want:    "c.pem",
+			wantErr: nil,
+		}, {
+			name:    "RequiredViolated",
+			kind:    argdef.ConstraintRequired,
+			args:    []string{"--tls"},
+			want:    "",
+			wantErr: argdef.ErrRequiredIf,
+		}, {
+			name:    "RequiredInactive",
+			kind:    argdef.ConstraintRequired,
+			args:    nil,
+			want:    "",
+			wantErr: nil,
+		}, {
+			name:    "ExcludedViolated",
+			kind:    argdef.ConstraintExcluded,
+			args:    []string{"--tls", "--cert", "c.pem"},
+			want:    "c.pem",
+			wantErr: argdef.ErrExcludedIf,
+		}, {
+			name:    "ExcludedInactive",
+			kind:    argdef.ConstraintExcluded,
+			args:    []string{"--cert", "c.pem"},
+			want:    "c.pem",
+			wantErr: nil,
+		}, {
+			name:    "ImpliesAssignsUnsetTarget",
+			kind:    argdef.ConstraintImplies,
+			args:    []string{"--tls"},
+			want:    "implied",
+			wantErr: nil,
+		}, {
+			name:    "ImpliesKeepsSpecifiedTarget",
+			kind:    argdef.ConstraintImplies,
+			args:    []string{"--tls", "--cert", "c.pem"},
+			want:    "c.pem",
+			wantErr: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			fs.Bool("tls", false, "")
+			cert := fs.String("cert", "", "")
+			sut := argdef.FromFlagSet(fs)
+			argdef.AddConstraint(sut, &argdef.Constraint{
+				Kind:    tc.kind,
+				When:    isSet(argdef.Ref{Flag: fs.Lookup("tls")}),
+				Targets: []argdef.Ref{{Flag: fs.Lookup("cert")}},
+				Value:   "implied",
+			})
+			if err := fs.Parse(tc.args); err != nil {
+				t.Fatalf("Parse(...) = %v, want nil", err)
+			}
+
+			// Act
+			err := argdef.Enforce(sut)
+
+			// Assert
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("Enforce(...) = %v, want %v", got, want)
+			}
+			if got, want := *cert, tc.want; got != want {
+				t.Errorf("Enforce(...) cert = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestEnforce_FallbackCountsAsSpecified(t *testing.T) {
+	t.Setenv("ENFORCE_TLS", "true")
+
+	// Arrange
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	fs.Bool("tls", false, "")
+	fs.String("cert", "", "")
+	argdef.AddEnvFallback(fs.Lookup("tls"), "ENFORCE_TLS")
+	sut := argdef.FromFlagSet(fs)
+	argdef.AddConstraint(sut, &argdef.Constraint{
+		Kind:    argdef.ConstraintRequired,
+		When:    isSet(argdef.Ref{Flag: fs.Lookup("tls")}),
+		Targets: []argdef.Ref{{Flag: fs.Lookup("cert")}},
+	})
+	if err := argdef.SetFlagFallbacks(context.Background(), fs); err != nil {
+		t.Fatalf("SetFlagFallbacks(...) = %v, want nil", err)
+	}
+
+	// Act
+	err := argdef.Enforce(sut)
+
+	// Assert
+	if got, want := err, argdef.ErrRequiredIf; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Fatalf("Enforce(...) = %v, want %v", got, want)
+	}
+}
+
+func TestEnforce_ImpliesChain(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		cycle bool
+	}{
+		{name: "RegisteredInReverse"},
+		{name: "Cycle", cycle: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			fs.Bool("force", false, "")
+			yes := fs.Bool("yes", false, "")
+			quiet := fs.Bool("quiet", false, "")
+			force := argdef.Ref{Flag: fs.Lookup("force")}
+			yesRef := argdef.Ref{Flag: fs.Lookup("yes")}
+			quietRef := argdef.Ref{Flag: fs.Lookup("quiet")}
+			sut := argdef.FromFlagSet(fs)
+			// --yes implies --quiet is registered before --force implies --yes,
+			// so --quiet is only implied once --yes has been.
+			argdef.AddConstraint(sut, &argdef.Constraint{
+				Kind: argdef.ConstraintImplies, When: isSet(yesRef), Targets: []argdef.Ref{quietRef}, Value: "true",
+			})
+			argdef.AddConstraint(sut, &argdef.Constraint{
+				Kind: argdef.ConstraintImplies, When: isSet(force), Targets: []argdef.Ref{yesRef}, Value: "true",
+			})
+			if tc.cycle {
+				argdef.AddConstraint(sut, &argdef.Constraint{
+					Kind: argdef.ConstraintImplies, When: isSet(quietRef), Targets: []argdef.Ref{force}, Value: "true",
+				})
+			}
+			if err := fs.Parse([]string{"--force"}); err != nil {
+				t.Fatalf("Parse(...) = %v, want nil", err)
+			}
+
+			// Act
+			err := argdef.Enforce(sut)
+
+			// Assert
+			if got, want := err, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("Enforce(...) = %v, want %v", got, want)
+			}
+			if got, want := *yes, true; got != want {
+				t.Errorf("Enforce(...) yes = %t, want %t", got, want)
+			}
+			if got, want := *quiet, true; got != want {
+				t.Errorf("Enforce(...) quiet = %t, want %t", got, want)
+			}
+		})
+	}
+}
+
+func TestEnforce_ExcludedIgnoresFallback(t *testing.T) {
+	t.Setenv("ENFORCE_CERT", "env.pem")
+
+	// Arrange
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	fs.Bool("tls", false, "")
+	fs.String("cert", "", "")
+	argdef.AddEnvFallback(fs.Lookup("cert"), "ENFORCE_CERT")
+	sut := argdef.FromFlagSet(fs)
+	argdef.AddConstraint(sut, &argdef.Constraint{
+		Kind:    argdef.ConstraintExcluded,
+		When:    isSet(argdef.Ref{Flag: fs.Lookup("tls")}),
+		Targets: []argdef.Ref{{Flag: fs.Lookup("cert")}},
+	})
+	if err := fs.Parse([]string{"--tls"}); err != nil {
+		t.Fatalf("Parse(...) = %v, want nil", err)
+	}
+	if err := argdef.SetFlagFallbacks(context.Background(), fs); err != nil {
+		t.Fatalf("SetFlagFallbacks(...) = %v, want nil", err)
+	}
+
+	// Act
+	err := argdef.Enforce(sut)
+
+	// Assert
+	if got, want := err, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Fatalf("Enforce(...) = %v, want %v", got, want)
+	}
+}
+
+func TestEnforce_Positional(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		args    []string
+		wantErr error
+	}{
+		{
+			name:    "SatisfiedByBoundPositional",
+			args:    []string{"--tls", "dst"},
+			wantErr: nil,
+		}, {
+			name:    "ViolatedByMissingPositional",
+			args:    []string{"--tls"},
+			wantErr: argdef.ErrRequiredIf,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			fs.Bool("tls", false, "")
+			sut := argdef.FromFlagSet(fs)
+			p := &argdef.Positional{Name: "dst", Set: func(string) error { return nil }}
+			argdef.AddPositional(sut, p)
+			argdef.AddConstraint(sut, &argdef.Constraint{
+				Kind:    argdef.ConstraintRequired,
+				When:    isSet(argdef.Ref{Flag: fs.Lookup("tls")}),
+				Targets: []argdef.Ref{{Positional: p}},
+			})
+			if err := fs.Parse(tc.args); err != nil {
+				t.Fatalf("Parse(...) = %v, want nil", err)
+			}
+			if err := argdef.Bind(context.Background(), sut, fs.Args()); err != nil {
+				t.Fatalf("Bind(...) = %v, want nil", err)
+			}
+
+			// Act
+			err := argdef.Enforce(sut)
+
+			// Assert
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("Enforce(...) = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestConstraint_String(t *testing.T) {
+	t.Parallel()
+
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	fs.Bool("tls", false, "")
+	fs.String("cert", "", "")
+	fs.String("key", "", "")
+	when := isSet(argdef.Ref{Flag: fs.Lookup("tls")})
+	cert := argdef.Ref{Flag: fs.Lookup("cert")}
+	key := argdef.Ref{Flag: fs.Lookup("key")}
+	src := argdef.Ref{Positional: &argdef.Positional{Name: "src"}}
+
+	testCases := []struct {
+		name string
+		sut  *argdef.Constraint
+		want string
+	}{
+		{
+			name: "Required",
+			sut:  &argdef.Constraint{Kind: argdef.ConstraintRequired, When: when, Targets: []argdef.Ref{cert}},
+			want: "--cert is required when --tls is specified",
+		}, {
+			name: "RequiredPlural",
+			sut:  &argdef.Constraint{Kind: argdef.ConstraintRequired, When: when, Targets: []argdef.Ref{cert, key}},
+			want: "--cert, --key are required when --tls is specified",
+		}, {
+			name: "ExcludedPositional",
+			sut:  &argdef.Constraint{Kind: argdef.ConstraintExcluded, When: when, Targets: []argdef.Ref{src}},
+			want: "<src> is not allowed when --tls is specified",
+		}, {
+			name: "Implies",
+			sut:  &argdef.Constraint{Kind: argdef.ConstraintImplies, When: when, Targets: []argdef.Ref{cert}, Value: "c.pem"},
+			want: "--cert defaults to c.pem when --tls is specified",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			got := tc.sut.String()
+
+			// Assert
+			if want := tc.want; got != want {
+				t.Errorf("String() = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestRef_Synopsis(t *testing.T) {
+	t.Parallel()
+
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	fs.String("cert", "", "")
+
+	testCases := []struct {
+		name string
+		sut  argdef.Ref
+		want string
+	}{
+		{
+			name: "Flag",
+			sut:  argdef.Ref{Flag: fs.Lookup("cert")},
+			want: "--cert",
+		}, {
+			name: "RequiredPositional",
+			sut:  argdef.Ref{Positional: &argdef.Positional{Name: "src", Required: true}},
+			want: "<src>",
+		}, {
+			name: "OptionalPositional",
+			sut:  argdef.Ref{Positional: &argdef.Positional{Name: "dst"}},
+			want: "[dst]",
+		}, {
+			name: "RequiredUnmatched",
+			sut:  argdef.Ref{Unmatched: &argdef.Unmatched{Name: "files", Required: true}},
+			want: "<files>...",
+		}, {
+			name: "OptionalUnmatched",
+			sut:  argdef.Ref{Unmatched: &argdef.Unmatched{Name: "files"}},
+			want: "[files...]",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			got := tc.sut.Synopsis()
+
+			// Assert
+			if want := tc.want; got != want {
+				t.Errorf("Synopsis() = %q, want %q", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package argdef
+
+import (
+	"github.com/spf13/pflag"
+)
+
+const (
+	// AnnotationPrompt is the pflag annotation marking a required flag as one
+	// that may be prompted for when it is left without a value.
+	AnnotationPrompt = "annotation://cli.flag_prompt"
+
+	// AnnotationSecret is the pflag annotation marking a flag's value as
+	// sensitive, so it is never echoed back.
+	AnnotationSecret = "annotation://cli.flag_secret"
+
+	// AnnotationChoices is the pflag annotation recording the fixed set of
+	// values a flag is chosen from.
+	AnnotationChoices = "annotation://cli.flag_choices"
+)
+
+// MarkPrompt sets each flag in 'flags' to be prompted for when it is required
+// and left without a value, by assigning the [AnnotationPrompt] annotation.
+func MarkPrompt(flags ...*pflag.Flag) {
+	for _, flag := range flags {
+		setAnnotation(flag, AnnotationPrompt, "true")
+	}
+}
+
+// IsPrompt reports whether f has been marked to be prompted for via
+// [MarkPrompt].
+func IsPrompt(f *pflag.Flag) bool {
+	_, ok := f.Annotations[AnnotationPrompt]
+	return ok
+}
+
+// MarkSecret sets each flag in 'flags' to hold a sensitive value by assigning
+// the [AnnotationSecret] annotation.
+func MarkSecret(flags ...*pflag.Flag) {
+	for _, flag := range flags {
+		setAnnotation(flag, AnnotationSecret, "true")
+	}
+}
+
+// IsSecret reports whether f has been marked as sensitive via [MarkSecret].
+func IsSecret(f *pflag.Flag) bool {
+	_, ok := f.Annotations[AnnotationSecret]
+	return ok
+}
+
+// SetChoices records choices as the fixed set of values f is chosen from, by
+// assigning the [AnnotationChoices] annotation.
+func SetChoices(f *pflag.Flag, choices ...string) {
+	if f.Annotations == nil {
+		f.Annotations = map[string][]string{}
+	}
+	f.Annotations[AnnotationChoices] = choices
+}
+
+// Choices returns the fixed set of values f is chosen from, as recorded by
+// [SetChoices], or nil if f may hold any value.
+func Choices(f *pflag.Flag) []string {
+	return f.Annotations[AnnotationChoices]
+}
+
+// PromptRequired marks every required flag and positional registered on reg
+// to be prompted for when it is left without a value. It must be called before
+// [ConfigureFlags], which leaves a flag to be prompted for out of cobra's own
+// required-flag check.
+func PromptRequired(reg *CommandLine) {
+	reg.flags.VisitAll(func(f *pflag.Flag) {
+		if IsRequired(f) {
+			MarkPrompt(f)
+		}
+	})
+	for _, p := range reg.positionals {
+		if p.Required {
+			p.Prompt = true
+		}
+	}
+}
+
+// Missing returns the required arguments of reg that may be prompted for and
+// were left without a value once reg was bound: flags in lexical order,
+// followed by positionals in registration order.
+func Missing(reg *CommandLine) []Ref {
+	var refs []Ref
+	reg.flags.VisitAll(func(f *pflag.Flag) {
+		if IsRequired(f) && IsPrompt(f) && !FlagSource(f).Specified() {
+			refs = append(refs, Ref{Flag: f})
+		}
+	})
+	for _, p := range reg.positionals {
+		if p.Required && p.Prompt && !p.source.Specified() {
+			refs = append(refs, Ref{Positional: p})
+		}
+	}
+	return refs
+}
+
+// Usage returns the help string of the argument.
+func (r Ref) Usage() string {
+	switch {
+	case r.Flag != nil:
+		return r.Flag.Usage
+	case r.Positional != nil:
+		return r.Positional.Usage
+	case r.Unmatched != nil:
+		return r.Unmatched.Usage
+	case r.Passthrough != nil:
+		return r.Passthrough.Usage
+	}
+	return ""
+}
+
+// Secret reports whether the argument holds a sensitive value. Only flags and
+// positionals may be secret.
+func (r Ref) Secret() bool {
+	switch {
+	case r.Flag != nil:
+		return IsSecret(r.Flag)
+	case r.Positional != nil:
+		return r.Positional.Secret
+	}
+	return false
+}
+
+// Choices returns the fixed set of values the argument is chosen from, or nil
+// if it may hold any value. Only flags and positionals may have choices.
+func (r Ref) Choices() []string {
+	switch {
+	case r.Flag != nil:
+		return Choices(r.Flag)
+	case r.Positional != nil:
+		return r.Positional.Choices
+	}
+	return nil
+}
+
+// Answer assigns value to the argument, recording it as given at a prompt.
+// Only flags and positionals may be answered; any other argument is left
+// unchanged.
+func (r Ref) Answer(value string) error {
+	source := Source{Kind: SourcePrompt}
+	switch {
+	case r.Flag != nil:
+		if err := r.Flag.Value.Set(value); err != nil {
+			return err
+		}
+		setFlagSource(r.Flag, source)
+		return nil
+	case r.Positional != nil:
+		return r.Positional.assign(value, source)
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package argdef_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/bitwizeshift/go-cli/internal/argdef"
+)
+
+func TestMissing(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		required bool
+		prompt   bool
+		all      bool
+		args     []string
+		want     []string
+	}{
+		{
+			name:     "RequiredPromptedAndUnsetIsMissing",
+			required: true,
+			prompt:   true,
+			want:     []string{"--name", "<src>"},
+		}, {
+			name:     "GivenArgumentsAreNotMissing",
+			required: true,
+			prompt:   true,
+			args:     []string{"--name", "bob", "in"},
+			want:     nil,
+		}, {
+			name:     "UnpromptedArgumentsAreNotMissing",
+			required: true,
+			want:     nil,
+		}, {
+			name:   "OptionalArgumentsAreNotMissing",
+			prompt: true,
+			want:   nil,
+		}, {
+			name:     "PromptRequiredMarksEveryRequiredArgument",
+			required: true,
+			all:      true,
+			want:     []string{"--name", "<src>"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			cl := argdef.New()
+			fs := argdef.Flags(cl)
+			fs.String("name", "", "")
+			name := fs.Lookup("name")
+			if tc.required {
+				argdef.MarkRequired(name)
+			}
+			if tc.prompt {
+				argdef.MarkPrompt(name)
+			}
+			argdef.AddPositional(cl, &argdef.Positional{
+				Name:     "src",
+				Required: tc.required,
+				Prompt:   tc.prompt,
+				Set:      func(string) error { return nil },
+			})
+			if tc.all {
+				argdef.PromptRequired(cl)
+			}
+			if err := fs.Parse(tc.args); err != nil {
+				t.Fatalf("Parse(...) = %v, want nil", err)
+			}
+			if err := argdef.Bind(context.Background(), cl, fs.Args()); err != nil {
+				t.Fatalf("Bind(...) = %v, want nil", err)
+			}
+
+			// Act
+			missing := argdef.Missing(cl)
+
+			// Assert
+			var got []string
+			for _, ref := range missing {
+				got = append(got, ref.String())
+			}
+			if want := tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Missing(...) = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestRef_Answer(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	cl := argdef.New()
+	fs := argdef.Flags(cl)
+	fs.String("name", "", "")
+	var src string
+	positional := &argdef.Positional{
+		Name: "src",
+		Set:  func(v string) error { src = v; return nil },
+	}
+	argdef.AddPositional(cl, positional)
+	flag := argdef.Ref{Flag: fs.Lookup("name")}
+	pos := argdef.Ref{Positional: positional}
+
+	// Act
+	flagErr := flag.Answer("bob")
+	posErr := pos.Answer("in")
+
+	// Assert
+	if flagErr != nil || posErr != nil {
+		t.Fatalf("Answer(...) = %v, %v, want nil", flagErr, posErr)
+	}
+	prompted := argdef.Source{Kind: argdef.SourcePrompt}
+	if got, set := flag.Resolved(); got != "bob" || !set {
+		t.Errorf("flag Resolved() = %q, %t, want %q, %t", got, set, "bob", true)
+	}
+	if got, want := flag.Source(), prompted; got != want {
+		t.Errorf("flag Source() = %v, want %v", got, want)
+	}
+	if got, want := src, "in"; got != want {
+		t.Errorf("positional value = %q, want %q", got, want)
+	}
+	if got, want := pos.Source(), prompted; got != want {
+		t.Errorf("positional Source() = %v, want %v", got, want)
+	}
+}
+
+func TestRef_SecretAndChoices(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	cl := argdef.New()
+	fs := argdef.Flags(cl)
+	fs.String("token", "", "")
+	fs.String("env", "", "")
+	argdef.MarkSecret(fs.Lookup("token"))
+	argdef.SetChoices(fs.Lookup("env"), "dev", "prod")
+
+	testCases := []struct {
+		name        string
+		ref         argdef.Ref
+		wantSecret  bool
+		wantChoices []string
+	}{
+		{
+			name:       "SecretFlag",
+			ref:        argdef.Ref{Flag: fs.Lookup("token")},
+			wantSecret: true,
+		}, {
+			name:        "FlagWithChoices",
+			ref:         argdef.Ref{Flag: fs.Lookup("env")},
+			wantChoices: []string{"dev", "prod"},
+		}, {
+			name:        "Positional",
+			ref:         argdef.Ref{Positional: &argdef.Positional{Secret: true, Choices: []string{"a"}}},
+			wantSecret:  true,
+			wantChoices: []string{"a"},
+		}, {
+			name: "Unmatched",
+			ref:  argdef.Ref{Unmatched: &argdef.Unmatched{}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			secret := tc.ref.Secret()
+			choices := tc.ref.Choices()
+
+			// Assert
+			if got, want := secret, tc.wantSecret; got != want {
+				t.Errorf("Secret() = %t, want %t", got, want)
+			}
+			if got, want := choices, tc.wantChoices; !cmp.Equal(got, want) {
+				t.Errorf("Choices() = %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package argdef
+
+import (
+	"github.com/spf13/pflag"
+)
+
+// AnnotationSource is the pflag annotation recording where a flag's value was
+// resolved from when it was not given on the command line.
+const AnnotationSource = "annotation://cli.flag_source"
+
+// SourceKind identifies the kind of place an argument's value was resolved
+// from.
+type SourceKind int
+
+const (
+	// SourceDefault indicates the argument was never specified, and holds its
+	// default value.
+	SourceDefault SourceKind = iota
+
+	// SourceCommandLine indicates the argument was given on the command line.
+	SourceCommandLine
+
+	// SourceEnv indicates the argument was supplied by an environment variable
+	// fallback.
+	SourceEnv
+
+	// SourceFunc indicates the argument was supplied by a fallback function.
+	SourceFunc
+
+	// SourceImplied indicates the argument was assigned by an implication
+	// constraint.
+	SourceImplied
+
+	// SourcePrompt indicates the argument was answered at an interactive
+	// prompt.
+	SourcePrompt
+)
+
+// String returns the lower-case name of the kind.
+func (k SourceKind) String() string {
+	switch k {
+	case SourceCommandLine:
+		return "command line"
+	case SourceEnv:
+		return "env"
+	case SourceFunc:
+		return "func"
+	case SourceImplied:
+		return "implied"
+	case SourcePrompt:
+		return "prompt"
+	}
+	return "default"
+}
+
+// Source describes where an argument's value was resolved from.
+type Source struct {
+	Kind SourceKind
+
+	// Env names the environment variable that supplied the value, and is only
+	// set for [SourceEnv].
+	Env string
+}
+
+// String describes the source, such as "command line" or "env APP_TOKEN".
+func (s Source) String() string {
+	if s.Kind == SourceEnv {
+		return s.Kind.String() + " " + s.Env
+	}
+	return s.Kind.String()
+}
+
+// Specified reports whether the source supplied a value, as opposed to the
+// argument holding its default.
+func (s Source) Specified() bool {
+	return s.Kind != SourceDefault
+}
+
+// Given reports whether the user gave the value, on the command line or at a
+// prompt, as opposed to it being supplied by a fallback or implication.
+func (s Source) Given() bool {
+	return s.Kind == SourceCommandLine || s.Kind == SourcePrompt
+}
+
+// FlagSource returns where the value of f was resolved from. A flag given on
+// the command line reports [SourceCommandLine]; otherwise the source recorded
+// when a fallback or implication assigned it is reported, or [SourceDefault] if
+// nothing did.
+func FlagSource(f *pflag.Flag) Source {
+	if f.Changed {
+		return Source{Kind: SourceCommandLine}
+	}
+	values := f.Annotations[AnnotationSource]
+	if len(values) == 0 {
+		return Source{}
+	}
+	source := Source{Kind: sourceKinds[values[0]]}
+	if len(values) > 1 {
+		source.Env = values[1]
+	}
+	return source
+}
+
+// sourceKinds maps the recorded name of a kind back to the kind.
+var sourceKinds = map[string]SourceKind{
+	SourceEnv.String():     SourceEnv,
+	SourceFunc.String():    SourceFunc,
+	SourceImplied.String(): SourceImplied,
+	SourcePrompt.String():  SourcePrompt,
+}
+
+// setFlagSource records source as the origin of the value of f.
+func setFlagSource(f *pflag.Flag, source Source) {
+	if f.Annotations == nil {
+		f.Annotations = map[string][]string{}
+	}
+	values := []string{source.Kind.String()}
+	if source.Env != "" {
+		values = append(values, source.Env)
+	}
+	f.Annotations[AnnotationSource] = values
+}
+
+// Lookup returns the argument registered on reg under name. Flags are matched
+// first by their long name, then positionals, the unmatched binding, and the
+// passthrough binding by the name they are shown under in help.
+func Lookup(reg *CommandLine, name string) (Ref, bool) {
+	if f := reg.flags.Lookup(name); f != nil {
+		return Ref{Flag: f}, true
+	}
+	for _, p := range reg.positionals {
+		if p.Name == name {
+			return Ref{Positional: p}, true
+		}
+	}
+	if reg.unmatched != nil && reg.unmatched.Name == name {
+		return Ref{Unmatched: reg.unmatched}, true
+	}
+	if reg.passthrough != nil && reg.passthrough.Name == name {
+		return Ref{Passthrough: reg.passthrough}, true
+	}
+	return Ref{}, false
+}
+
+// Refs returns every argument registered on reg: the flags in lexical order,
+// followed by the positionals in registration order, the unmatched binding, and
+// the passthrough binding.
+func Refs(reg *CommandLine) []Ref {
+	var refs []Ref
+	reg.flags.VisitAll(func(f *pflag.Flag) {
+		refs = append(refs, Ref{Flag: f})
+	})
+	for _, p := range reg.positionals {
+		refs = append(refs, Ref{Positional: p})
+	}
+	if reg.unmatched != nil {
+		refs = append(refs, Ref{Unmatched: reg.unmatched})
+	}
+	if reg.passthrough != nil {
+		refs = append(refs, Ref{Passthrough: reg.passthrough})
+	}
+	return refs
+}
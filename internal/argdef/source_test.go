@@ -0,0 +1,155 @@
+package argdef_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/pflag"
+
+	"github.com/bitwizeshift/go-cli/internal/argdef"
+)
+
+func TestFlagSource(t *testing.T) {
+	t.Setenv("SOURCE_FROM_ENV", "env-value")
+
+	// Arrange
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	fs.String("given", "", "")
+	fs.String("env", "", "")
+	fs.String("func", "", "")
+	fs.String("unset", "", "")
+	argdef.AddEnvFallback(fs.Lookup("given"), "SOURCE_FROM_ENV")
+	argdef.AddEnvFallback(fs.Lookup("env"), "SOURCE_FROM_ENV")
+	argdef.AddFuncFallback(fs.Lookup("func"), constantFallback("func-value"))
+	if err := fs.Parse([]string{"--given", "value"}); err != nil {
+		t.Fatalf("Parse(...) = %v, want nil", err)
+	}
+	if err := argdef.SetFlagFallbacks(context.Background(), fs); err != nil {
+		t.Fatalf("SetFlagFallbacks(...) = %v, want nil", err)
+	}
+
+	testCases := []struct {
+		name string
+		flag string
+		want argdef.Source
+	}{
+		{name: "CommandLine", flag: "given", want: argdef.Source{Kind: argdef.SourceCommandLine}},
+		{name: "Env", flag: "env", want: argdef.Source{Kind: argdef.SourceEnv, Env: "SOURCE_FROM_ENV"}},
+		{name: "Func", flag: "func", want: argdef.Source{Kind: argdef.SourceFunc}},
+		{name: "Default", flag: "unset", want: argdef.Source{Kind: argdef.SourceDefault}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			got := argdef.FlagSource(fs.Lookup(tc.flag))
+
+			// Assert
+			if want := tc.want; !cmp.Equal(got, want) {
+				t.Errorf("FlagSource(%q) = %v, want %v", tc.flag, got, want)
+			}
+		})
+	}
+}
+
+func TestSource_String(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		sut  argdef.Source
+		want string
+	}{
+		{name: "Default", sut: argdef.Source{}, want: "default"},
+		{name: "CommandLine", sut: argdef.Source{Kind: argdef.SourceCommandLine}, want: "command line"},
+		{name: "Env", sut: argdef.Source{Kind: argdef.SourceEnv, Env: "APP_TOKEN"}, want: "env APP_TOKEN"},
+		{name: "Func", sut: argdef.Source{Kind: argdef.SourceFunc}, want: "func"},
+		{name: "Implied", sut: argdef.Source{Kind: argdef.SourceImplied}, want: "implied"},
+		{name: "Prompt", sut: argdef.Source{Kind: argdef.SourcePrompt}, want: "prompt"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			got := tc.sut.String()
+
+			// Assert
+			if want := tc.want; got != want {
+				t.Errorf("String() = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestBind_RecordsPositionalSource(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	cl := argdef.New()
+	given := &argdef.Positional{Index: 0, Name: "given", Set: func(string) error { return nil }}
+	computed := &argdef.Positional{
+		Index:         1,
+		Name:          "computed",
+		Set:           func(string) error { return nil },
+		FuncFallbacks: []argdef.FallbackFunc{constantFallback("value")},
+	}
+	argdef.AddPositional(cl, given)
+	argdef.AddPositional(cl, computed)
+
+	// Act
+	err := argdef.Bind(context.Background(), cl, []string{"x"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Bind(...) = %v, want nil", err)
+	}
+	if got, want := (argdef.Ref{Positional: given}).Source(), (argdef.Source{Kind: argdef.SourceCommandLine}); got != want {
+		t.Errorf("Source() given = %v, want %v", got, want)
+	}
+	if got, want := (argdef.Ref{Positional: computed}).Source(), (argdef.Source{Kind: argdef.SourceFunc}); got != want {
+		t.Errorf("Source() computed = %v, want %v", got, want)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	fs.String("token", "", "")
+	cl := argdef.FromFlagSet(fs)
+	argdef.AddPositional(cl, &argdef.Positional{Name: "src"})
+	argdef.SetUnmatched(cl, &argdef.Unmatched{Name: "rest"})
+
+	testCases := []struct {
+		name   string
+		arg    string
+		want   string
+		wantOK bool
+	}{
+		{name: "Flag", arg: "token", want: "--token", wantOK: true},
+		{name: "Positional", arg: "src", want: "<src>", wantOK: true},
+		{name: "Unmatched", arg: "rest", want: "<rest>...", wantOK: true},
+		{name: "Unknown", arg: "nope", want: "", wantOK: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			ref, ok := argdef.Lookup(cl, tc.arg)
+
+			// Assert
+			if got, want := ok, tc.wantOK; got != want {
+				t.Fatalf("Lookup(%q) ok = %t, want %t", tc.arg, got, want)
+			}
+			if got, want := ref.String(), tc.want; got != want {
+				t.Errorf("Lookup(%q) = %q, want %q", tc.arg, got, want)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package argdef
 
 import (
+	"slices"
 	"strings"
 
 	"github.com/spf13/pflag"
@@ -20,6 +21,11 @@ const flagsPlaceholder = "[flags]"
 // and the registered positional arguments, and name the parts of the command
 // line that cl does not model, such as a subcommand.
 //
+// A [Passthrough] binding is rendered last, after "[flags]", since the flags
+// must precede the arguments handed to the wrapped command. It is introduced by
+// "--", which is shown as optional when flag parsing stops at the first
+// positional: "[--] <cmd> [args...]".
+//
 // It returns an empty string when the command accepts no arguments at all.
 func Usage(cl *CommandLine, operands ...string) string {
 	segments := requiredFlagUsage(cl)
@@ -33,9 +39,100 @@ func Usage(cl *CommandLine, operands ...string) string {
 	if hasOptionalFlags(cl) {
 		segments = append(segments, flagsPlaceholder)
 	}
+	if cl.passthrough != nil {
+		segments = append(segments, passthroughUsage(cl.passthrough, cl.interspersed))
+	}
+	return strings.Join(segments, " ")
+}
+
+// Synopsis returns the docopt-style synopsis of the arguments registered on cl,
+// excluding the command name itself. Unlike [Usage], every visible flag is
+// spelled out, so that the relationships between them read from the synopsis
+// alone:
+//
+//	<name> (--file <string> | --stdin) [--priority <int>]
+//
+// operands lead, followed by the positional arguments and the flags in lexical
+// order. A required flag is named bare and an optional one is bracketed. Flags
+// of which at least one is required form a parenthesised alternation, mutually
+// exclusive flags a bracketed alternation, and flags required together a single
+// bracketed sequence; each group is rendered once, in the position of its first
+// member. A [Passthrough] binding is rendered last, as it is by [Usage].
+//
+// It returns an empty string when the command accepts no arguments at all.
+func Synopsis(cl *CommandLine, operands ...string) string {
+	segments := slices.Clone(operands)
+	for _, p := range cl.positionals {
+		segments = append(segments, positionalUsage(p))
+	}
+	if cl.unmatched != nil {
+		segments = append(segments, unmatchedUsage(cl.unmatched))
+	}
+	segments = append(segments, flagSynopsis(cl)...)
+	if cl.passthrough != nil {
+		segments = append(segments, passthroughUsage(cl.passthrough, cl.interspersed))
+	}
 	return strings.Join(segments, " ")
 }
 
+// flagSynopsis returns a segment for every visible flag registered on cl, in
+// lexical order. A flag belonging to a group contributes to the group's single
+// segment, emitted in the position of the first member visited; a flag claimed
+// by an earlier group is not rendered again by a later one.
+func flagSynopsis(cl *CommandLine) []string {
+	var segments []string
+	emitted := map[string]struct{}{}
+	cl.flags.VisitAll(func(f *pflag.Flag) {
+		if _, ok := emitted[f.Name]; ok || f.Hidden {
+			return
+		}
+		var group []*pflag.Flag
+		open, separator, closing := "[", " ", "]"
+		switch {
+		case len(OneRequired(f)) > 0:
+			group = visibleMembers(cl, OneRequired(f), emitted)
+			open, separator, closing = "(", " | ", ")"
+		case len(MutuallyExclusive(f)) > 0:
+			group = visibleMembers(cl, MutuallyExclusive(f), emitted)
+			separator = " | "
+		case len(RequiredTogether(f)) > 0:
+			group = visibleMembers(cl, RequiredTogether(f), emitted)
+		case IsRequired(f):
+			open, closing = "", ""
+			group = []*pflag.Flag{f}
+		default:
+			group = []*pflag.Flag{f}
+		}
+		alternatives := make([]string, 0, len(group))
+		for _, member := range group {
+			emitted[member.Name] = struct{}{}
+			alternatives = append(alternatives, flagUsage(member))
+		}
+		if open == "(" && len(alternatives) == 1 {
+			open, closing = "", ""
+		}
+		segments = append(segments, open+strings.Join(alternatives, separator)+closing)
+	})
+	return segments
+}
+
+// visibleMembers returns the visible flags named by group that are not already
+// emitted, in the order group names them.
+func visibleMembers(cl *CommandLine, group []string, emitted map[string]struct{}) []*pflag.Flag {
+	members := make([]*pflag.Flag, 0, len(group))
+	for _, name := range group {
+		f := cl.flags.Lookup(name)
+		if f == nil || f.Hidden {
+			continue
+		}
+		if _, ok := emitted[name]; ok {
+			continue
+		}
+		members = append(members, f)
+	}
+	return members
+}
+
 // requiredFlagUsage returns a segment for every visible flag that must be
 // specified. Flags belonging to a group of which at least one member is
 // required contribute a single alternation segment, emitted in the position of
@@ -120,3 +217,18 @@ func unmatchedUsage(u *Unmatched) string {
 	}
 	return "[" + u.Name + "...]"
 }
+
+// passthroughUsage returns the synopsis of the binding handed every argument
+// after "--". The separator is optional when flags are not interspersed, since
+// parsing then stops at the first positional regardless.
+func passthroughUsage(p *Passthrough, interspersed bool) string {
+	separator := "--"
+	if !interspersed {
+		separator = "[--]"
+	}
+	usage := separator + " <" + p.Name + "> [args...]"
+	if p.Required {
+		return usage
+	}
+	return "[" + usage + "]"
+}
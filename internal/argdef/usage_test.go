@@ -54,6 +54,8 @@ func TestUsage(t *testing.T) {
 		requiredTogether []string
 		positionals      []*argdef.Positional
 		unmatched        *argdef.Unmatched
+		passthrough      *argdef.Passthrough
+		noIntersperse    bool
 		operands         []string
 		want             string
 	}{
@@ -160,6 +162,27 @@ func TestUsage(t *testing.T) {
 			},
 			unmatched: &argdef.Unmatched{Name: "names"},
 			want:      "--token <string> <src> [dst] [names...] [flags]",
+		}, {
+			name:        "RequiredPassthroughFollowsDash",
+			passthrough: &argdef.Passthrough{Name: "cmd", Required: true},
+			want:        "-- <cmd> [args...]",
+		}, {
+			name:        "OptionalPassthroughIsBracketed",
+			passthrough: &argdef.Passthrough{Name: "cmd"},
+			want:        "[-- <cmd> [args...]]",
+		}, {
+			name:          "PassthroughDashOptionalWithoutIntersperse",
+			passthrough:   &argdef.Passthrough{Name: "cmd", Required: true},
+			noIntersperse: true,
+			want:          "[--] <cmd> [args...]",
+		}, {
+			name:          "PassthroughFollowsPlaceholder",
+			optionalFlags: []string{"verbose"},
+			positionals: []*argdef.Positional{
+				{Name: "target", Index: 0, Required: true},
+			},
+			passthrough: &argdef.Passthrough{Name: "cmd", Required: true},
+			want:        "<target> [flags] -- <cmd> [args...]",
 		},
 	}
 
@@ -185,6 +208,10 @@ func TestUsage(t *testing.T) {
 			if tc.unmatched != nil {
 				argdef.SetUnmatched(cl, tc.unmatched)
 			}
+			if tc.passthrough != nil {
+				argdef.SetPassthrough(cl, tc.passthrough)
+			}
+			argdef.SetInterspersed(cl, !tc.noIntersperse)
 
 			// Act
 			usage := argdef.Usage(cl, tc.operands...)
@@ -196,3 +223,111 @@ func TestUsage(t *testing.T) {
 		})
 	}
 }
+
+func TestSynopsis(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name              string
+		optionalFlags     []string
+		requiredFlags     []string
+		optionalBools     []string
+		hiddenFlags       []string
+		oneRequired       []string
+		oneRequiredBools  []string
+		mutuallyExclusive []string
+		requiredTogether  []string
+		positionals       []*argdef.Positional
+		passthrough       *argdef.Passthrough
+		operands          []string
+		want              string
+	}{
+		{
+			name: "NoArguments",
+			want: "",
+		}, {
+			name:          "OptionalFlagBracketed",
+			optionalFlags: []string{"priority"},
+			want:          "[--priority <string>]",
+		}, {
+			name:          "RequiredFlagBare",
+			requiredFlags: []string{"token"},
+			want:          "--token <string>",
+		}, {
+			name:        "HiddenFlagOmitted",
+			hiddenFlags: []string{"debug"},
+			want:        "",
+		}, {
+			name:             "OneRequiredGroupParenthesised",
+			oneRequired:      []string{"file"},
+			oneRequiredBools: []string{"stdin"},
+			want:             "(--file <string> | --stdin)",
+		}, {
+			name:              "MutuallyExclusiveGroupBracketed",
+			mutuallyExclusive: []string{"json", "yaml"},
+			want:              "[--json <string> | --yaml <string>]",
+		}, {
+			name:             "RequiredTogetherGroupBracketedSequence",
+			requiredTogether: []string{"user", "password"},
+			want:             "[--password <string> --user <string>]",
+		}, {
+			name:          "FlagsInLexicalOrder",
+			optionalFlags: []string{"zulu"},
+			optionalBools: []string{"alpha"},
+			requiredFlags: []string{"mike"},
+			want:          "[--alpha] --mike <string> [--zulu <string>]",
+		}, {
+			name:             "PositionalsPrecedeFlags",
+			oneRequired:      []string{"file"},
+			oneRequiredBools: []string{"stdin"},
+			optionalFlags:    []string{"priority"},
+			positionals: []*argdef.Positional{
+				{Name: "name", Index: 0, Required: true},
+			},
+			want: "<name> (--file <string> | --stdin) [--priority <string>]",
+		}, {
+			name:          "OperandsLead",
+			operands:      []string{"<command>"},
+			optionalFlags: []string{"verbose"},
+			want:          "<command> [--verbose <string>]",
+		}, {
+			name:          "PassthroughTrails",
+			optionalBools: []string{"verbose"},
+			passthrough:   &argdef.Passthrough{Name: "cmd", Required: true},
+			want:          "[--verbose] -- <cmd> [args...]",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			cl := argdef.New()
+			fs := argdef.Flags(cl)
+			addStringFlags(fs, tc.optionalFlags)
+			addBoolFlags(fs, tc.optionalBools)
+			argdef.MarkRequired(addStringFlags(fs, tc.requiredFlags)...)
+			hideFlags(addStringFlags(fs, tc.hiddenFlags))
+			oneOf := addStringFlags(fs, tc.oneRequired)
+			oneOf = append(oneOf, addBoolFlags(fs, tc.oneRequiredBools)...)
+			argdef.MarkOneRequired(oneOf...)
+			argdef.MarkMutuallyExclusive(addStringFlags(fs, tc.mutuallyExclusive)...)
+			argdef.MarkRequiredTogether(addStringFlags(fs, tc.requiredTogether)...)
+			for _, p := range tc.positionals {
+				argdef.AddPositional(cl, p)
+			}
+			if tc.passthrough != nil {
+				argdef.SetPassthrough(cl, tc.passthrough)
+			}
+
+			// Act
+			synopsis := argdef.Synopsis(cl, tc.operands...)
+
+			// Assert
+			if got, want := synopsis, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Synopsis(...) = %q, want %q", got, want)
+			}
+		})
+	}
+}
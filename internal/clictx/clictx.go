@@ -5,6 +5,8 @@ import (
 	"io"
 	"os"
 
+	"github.com/bitwizeshift/go-cli/internal/argdef"
+	"github.com/bitwizeshift/go-cli/internal/session"
 	"github.com/bitwizeshift/go-cli/internal/storage"
 	"github.com/bitwizeshift/go-cli/internal/term"
 	"github.com/bitwizeshift/go-cli/richtext"
@@ -16,6 +18,12 @@ const (
 	ctxKeyIO ctxKey = iota
 	ctxKeySizer
 	ctxKeyStorage
+	ctxKeyArgs
+	ctxKeySession
+	ctxKeyInteraction
+	ctxKeyEditor
+	ctxKeyConversation
+	ctxKeyInput
 )
 
 type writerContext struct {
@@ -45,6 +53,21 @@ func Writers(ctx context.Context) (stdout, stderr io.Writer) {
 	return outStream, errStream
 }
 
+// WithInput returns a copy of ctx carrying in as the command's input stream,
+// retrievable with [Input].
+func WithInput(ctx context.Context, in io.Reader) context.Context {
+	return context.WithValue(ctx, ctxKeyInput, in)
+}
+
+// Input returns the input stream stored on ctx by [WithInput]. It returns
+// [os.Stdin] when ctx carries no stream.
+func Input(ctx context.Context) io.Reader {
+	if in, ok := ctx.Value(ctxKeyInput).(io.Reader); ok && in != nil {
+		return in
+	}
+	return os.Stdin
+}
+
 // WithSizer returns a copy of ctx carrying sizer as the policy for resolving
 // terminal width, retrievable through [Columns].
 func WithSizer(ctx context.Context, sizer term.Sizer) context.Context {
@@ -77,6 +100,98 @@ func Storage(ctx context.Context) *storage.AppStorage {
 	return nil
 }
 
+// WithArgs returns a copy of ctx carrying cl as the running command's resolved
+// arguments, retrievable with [Args].
+func WithArgs(ctx context.Context, cl *argdef.CommandLine) context.Context {
+	return context.WithValue(ctx, ctxKeyArgs, cl)
+}
+
+// Args returns the [argdef.CommandLine] stored on ctx by [WithArgs], or nil when
+// ctx carries none.
+func Args(ctx context.Context) *argdef.CommandLine {
+	if cl, ok := ctx.Value(ctxKeyArgs).(*argdef.CommandLine); ok {
+		return cl
+	}
+	return nil
+}
+
+// WithSession returns a copy of ctx carrying s as the session the command runs
+// within, retrievable with [Session].
+func WithSession(ctx context.Context, s *session.Session) context.Context {
+	return context.WithValue(ctx, ctxKeySession, s)
+}
+
+// Session returns the [session.Session] stored on ctx by [WithSession], or nil
+// when ctx carries none.
+func Session(ctx context.Context) *session.Session {
+	if s, ok := ctx.Value(ctxKeySession).(*session.Session); ok {
+		return s
+	}
+	return nil
+}
+
+// Interaction is the policy deciding how a command may interact with the user
+// while it runs.
+type Interaction struct {
+	// Interactive reports whether the user may be prompted. When it is false,
+	// every prompt fails rather than waiting for input that will never come.
+	Interactive bool
+
+	// AssumeYes accepts every confirmation without prompting for it.
+	AssumeYes bool
+}
+
+// WithInteraction returns a copy of ctx carrying policy as the interaction
+// policy of the running command, retrievable with [InteractionPolicy].
+func WithInteraction(ctx context.Context, policy Interaction) context.Context {
+	return context.WithValue(ctx, ctxKeyInteraction, policy)
+}
+
+// InteractionPolicy returns the [Interaction] stored on ctx by
+// [WithInteraction]. When ctx carries none, the user may be prompted and no
+// confirmation is assumed.
+func InteractionPolicy(ctx context.Context) Interaction {
+	if policy, ok := ctx.Value(ctxKeyInteraction).(Interaction); ok {
+		return policy
+	}
+	return Interaction{Interactive: true}
+}
+
+// EditFunc edits content as a text editor would, in a file with the extension
+// ext, returning the content that was saved.
+type EditFunc = func(ctx context.Context, content, ext string) (string, error)
+
+// WithEditor returns a copy of ctx carrying edit as the text editor prompts
+// edit through, retrievable with [Editor].
+func WithEditor(ctx context.Context, edit EditFunc) context.Context {
+	return context.WithValue(ctx, ctxKeyEditor, edit)
+}
+
+// Editor returns the [EditFunc] stored on ctx by [WithEditor], or nil when ctx
+// carries none.
+func Editor(ctx context.Context) EditFunc {
+	if edit, ok := ctx.Value(ctxKeyEditor).(EditFunc); ok {
+		return edit
+	}
+	return nil
+}
+
+// WithConversation returns a copy of ctx carrying conv as a scripted
+// conversation that prompts write to and read replies from in place of the
+// terminal, retrievable with [Conversation].
+func WithConversation(ctx context.Context, conv io.ReadWriter) context.Context {
+	return context.WithValue(ctx, ctxKeyConversation, conv)
+}
+
+// Conversation returns the scripted conversation stored on ctx by
+// [WithConversation], or nil when ctx carries none.
+func Conversation(ctx context.Context) io.ReadWriter {
+	if conv, ok := ctx.Value(ctxKeyConversation).(io.ReadWriter); ok {
+		return conv
+	}
+	return nil
+}
+
 // underlying returns the writer beneath w, following any writer that exposes a
 // Writer() io.Writer method, so sizing can reach the file descriptor of the real
 // terminal rather than a markup writer wrapped around it.
@@ -3,10 +3,14 @@ package clictx_test
 import (
 	"bytes"
 	"context"
+	"io"
+	"os"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/bitwizeshift/go-cli/internal/clictx"
+	"github.com/bitwizeshift/go-cli/internal/session"
 	"github.com/bitwizeshift/go-cli/internal/storage"
 	"github.com/bitwizeshift/go-cli/internal/storage/storagetest"
 	"github.com/bitwizeshift/go-cli/internal/term"
@@ -94,6 +98,196 @@ func TestStorage(t *testing.T) {
 	}
 }
 
+func TestSession(t *testing.T) {
+	t.Parallel()
+
+	s := session.New()
+
+	testCases := []struct {
+		name string
+		ctx  context.Context
+		want *session.Session
+	}{
+		{
+			name: "StoredSession",
+			ctx:  clictx.WithSession(context.Background(), s),
+			want: s,
+		},
+		{
+			name: "NoSession",
+			ctx:  context.Background(),
+			want: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			got := clictx.Session(tc.ctx)
+
+			// Assert
+			if want := tc.want; got != want {
+				t.Errorf("Session(ctx) = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestInteractionPolicy(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		ctx  context.Context
+		want clictx.Interaction
+	}{
+		{
+			name: "StoredPolicy",
+			ctx:  clictx.WithInteraction(context.Background(), clictx.Interaction{AssumeYes: true}),
+			want: clictx.Interaction{AssumeYes: true},
+		},
+		{
+			name: "NoPolicyIsInteractive",
+			ctx:  context.Background(),
+			want: clictx.Interaction{Interactive: true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			got := clictx.InteractionPolicy(tc.ctx)
+
+			// Assert
+			if want := tc.want; got != want {
+				t.Errorf("InteractionPolicy(ctx) = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestEditor(t *testing.T) {
+	t.Parallel()
+
+	edit := func(context.Context, string, string) (string, error) {
+		return "edited", nil
+	}
+
+	testCases := []struct {
+		name    string
+		ctx     context.Context
+		wantNil bool
+	}{
+		{
+			name: "StoredEditor",
+			ctx:  clictx.WithEditor(context.Background(), edit),
+		},
+		{
+			name:    "NoEditor",
+			ctx:     context.Background(),
+			wantNil: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			got := clictx.Editor(tc.ctx)
+
+			// Assert
+			if got, want := got == nil, tc.wantNil; got != want {
+				t.Fatalf("Editor(ctx) == nil = %t, want %t", got, want)
+			}
+			if got == nil {
+				return
+			}
+			if content, _ := got(tc.ctx, "", ""); content != "edited" {
+				t.Errorf("Editor(ctx)(...) = %q, want %q", content, "edited")
+			}
+		})
+	}
+}
+
+func TestInput(t *testing.T) {
+	t.Parallel()
+
+	in := strings.NewReader("input")
+
+	testCases := []struct {
+		name string
+		ctx  context.Context
+		want io.Reader
+	}{
+		{
+			name: "StoredInput",
+			ctx:  clictx.WithInput(context.Background(), in),
+			want: in,
+		},
+		{
+			name: "NoInputIsStdin",
+			ctx:  context.Background(),
+			want: os.Stdin,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			got := clictx.Input(tc.ctx)
+
+			// Assert
+			if want := tc.want; got != want {
+				t.Errorf("Input(ctx) = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestConversation(t *testing.T) {
+	t.Parallel()
+
+	conv := &bytes.Buffer{}
+
+	testCases := []struct {
+		name string
+		ctx  context.Context
+		want io.ReadWriter
+	}{
+		{
+			name: "StoredConversation",
+			ctx:  clictx.WithConversation(context.Background(), conv),
+			want: conv,
+		},
+		{
+			name: "NoConversation",
+			ctx:  context.Background(),
+			want: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			got := clictx.Conversation(tc.ctx)
+
+			// Assert
+			if want := tc.want; got != want {
+				t.Errorf("Conversation(ctx) = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
 func TestColumns(t *testing.T) {
 	t.Parallel()
 
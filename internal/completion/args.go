@@ -16,7 +16,7 @@ func ForArgs(fns map[int]Func, unmatched Func) cobra.CompletionFunc {
 	if len(fns) == 0 && unmatched == nil {
 		return nil
 	}
-	return func(_ *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
 		fn, ok := fns[len(args)]
 		if !ok {
 			fn = unmatched
@@ -24,7 +24,21 @@ func ForArgs(fns map[int]Func, unmatched Func) cobra.CompletionFunc {
 		if fn == nil {
 			return nil, cobra.ShellCompDirectiveDefault
 		}
-		values, directive := fn(toComplete)
-		return values, cobraDirective(directive)
+		return invoke(fn, cmd, args, toComplete)
+	}
+}
+
+// ForPassthrough wraps complete so that the argument being completed defers to
+// the shell's default file completion whenever claims reports, from the
+// arguments already supplied, that it is handed to a wrapped command. What a
+// wrapped command accepts is unknown, so files are the most useful guess. A nil
+// complete offers the same default for every other argument, as an uncompleted
+// command would.
+func ForPassthrough(complete cobra.CompletionFunc, claims func(args []string) bool) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if complete == nil || claims(args) {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+		return complete(cmd, args, toComplete)
 	}
 }
@@ -1,6 +1,7 @@
 package completion_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -20,15 +21,15 @@ type offered struct {
 // completerOf returns a completion function offering candidates with directive,
 // disregarding the word being completed.
 func completerOf(candidates []string, directive completion.Directive) completion.Func {
-	return func(string) ([]string, completion.Directive) {
+	return func(context.Context, completion.Request) ([]string, completion.Directive) {
 		return candidates, directive
 	}
 }
 
 // echoCompleter suffixes the word being completed, to observe that the word
 // reaches the registered function unaltered.
-func echoCompleter(toComplete string) ([]string, completion.Directive) {
-	return []string{toComplete + "-done"}, completion.NoFileComp
+func echoCompleter(_ context.Context, req completion.Request) ([]string, completion.Directive) {
+	return []string{req.ToComplete + "-done"}, completion.NoFileComp
 }
 
 func TestForArgs(t *testing.T) {
@@ -270,3 +271,67 @@ func TestForArgs_Nil(t *testing.T) {
 		})
 	}
 }
+
+func TestForPassthrough(t *testing.T) {
+	t.Parallel()
+
+	targets := completion.ForArgs(map[int]completion.Func{
+		0: completerOf([]string{"prod", "dev"}, completion.NoFileComp),
+	}, nil)
+
+	testCases := []struct {
+		name     string
+		complete cobra.CompletionFunc
+		claims   bool
+		want     offered
+	}{
+		{
+			name:     "UnclaimedDefersToComplete",
+			complete: targets,
+			claims:   false,
+			want: offered{
+				Candidates: []string{"prod", "dev"},
+				Directive:  cobra.ShellCompDirectiveNoFileComp,
+			},
+		},
+		{
+			name:     "ClaimedDefersToShell",
+			complete: targets,
+			claims:   true,
+			want: offered{
+				Candidates: nil,
+				Directive:  cobra.ShellCompDirectiveDefault,
+			},
+		},
+		{
+			name:     "NilCompleteDefersToShell",
+			complete: nil,
+			claims:   false,
+			want: offered{
+				Candidates: nil,
+				Directive:  cobra.ShellCompDirectiveDefault,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			sut := completion.ForPassthrough(tc.complete, func([]string) bool { return tc.claims })
+
+			// Act
+			candidates, directive := sut(nil, nil, "")
+
+			// Assert
+			offer := offered{
+				Candidates: candidates,
+				Directive:  directive,
+			}
+			if got, want := offer, tc.want; !cmp.Equal(got, want, cmpopts.EquateEmpty()) {
+				t.Errorf("ForPassthrough(...)(...) = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
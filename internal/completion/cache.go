@@ -0,0 +1,160 @@
+package completion
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/rand/v2"
+	"os"
+	"time"
+)
+
+// Store is the storage backend a [Cache] persists candidates through. It is
+// satisfied by the application's cache root, which already scopes writes beneath
+// an application-specific directory, so entries are addressed by bare names.
+type Store interface {
+	// ReadFile reads the entire contents of the named entry.
+	ReadFile(name string) ([]byte, error)
+
+	// WriteFile writes data to the named entry, creating it if absent.
+	WriteFile(name string, data []byte) error
+
+	// Rename moves oldname to newname, replacing any entry at newname.
+	Rename(oldname, newname string) error
+
+	// Remove removes the named entry.
+	Remove(name string) error
+}
+
+// Cache memoizes a [Func] through a [Store], so repeated completions within TTL
+// do not recompute their candidates.
+//
+// An entry younger than TTL is fresh and is offered as-is. An entry that has
+// been stale for less than a further TTL is still offered, while Revalidate
+// recomputes it for the completions that follow; an entry older than that is
+// recomputed before it is offered. Only successful completions are cached: a
+// [Func] reporting [Error] leaves any existing entry intact.
+//
+// Entries are published by writing a uniquely named temporary file and renaming
+// it into place, so shells completing concurrently never observe a partial
+// write; the last writer wins.
+type Cache struct {
+	// Func is the wrapped function consulted when no usable entry exists.
+	Func Func
+
+	// Key names the entry a request is cached under.
+	Key func(ctx context.Context, req Request) string
+
+	// TTL is how long a cached entry is considered fresh.
+	TTL time.Duration
+
+	// Store returns the backend entries are read from and written to under ctx,
+	// or nil to complete without caching.
+	Store func(ctx context.Context) Store
+
+	// Now reports the current time.
+	Now func() time.Time
+
+	// Revalidate runs refresh, which recomputes a stale entry, for the
+	// completion served under ctx. refresh reads the request, so it must finish
+	// before the request's flags are reset or parsed again; [Defer] runs it once
+	// the completion has been written.
+	Revalidate func(ctx context.Context, refresh func())
+}
+
+// cacheRecord is the on-disk representation of a cached completion.
+type cacheRecord struct {
+	Candidates []string  `json:"candidates"`
+	Directive  Directive `json:"directive"`
+	CachedAt   time.Time `json:"cached_at"`
+}
+
+// Complete satisfies [Func], offering the cached candidates for req when a
+// usable entry exists and otherwise consulting the wrapped function.
+func (c *Cache) Complete(ctx context.Context, req Request) ([]string, Directive) {
+	store := c.Store(ctx)
+	if store == nil {
+		return c.Func(ctx, req)
+	}
+	name := entryName(c.Key(ctx, req))
+	record, ok := c.cached(store, name)
+	if ok {
+		age := c.Now().Sub(record.CachedAt)
+		switch {
+		case age < c.TTL:
+			return record.Candidates, record.Directive
+		case age < 2*c.TTL:
+			c.Revalidate(ctx, func() {
+				c.refresh(ctx, req, store, name)
+			})
+			return record.Candidates, record.Directive
+		}
+	}
+	return c.refresh(ctx, req, store, name)
+}
+
+// cached returns the entry stored under name and whether it was found. A
+// missing or unreadable entry reports false.
+func (c *Cache) cached(store Store, name string) (cacheRecord, bool) {
+	data, err := store.ReadFile(name)
+	if err != nil {
+		return cacheRecord{}, false
+	}
+	var record cacheRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return cacheRecord{}, false
+	}
+	return record, true
+}
+
+// refresh consults the wrapped function and caches a successful result under
+// name.
+func (c *Cache) refresh(ctx context.Context, req Request, store Store, name string) ([]string, Directive) {
+	candidates, directive := c.Func(ctx, req)
+	if directive != Error {
+		c.store(store, name, cacheRecord{
+			Candidates: candidates,
+			Directive:  directive,
+			CachedAt:   c.Now(),
+		})
+	}
+	return candidates, directive
+}
+
+// store best-effort publishes record under name. Write failures are ignored: a
+// failed cache write must not fail a completion that otherwise succeeded.
+func (c *Cache) store(store Store, name string, record cacheRecord) {
+	data, _ := json.Marshal(record)
+	tmp := fmt.Sprintf("%s.%d-%x.tmp", name, os.Getpid(), rand.Uint64())
+	if err := store.WriteFile(tmp, data); err != nil {
+		return
+	}
+	if err := store.Rename(tmp, name); err != nil {
+		_ = store.Remove(tmp)
+	}
+}
+
+// Invalidate discards the entries cached under keys through store, so the next
+// completion for each recomputes its candidates. It is not an error for an
+// entry to be absent.
+func Invalidate(store Store, keys ...string) error {
+	var errs []error
+	for _, key := range keys {
+		err := store.Remove(entryName(key))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// entryName returns the name of the entry cached under key. The key is hashed
+// so that any string yields a valid, fixed-length name.
+func entryName(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return "completion/" + hex.EncodeToString(sum[:16]) + ".json"
+}
@@ -0,0 +1,248 @@
+package completion_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/bitwizeshift/go-cli/internal/completion"
+	"github.com/bitwizeshift/go-cli/internal/storage/storagetest"
+)
+
+// cacheEpoch is the time at which the cache tests seed their entries.
+var cacheEpoch = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// cacheTTL is the freshness window used across the cache tests.
+const cacheTTL = time.Hour
+
+// sequence is a completion function that offers the candidate at the head of
+// values on each call, recording how many times it was called.
+type sequence struct {
+	values    []string
+	directive completion.Directive
+	calls     int
+}
+
+func (s *sequence) complete(context.Context, completion.Request) ([]string, completion.Directive) {
+	value := s.values[min(s.calls, len(s.values)-1)]
+	s.calls++
+	return []string{value}, s.directive
+}
+
+// newCache returns a cache over fn and store that keys every request by its
+// command path, reads the time from now, and revalidates synchronously,
+// counting each revalidation in revalidated.
+func newCache(fn completion.Func, store completion.Store, now *time.Time, revalidated *int) *completion.Cache {
+	return &completion.Cache{
+		Func: fn,
+		Key: func(_ context.Context, req completion.Request) string {
+			return req.CommandPath
+		},
+		TTL: cacheTTL,
+		Store: func(context.Context) completion.Store {
+			return store
+		},
+		Now: func() time.Time { return *now },
+		Revalidate: func(_ context.Context, refresh func()) {
+			*revalidated++
+			refresh()
+		},
+	}
+}
+
+func TestCache_Complete(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name            string
+		age             time.Duration
+		want            []string
+		wantRevalidated int
+		wantNext        []string
+	}{
+		{
+			name:     "FreshEntryIsOffered",
+			age:      30 * time.Minute,
+			want:     []string{"first"},
+			wantNext: []string{"first"},
+		}, {
+			name:            "StaleEntryIsOfferedAndRevalidated",
+			age:             90 * time.Minute,
+			want:            []string{"first"},
+			wantRevalidated: 1,
+			wantNext:        []string{"second"},
+		}, {
+			name:     "ExpiredEntryIsRecomputed",
+			age:      3 * time.Hour,
+			want:     []string{"second"},
+			wantNext: []string{"second"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			fn := &sequence{values: []string{"first", "second"}, directive: completion.NoFileComp}
+			now := cacheEpoch
+			revalidated := 0
+			sut := newCache(fn.complete, storagetest.New("cache"), &now, &revalidated)
+			req := completion.Request{CommandPath: "app remote rm"}
+			_, _ = sut.Complete(t.Context(), req)
+			now = cacheEpoch.Add(tc.age)
+
+			// Act
+			got, _ := sut.Complete(t.Context(), req)
+			next, _ := sut.Complete(t.Context(), req)
+
+			// Assert
+			if want := tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Complete(...) = %v, want %v", got, want)
+			}
+			if got, want := revalidated, tc.wantRevalidated; got != want {
+				t.Errorf("Complete(...) revalidated %d times, want %d", got, want)
+			}
+			if got, want := next, tc.wantNext; !cmp.Equal(got, want) {
+				t.Errorf("Complete(...) after revalidation = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestCache_Complete_KeepsDirective(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	fn := &sequence{values: []string{"json"}, directive: completion.FilterFileExt}
+	now := cacheEpoch
+	revalidated := 0
+	sut := newCache(fn.complete, storagetest.New("cache"), &now, &revalidated)
+	_, _ = sut.Complete(t.Context(), completion.Request{})
+
+	// Act
+	_, directive := sut.Complete(t.Context(), completion.Request{})
+
+	// Assert
+	if got, want := directive, completion.FilterFileExt; got != want {
+		t.Errorf("Complete(...) directive = %v, want %v", got, want)
+	}
+	if got, want := fn.calls, 1; got != want {
+		t.Errorf("Complete(...) called the completer %d times, want %d", got, want)
+	}
+}
+
+func TestCache_Complete_ErrorIsNotCached(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	fn := &sequence{values: []string{"unused"}, directive: completion.Error}
+	now := cacheEpoch
+	revalidated := 0
+	sut := newCache(fn.complete, storagetest.New("cache"), &now, &revalidated)
+	_, _ = sut.Complete(t.Context(), completion.Request{})
+
+	// Act
+	_, directive := sut.Complete(t.Context(), completion.Request{})
+
+	// Assert
+	if got, want := directive, completion.Error; got != want {
+		t.Errorf("Complete(...) directive = %v, want %v", got, want)
+	}
+	if got, want := fn.calls, 2; got != want {
+		t.Errorf("Complete(...) called the completer %d times, want %d", got, want)
+	}
+}
+
+func TestCache_Complete_NoStore_CompletesUncached(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	fn := &sequence{values: []string{"first", "second"}, directive: completion.NoFileComp}
+	now := cacheEpoch
+	revalidated := 0
+	sut := newCache(fn.complete, nil, &now, &revalidated)
+	sut.Store = func(context.Context) completion.Store { return nil }
+	_, _ = sut.Complete(t.Context(), completion.Request{})
+
+	// Act
+	got, _ := sut.Complete(t.Context(), completion.Request{})
+
+	// Assert
+	if want := []string{"second"}; !cmp.Equal(got, want) {
+		t.Errorf("Complete(...) = %v, want %v", got, want)
+	}
+}
+
+func TestCache_Complete_ConcurrentCompletions(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	store := storagetest.New("cache")
+	completers := make([]*completion.Cache, 8)
+	for i := range completers {
+		completers[i] = &completion.Cache{
+			Func: func(context.Context, completion.Request) ([]string, completion.Directive) {
+				return []string{"origin", "upstream"}, completion.NoFileComp
+			},
+			Key:        func(context.Context, completion.Request) string { return "remotes" },
+			TTL:        cacheTTL,
+			Store:      func(context.Context) completion.Store { return store },
+			Now:        func() time.Time { return cacheEpoch },
+			Revalidate: func(_ context.Context, refresh func()) { refresh() },
+		}
+	}
+
+	// Act
+	results := make([][]string, len(completers))
+	var wg sync.WaitGroup
+	for i, sut := range completers {
+		wg.Go(func() {
+			results[i], _ = sut.Complete(t.Context(), completion.Request{})
+		})
+	}
+	wg.Wait()
+	entries, err := store.ReadDir("completion")
+
+	// Assert
+	for _, got := range results {
+		if want := []string{"origin", "upstream"}; !cmp.Equal(got, want) {
+			t.Errorf("Complete(...) = %v, want %v", got, want)
+		}
+	}
+	if err != nil {
+		t.Fatalf("ReadDir(completion) = %v, want nil", err)
+	}
+	if got, want := len(entries), 1; got != want {
+		t.Errorf("ReadDir(completion) = %d entries, want %d", got, want)
+	}
+}
+
+func TestInvalidate(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	fn := &sequence{values: []string{"first", "second"}, directive: completion.NoFileComp}
+	store := storagetest.New("cache")
+	now := cacheEpoch
+	revalidated := 0
+	sut := newCache(fn.complete, store, &now, &revalidated)
+	req := completion.Request{CommandPath: "app remote rm"}
+	_, _ = sut.Complete(t.Context(), req)
+
+	// Act
+	err := completion.Invalidate(store, "app remote rm", "never cached")
+	got, _ := sut.Complete(t.Context(), req)
+
+	// Assert
+	if got, want := err, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Fatalf("Invalidate(...) = %v, want %v", got, want)
+	}
+	if want := []string{"second"}; !cmp.Equal(got, want) {
+		t.Errorf("Complete(...) after Invalidate = %v, want %v", got, want)
+	}
+}
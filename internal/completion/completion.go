@@ -1,6 +1,7 @@
 package completion
 
 import (
+	"context"
 	"fmt"
 	"runtime"
 	"sync"
@@ -32,12 +33,67 @@ const (
 
 	// FilterDirs completes directory names only.
 	FilterDirs
+
+	// Error reports that candidates could not be computed, offering none.
+	Error
 )
 
-// Func computes completion candidates for the partial word toComplete,
-// returning the candidates and a [Directive] describing how the shell should
-// treat them.
-type Func = func(toComplete string) ([]string, Directive)
+// Request describes the word being completed and the command line typed ahead
+// of it.
+type Request struct {
+	// ToComplete is the partial word being completed.
+	ToComplete string
+
+	// Args are the positional arguments preceding the word being completed, in
+	// command-line order.
+	Args []string
+
+	// Flags holds the command's flags, parsed from the command line typed so
+	// far. It is nil when the request is made outside of a command.
+	Flags *pflag.FlagSet
+
+	// CommandPath is the space-delimited path of the command being completed,
+	// such as "app remote add".
+	CommandPath string
+}
+
+// Func computes completion candidates for the request req, returning the
+// candidates and a [Directive] describing how the shell should treat them. A
+// candidate may carry a description after a tab, as "value\tdescription".
+type Func = func(ctx context.Context, req Request) ([]string, Directive)
+
+// Preparer readies the context a [Func] runs under for cmd, resolving the
+// arguments typed so far the way an invocation of cmd would, without running
+// it. args are the positional arguments preceding the word being completed.
+type Preparer = func(cmd *cobra.Command, args []string) context.Context
+
+// Prepared wraps complete so that prepare readies the command's context before
+// complete runs. A nil prepare leaves complete unchanged.
+func Prepared(complete cobra.CompletionFunc, prepare Preparer) cobra.CompletionFunc {
+	if complete == nil || prepare == nil {
+		return complete
+	}
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		cmd.SetContext(prepare(cmd, args))
+		return complete(cmd, args, toComplete)
+	}
+}
+
+// invoke calls fn with a request for toComplete built from cmd, which may be
+// nil, under the command's context.
+func invoke(fn Func, cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	ctx := context.Background()
+	req := Request{ToComplete: toComplete, Args: args}
+	if cmd != nil {
+		if cmd.Context() != nil {
+			ctx = cmd.Context()
+		}
+		req.Flags = cmd.Flags()
+		req.CommandPath = cmd.CommandPath()
+	}
+	values, directive := fn(ctx, req)
+	return values, cobraDirective(directive)
+}
 
 // mu guards funcs and current. funcs is the process-wide registry of completion
 // functions, keyed by the identifier stored in a flag's [Annotation]; current is
@@ -84,19 +140,20 @@ func FlagFunc(f *pflag.Flag) Func {
 
 // RegisterFlags walks the flags of cmd and registers a cobra completion function
 // for each flag annotated via [AddFlag], translating its [Directive] into the
-// corresponding [cobra.ShellCompDirective].
-func RegisterFlags(cmd *cobra.Command) {
+// corresponding [cobra.ShellCompDirective]. Each function runs under the context
+// readied by prepare, which may be nil.
+func RegisterFlags(cmd *cobra.Command, prepare Preparer) {
 	cmd.Flags().VisitAll(func(f *pflag.Flag) {
 		fn, ok := lookup(f)
 		if !ok {
 			return
 		}
+		complete := func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			return invoke(fn, cmd, args, toComplete)
+		}
 		// The flag is guaranteed to exist and to have no prior completion, so
 		// this error cannot occur.
-		_ = cmd.RegisterFlagCompletionFunc(f.Name, func(_ *cobra.Command, _ []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
-			values, directive := fn(toComplete)
-			return values, cobraDirective(directive)
-		})
+		_ = cmd.RegisterFlagCompletionFunc(f.Name, Prepared(complete, prepare))
 	})
 }
 
@@ -123,6 +180,8 @@ func cobraDirective(directive Directive) cobra.ShellCompDirective {
 		return cobra.ShellCompDirectiveFilterFileExt
 	case FilterDirs:
 		return cobra.ShellCompDirectiveFilterDirs
+	case Error:
+		return cobra.ShellCompDirectiveError
 	default:
 		return cobra.ShellCompDirectiveDefault
 	}
@@ -1,6 +1,7 @@
 package completion_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -18,7 +19,7 @@ func TestAddFlag(t *testing.T) {
 	target := newStringFlag("flag")
 
 	// Act
-	completion.AddFlag(target, func(string) ([]string, completion.Directive) {
+	completion.AddFlag(target, func(context.Context, completion.Request) ([]string, completion.Directive) {
 		return nil, completion.Default
 	})
 	ids := len(target.Annotations[completion.Annotation])
@@ -34,8 +35,8 @@ func TestFlagFunc_FlagWithCompletion_ReturnsCompletion(t *testing.T) {
 
 	// Arrange
 	target := newStringFlag("flag")
-	completion.AddFlag(target, func(toComplete string) ([]string, completion.Directive) {
-		return []string{toComplete + "-value"}, completion.NoFileComp
+	completion.AddFlag(target, func(_ context.Context, req completion.Request) ([]string, completion.Directive) {
+		return []string{req.ToComplete + "-value"}, completion.NoFileComp
 	})
 
 	// Act
@@ -133,10 +134,10 @@ func TestRegisterFlags(t *testing.T) {
 
 			// Arrange
 			cmd := newStringFlagCommand("flag")
-			completion.AddFlag(cmd.Flags().Lookup("flag"), func(string) ([]string, completion.Directive) {
+			completion.AddFlag(cmd.Flags().Lookup("flag"), func(context.Context, completion.Request) ([]string, completion.Directive) {
 				return tc.values, tc.directive
 			})
-			completion.RegisterFlags(cmd)
+			completion.RegisterFlags(cmd, nil)
 
 			// Act
 			values, directive := invokeCompletion(t, cmd, "flag", "")
@@ -159,7 +160,7 @@ func TestRegisterFlags_Unannotated_RegistersNothing(t *testing.T) {
 	cmd := newStringFlagCommand("flag")
 
 	// Act
-	completion.RegisterFlags(cmd)
+	completion.RegisterFlags(cmd, nil)
 
 	// Assert
 	if _, got := cmd.GetFlagCompletionFunc("flag"); got != false {
@@ -190,7 +191,7 @@ func complete(t testing.TB, fn completion.Func, toComplete string) ([]string, co
 	if fn == nil {
 		t.Fatalf("FlagFunc(...) = nil, want a completion function")
 	}
-	return fn(toComplete)
+	return fn(context.Background(), completion.Request{ToComplete: toComplete})
 }
 
 // invokeCompletion invokes the completion function registered on cmd for the
@@ -203,3 +204,33 @@ func invokeCompletion(t testing.TB, cmd *cobra.Command, name, toComplete string)
 	}
 	return complete(cmd, nil, toComplete)
 }
+
+func TestPrepared(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	type ctxKey struct{}
+	var preparedArgs []string
+	prepare := func(cmd *cobra.Command, args []string) context.Context {
+		preparedArgs = args
+		return context.WithValue(context.Background(), ctxKey{}, "prepared")
+	}
+	var seen any
+	fn := completion.ForArgs(nil, func(ctx context.Context, _ completion.Request) ([]string, completion.Directive) {
+		seen = ctx.Value(ctxKey{})
+		return nil, completion.NoFileComp
+	})
+	sut := completion.Prepared(fn, prepare)
+	cmd := &cobra.Command{Use: "root"}
+
+	// Act
+	sut(cmd, []string{"first"}, "")
+
+	// Assert
+	if got, want := preparedArgs, []string{"first"}; !cmp.Equal(got, want) {
+		t.Errorf("Preparer args = %v, want %v", got, want)
+	}
+	if got, want := seen, any("prepared"); got != want {
+		t.Errorf("Func context value = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,62 @@
+package completion
+
+import (
+	"context"
+	"sync"
+)
+
+// ctxKeyDeferred is the context key under which [WithDeferred] stores the work
+// collected by [Defer].
+type ctxKeyDeferred struct{}
+
+// deferred is the work collected by [Defer] under a context.
+type deferred struct {
+	mu  sync.Mutex
+	fns []func()
+}
+
+// WithDeferred returns a copy of ctx under which [Defer] collects work instead
+// of running it, along with a function that runs the work collected so far, in
+// the order it was deferred. The caller runs it once the completion served
+// under ctx has been written, and before the arguments that completion resolved
+// are reset or parsed again.
+func WithDeferred(ctx context.Context) (context.Context, func()) {
+	d := &deferred{}
+	return context.WithValue(ctx, ctxKeyDeferred{}, d), d.run
+}
+
+// Defer arranges for fn to run after the completion served under ctx has been
+// written, when ctx was returned by [WithDeferred]. Otherwise fn runs at once.
+func Defer(ctx context.Context, fn func()) {
+	d, ok := ctx.Value(ctxKeyDeferred{}).(*deferred)
+	if !ok {
+		fn()
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.fns = append(d.fns, fn)
+}
+
+// Pending reports whether work has been deferred under ctx, as returned by
+// [WithDeferred], and not yet run.
+func Pending(ctx context.Context) bool {
+	d, ok := ctx.Value(ctxKeyDeferred{}).(*deferred)
+	if !ok {
+		return false
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.fns) > 0
+}
+
+// run runs and forgets the work collected so far.
+func (d *deferred) run() {
+	d.mu.Lock()
+	fns := d.fns
+	d.fns = nil
+	d.mu.Unlock()
+	for _, fn := range fns {
+		fn()
+	}
+}
@@ -0,0 +1,104 @@
+package completion_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/bitwizeshift/go-cli/internal/completion"
+)
+
+func TestDefer(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		collect   bool
+		wantFirst []string
+		wantAfter []string
+	}{
+		{
+			name:      "WithoutCollector_RunsAtOnce",
+			wantFirst: []string{"a", "b"},
+			wantAfter: []string{"a", "b"},
+		}, {
+			name:      "WithCollector_RunsInOrderWhenRun",
+			collect:   true,
+			wantFirst: nil,
+			wantAfter: []string{"a", "b"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			ctx, run := context.Background(), func() {}
+			if tc.collect {
+				ctx, run = completion.WithDeferred(ctx)
+			}
+			var ran []string
+
+			// Act
+			completion.Defer(ctx, func() { ran = append(ran, "a") })
+			completion.Defer(ctx, func() { ran = append(ran, "b") })
+			first := append([]string(nil), ran...)
+			run()
+			run()
+
+			// Assert
+			if got, want := first, tc.wantFirst; !cmp.Equal(got, want, cmpopts.EquateEmpty()) {
+				t.Errorf("ran before run() = %v, want %v", got, want)
+			}
+			if got, want := ran, tc.wantAfter; !cmp.Equal(got, want) {
+				t.Errorf("ran after run() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestPending(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		collect  bool
+		deferred bool
+		run      bool
+		want     bool
+	}{
+		{name: "WithoutCollector", deferred: true, want: false},
+		{name: "NothingDeferred", collect: true, want: false},
+		{name: "Deferred", collect: true, deferred: true, want: true},
+		{name: "DeferredAndRun", collect: true, deferred: true, run: true, want: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			ctx, run := context.Background(), func() {}
+			if tc.collect {
+				ctx, run = completion.WithDeferred(ctx)
+			}
+			if tc.deferred {
+				completion.Defer(ctx, func() {})
+			}
+			if tc.run {
+				run()
+			}
+
+			// Act
+			got := completion.Pending(ctx)
+
+			// Assert
+			if want := tc.want; got != want {
+				t.Errorf("completion.Pending(ctx) = %t, want %t", got, want)
+			}
+		})
+	}
+}
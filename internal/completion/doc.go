@@ -9,5 +9,9 @@ reachable without its command. Positional and unmatched arguments have no such
 carrier, and cobra permits only a single completion function per command for
 them, so their completers are instead reconciled by the index of the argument
 being completed.
+
+Each function receives a [Request] describing the command line typed ahead of
+the word being completed, and runs under a context that a [Preparer] readies by
+resolving those arguments as an invocation would.
 */
 package completion
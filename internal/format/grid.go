@@ -2,7 +2,6 @@ package format
 
 import (
 	"strings"
-	"unicode/utf8"
 )
 
 // Row is a single row of a two-column [Grid]: an aligned left marker column and
@@ -13,7 +12,7 @@ type Row struct {
 	Marker string
 
 	// MarkerWidth is the visible width of Marker used for column alignment. When
-	// zero, the rune count of Marker is used instead.
+	// zero, the [Width] of Marker is used instead.
 	MarkerWidth int
 
 	// Description is the right column. It is plain text, wrapped to fit the width
@@ -26,7 +25,7 @@ func (r Row) width() int {
 	if r.MarkerWidth > 0 {
 		return r.MarkerWidth
 	}
-	return utf8.RuneCountInString(r.Marker)
+	return Width(r.Marker)
 }
 
 // Grid renders rows as a two-column layout: markers aligned to the widest
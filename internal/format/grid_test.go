@@ -114,6 +114,25 @@ func TestGrid(t *testing.T) {
 			indent:  2,
 			gap:     3,
 			want:    "  --a-very-long-marker   desc\n  -b                     other",
+		}, {
+			name: "WideMarkerAlignsByDisplayWidth",
+			rows: []format.Row{
+				{Marker: "同期", Description: "sync"},
+				{Marker: "add", Description: "add"},
+			},
+			columns: 80,
+			indent:  2,
+			gap:     3,
+			want:    "  同期   sync\n  add    add",
+		}, {
+			name: "WideDescriptionWrapsByDisplayWidth",
+			rows: []format.Row{
+				{Marker: "-a", Description: "リモートと同期する"},
+			},
+			columns: 15,
+			indent:  2,
+			gap:     3,
+			want:    "  -a   リモート\n       と同期す\n       る",
 		},
 	}
 
@@ -13,11 +13,12 @@ var (
 const continuationPad = "  "
 
 // Resize reflows s to fit within columns of width per line, breaking at
-// whitespace word boundaries. Words longer than columns are emitted whole and
-// exceed the limit. Two or more consecutive newlines are preserved as a
-// paragraph break. Lines starting with "* ", "- ", "N." or "N)" (each
-// followed by a space) are treated as bullets; wrapped continuation lines of
-// a bullet are indented by two spaces.
+// whitespace word boundaries and between wide characters, as measured by
+// [Width]. Words longer than columns are emitted whole and exceed the limit.
+// Two or more consecutive newlines are preserved as a paragraph break. Lines
+// starting with "* ", "- ", "N." or "N)" (each followed by a space) are treated
+// as bullets; wrapped continuation lines of a bullet are indented by two
+// spaces.
 func Resize(s string, columns int) string {
 	if columns <= 0 {
 		return s
@@ -148,25 +149,50 @@ func renderBullet(b resizeBlock, columns int) string {
 // line and restWidth for every subsequent line. A single word longer than the
 // applicable width occupies its own line and is allowed to overflow.
 func wrap(text string, firstWidth, restWidth int) []string {
-	words := strings.Fields(text)
+	words := splitWords(text)
 	if len(words) == 0 {
 		return nil
 	}
 	var lines []string
-	current := words[0]
+	current, used := words[0].text, Width(words[0].text)
 	width := firstWidth
-	for _, word := range words[1:] {
-		if len(current)+1+len(word) <= width {
-			current = current + " " + word
+	for _, w := range words[1:] {
+		sep := ""
+		if w.spaced {
+			sep = " "
+		}
+		size := Width(w.text)
+		if used+len(sep)+size <= width {
+			current += sep + w.text
+			used += len(sep) + size
 			continue
 		}
 		lines = append(lines, current)
-		current = word
+		current, used = w.text, size
 		width = restWidth
 	}
 	return append(lines, current)
 }
 
+// wrapWord is a piece of text that wrap never breaks, preceded by a space
+// when spaced and joined directly to the previous piece otherwise.
+type wrapWord struct {
+	text   string
+	spaced bool
+}
+
+// splitWords splits text into the pieces wrap may break between: its
+// whitespace-separated words, each further split by [Breaks].
+func splitWords(text string) []wrapWord {
+	var words []wrapWord
+	for i, field := range strings.Fields(text) {
+		for j, piece := range Breaks(field) {
+			words = append(words, wrapWord{text: piece, spaced: i > 0 && j == 0})
+		}
+	}
+	return words
+}
+
 func atLeast(v, min int) int {
 	if v < min {
 		return min
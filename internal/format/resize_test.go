@@ -202,6 +202,31 @@ func TestResize(t *testing.T) {
 			text:    "9. nine items here\n10. ten items here",
 			columns: 12,
 			want:    "9. nine\n  items here\n10. ten\n  items here",
+		}, {
+			name:    "WideCharactersCountTwoColumns",
+			text:    "日本語 の 説明",
+			columns: 8,
+			want:    "日本語\nの 説明",
+		}, {
+			name:    "WideTextWithoutSpacesBreaksBetweenCharacters",
+			text:    "設定ファイルを読み込みます",
+			columns: 10,
+			want:    "設定ファイ\nルを読み込\nみます",
+		}, {
+			name:    "ClosingPunctuationNeverStartsLine",
+			text:    "同期します。完了",
+			columns: 8,
+			want:    "同期しま\nす。完了",
+		}, {
+			name:    "CombiningMarksAddNoWidth",
+			text:    "cafe\u0301 cafe\u0301",
+			columns: 9,
+			want:    "cafe\u0301 cafe\u0301",
+		}, {
+			name:    "EmojiSequenceKeptWhole",
+			text:    "ok 👩‍💻 done",
+			columns: 5,
+			want:    "ok 👩‍💻\ndone",
 		},
 	}
 
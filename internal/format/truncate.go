@@ -1,21 +1,32 @@
 package format
 
+import (
+	"github.com/rivo/uniseg"
+)
+
 const ellipsis = "…"
 
 // Truncate shortens s to at most width visible columns, replacing the trailing
-// runes with a single-rune ellipsis when it must cut. It returns s unchanged
-// when it already fits or when width <= 0. It counts runes, not bytes, and does
-// not interpret ANSI escape sequences.
+// characters with a single-column ellipsis when it must cut. It returns s
+// unchanged when it already fits or when width <= 0. It measures columns as
+// [Width] does and cuts only between grapheme clusters, so a wide character
+// that would straddle the limit is dropped whole, and a combining mark is
+// never separated from its base. It does not interpret ANSI escape sequences.
 func Truncate(s string, width int) string {
-	if width <= 0 {
-		return s
-	}
-	runes := []rune(s)
-	if len(runes) <= width {
+	if width <= 0 || Width(s) <= width {
 		return s
 	}
-	if width == 1 {
-		return ellipsis
+	used, end := 0, 0
+	state := -1
+	for rest := s; rest != ""; {
+		var cluster string
+		var size int
+		cluster, rest, size, state = uniseg.FirstGraphemeClusterInString(rest, state)
+		if used+size > width-1 {
+			break
+		}
+		used += size
+		end += len(cluster)
 	}
-	return string(runes[:width-1]) + ellipsis
+	return s[:end] + ellipsis
 }
@@ -57,6 +57,26 @@ func TestTruncate(t *testing.T) {
 			text:  "café",
 			width: 4,
 			want:  "café",
+		}, {
+			name:  "WideCharactersCountTwoColumns",
+			text:  "日本語の説明",
+			width: 7,
+			want:  "日本語…",
+		}, {
+			name:  "WideCharacterStraddlingLimitDropped",
+			text:  "日本語の説明",
+			width: 6,
+			want:  "日本…",
+		}, {
+			name:  "CombiningMarkKeptWithBase",
+			text:  "cafe\u0301s and more",
+			width: 6,
+			want:  "cafe\u0301s…",
+		}, {
+			name:  "EmojiSequenceNeverSplit",
+			text:  "👩‍💻👩‍💻 coders",
+			width: 4,
+			want:  "👩‍💻…",
 		},
 	}
 
@@ -0,0 +1,60 @@
+package format
+
+import (
+	"github.com/rivo/uniseg"
+)
+
+// Width returns the number of terminal columns s occupies. It measures whole
+// grapheme clusters, so East Asian wide and fullwidth characters and most emoji
+// count as two columns, while combining marks, variation selectors, and the
+// joiners within an emoji sequence add nothing to the character they modify.
+// Like [Truncate], it does not interpret ANSI escape sequences.
+func Width(s string) int {
+	return uniseg.StringWidth(s)
+}
+
+// Breaks splits word, a run of text containing no whitespace, at the points
+// where a line may break within it without a space. These are the line break
+// opportunities beside a wide character, which lets text written without
+// spaces between its words, such as Chinese or Japanese, wrap between its
+// characters, while never starting a line with closing punctuation such as
+// "。". Narrow text, such as "well-known", is never broken. Joining the pieces
+// Breaks returns yields word.
+func Breaks(word string) []string {
+	var pieces []string
+	start, end := 0, 0
+	prev := ""
+	state := -1
+	for rest := word; rest != ""; {
+		var segment string
+		segment, rest, _, state = uniseg.FirstLineSegmentInString(rest, state)
+		if end > start && (isWide(lastCluster(prev)) || isWide(firstCluster(segment))) {
+			pieces = append(pieces, word[start:end])
+			start = end
+		}
+		end += len(segment)
+		prev = segment
+	}
+	return append(pieces, word[start:end])
+}
+
+// isWide reports whether cluster occupies two columns.
+func isWide(cluster string) bool {
+	return uniseg.StringWidth(cluster) > 1
+}
+
+// firstCluster returns the first grapheme cluster of s.
+func firstCluster(s string) string {
+	cluster, _, _, _ := uniseg.FirstGraphemeClusterInString(s, -1)
+	return cluster
+}
+
+// lastCluster returns the last grapheme cluster of s.
+func lastCluster(s string) string {
+	last := ""
+	state := -1
+	for s != "" {
+		last, s, _, state = uniseg.FirstGraphemeClusterInString(s, state)
+	}
+	return last
+}
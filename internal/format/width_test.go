@@ -0,0 +1,119 @@
+package format_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/bitwizeshift/go-cli/internal/format"
+)
+
+func TestWidth(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		text string
+		want int
+	}{
+		{
+			name: "Empty",
+			text: "",
+			want: 0,
+		}, {
+			name: "ASCII",
+			text: "hello",
+			want: 5,
+		}, {
+			name: "EastAsianWide",
+			text: "日本語",
+			want: 6,
+		}, {
+			name: "Fullwidth",
+			text: "ＡＢ",
+			want: 4,
+		}, {
+			name: "CombiningMark",
+			text: "é",
+			want: 1,
+		}, {
+			name: "Emoji",
+			text: "🚀",
+			want: 2,
+		}, {
+			name: "EmojiZWJSequence",
+			text: "👩‍💻",
+			want: 2,
+		}, {
+			name: "FlagSequence",
+			text: "🇯🇵",
+			want: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			text := tc.text
+
+			// Act
+			width := format.Width(text)
+
+			// Assert
+			if got, want := width, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Width(%q) = %d, want %d", text, got, want)
+			}
+		})
+	}
+}
+
+func TestBreaks(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		word string
+		want []string
+	}{
+		{
+			name: "NarrowWordUnbroken",
+			word: "well-known",
+			want: []string{"well-known"},
+		}, {
+			name: "WideCharactersBreakBetween",
+			word: "日本語",
+			want: []string{"日", "本", "語"},
+		}, {
+			name: "ClosingPunctuationStaysWithPrevious",
+			word: "です。",
+			want: []string{"で", "す。"},
+		}, {
+			name: "NarrowRunBesideWideBreaksAtBoundary",
+			word: "Go言語",
+			want: []string{"Go", "言", "語"},
+		}, {
+			name: "EmojiSequenceKeptWhole",
+			word: "👩‍💻👩‍💻",
+			want: []string{"👩‍💻", "👩‍💻"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			word := tc.word
+
+			// Act
+			pieces := format.Breaks(word)
+
+			// Assert
+			if got, want := pieces, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Breaks(%q) = %q, want %q", word, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,3 @@
+// Package interact holds what the packages that prompt the user, and those that
+// report on prompting, share without depending on one another.
+package interact
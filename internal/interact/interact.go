@@ -0,0 +1,8 @@
+package interact
+
+import "errors"
+
+// ErrNonInteractive is returned by a prompt made while the running command may
+// not interact with the user. It is exported as
+// [github.com/bitwizeshift/go-cli/prompt.ErrNonInteractive].
+var ErrNonInteractive = errors.New("prompt: input required but not interactive")
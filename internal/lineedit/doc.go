@@ -0,0 +1,14 @@
+/*
+Package lineedit reads lines of input with interactive editing, in the manner of
+readline.
+
+An [Editor] places the terminal in raw mode while a line is read, so that it can
+interpret cursor movement, deletion, history navigation, and tab completion
+itself, redrawing the line after every keystroke. When the streams are not an
+interactive terminal, it degrades to reading whole lines as they arrive, so the
+same code path serves a scripted pipe and a person at a keyboard.
+
+Entered lines are recorded in a [History], which can be loaded from and saved
+to any reader or writer so that callers decide where it persists.
+*/
+package lineedit
@@ -0,0 +1,411 @@
+package lineedit
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/bitwizeshift/go-cli/internal/format"
+	"github.com/bitwizeshift/go-cli/internal/term"
+	xterm "golang.org/x/term"
+)
+
+// ErrInterrupted is returned by [Editor.ReadLine] when the user abandons the
+// line with Ctrl-C.
+var ErrInterrupted = errors.New("input interrupted")
+
+// CompleteFunc returns the completion candidates for the last word of head,
+// the text before the cursor. Each candidate is a full replacement for that
+// word.
+type CompleteFunc = func(head string) []string
+
+// Editor reads lines from In, echoing and redrawing them on Out.
+//
+// While a line is read, Console places the terminal in raw mode so the editor
+// can interpret each keystroke:
+//
+//	Left, Right, Ctrl-B, Ctrl-F   move by character
+//	Alt-B, Alt-F, Ctrl-Left/Right move by word
+//	Home, End, Ctrl-A, Ctrl-E     move to the start or end of the line
+//	Backspace, Delete             delete a character
+//	Ctrl-W                        delete the word before the cursor
+//	Ctrl-K, Ctrl-U                delete to the end or start of the line
+//	Up, Down, Ctrl-P, Ctrl-N      recall entries from History
+//	Tab                           complete the word before the cursor
+//	Ctrl-L                        clear the screen
+//	Ctrl-C                        abandon the line
+//	Ctrl-D                        end input on an empty line
+//
+// A line too long for the terminal scrolls horizontally, so the cursor stays in
+// view without the line wrapping onto the next row.
+//
+// When Console is nil, or cannot enter raw mode because Out is not an
+// interactive terminal, lines are read whole and without editing.
+type Editor struct {
+	Out io.Writer
+	In  io.Reader
+
+	// Console toggles the terminal attached to Out into raw mode.
+	Console term.EchoDisabler
+
+	// History records entered lines and supplies them for recall. It may be
+	// nil, in which case no history is kept.
+	History *History
+
+	// Complete supplies tab-completion candidates. It may be nil, in which case
+	// Tab is ignored.
+	Complete CompleteFunc
+
+	// Sizer reports the width of the terminal attached to Out. It may be nil,
+	// in which case the terminal is asked directly, falling back to 80
+	// columns.
+	Sizer term.Sizer
+
+	reader *bufio.Reader
+}
+
+// ReadLine writes prompt to Out and returns the line entered, without its line
+// terminator. Every non-blank line is recorded in History.
+//
+// It returns [ErrInterrupted] when the line is abandoned with Ctrl-C, and
+// [io.EOF] when input ends, or Ctrl-D is pressed, on an empty line.
+func (e *Editor) ReadLine(prompt string) (string, error) {
+	return e.ReadLineContext(context.Background(), prompt)
+}
+
+// ReadLineContext behaves as [Editor.ReadLine], except that it returns
+// ctx.Err() if ctx is cancelled before the line is entered. The terminal is
+// restored before it returns either way.
+func (e *Editor) ReadLineContext(ctx context.Context, prompt string) (line string, err error) {
+	if e.reader == nil {
+		e.reader = bufio.NewReader(e.In)
+	}
+	var restore term.RestoreFunc
+	rawErr := errNoConsole
+	if e.Console != nil {
+		restore, rawErr = e.Console.DisableEcho(e.Out)
+	}
+	if rawErr != nil {
+		line, err = e.readBuffered(ctx, prompt)
+	} else {
+		defer func() {
+			err = errors.Join(err, restore())
+		}()
+		line, err = e.readRaw(ctx, prompt)
+	}
+	if err == nil && e.History != nil {
+		e.History.Add(line)
+	}
+	return line, err
+}
+
+// errNoConsole reports that an [Editor] has no Console to enter raw mode with.
+var errNoConsole = errors.New("no console")
+
+// readBuffered writes prompt and returns the next whole line of input.
+func (e *Editor) readBuffered(ctx context.Context, prompt string) (string, error) {
+	if _, err := io.WriteString(e.Out, prompt); err != nil {
+		return "", err
+	}
+	line, err := await(ctx, func() (string, error) {
+		return e.reader.ReadString('\n')
+	})
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		// No newline was echoed, so move off the prompt line before returning.
+		_, _ = io.WriteString(e.Out, "\n")
+		return "", ctxErr
+	}
+	if err != nil && !(errors.Is(err, io.EOF) && line != "") {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// readRaw edits a line keystroke by keystroke, with the terminal in raw mode.
+func (e *Editor) readRaw(ctx context.Context, prompt string) (string, error) {
+	s := &state{prompt: prompt, out: e.Out, cols: e.columns()}
+	if e.History != nil {
+		s.history = e.History.Entries()
+	}
+	s.index = len(s.history)
+	s.refresh()
+	for {
+		k, r, err := ReadKeyContext(ctx, e.reader)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			s.newline()
+			return "", ctxErr
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) && len(s.buf) > 0 {
+				s.newline()
+				return string(s.buf), nil
+			}
+			return "", err
+		}
+		switch k {
+		case KeyEnter:
+			s.newline()
+			return string(s.buf), nil
+		case KeyInterrupt:
+			_, _ = io.WriteString(e.Out, "^C")
+			s.newline()
+			return "", ErrInterrupted
+		case KeyEOF:
+			if len(s.buf) == 0 {
+				s.newline()
+				return "", io.EOF
+			}
+			s.deleteAt(s.pos)
+		case KeyTab:
+			e.complete(s)
+		default:
+			s.edit(k, r)
+		}
+		s.refresh()
+	}
+}
+
+// terminalSizer reads the width of the terminal itself. Unlike
+// [term.DefaultSizer], it is not clamped, since a line drawn wider than the
+// terminal wraps.
+var terminalSizer = term.FallbackSizer{
+	term.TTYFuncSizer(xterm.GetSize),
+	term.FixedSizer(80),
+}
+
+// columns returns the width of the terminal attached to Out.
+func (e *Editor) columns() int {
+	sizer := e.Sizer
+	if sizer == nil {
+		sizer = terminalSizer
+	}
+	if cols := sizer.Columns(e.Out); cols > 0 {
+		return cols
+	}
+	return 80
+}
+
+// await returns the result of read, or ctx.Err() if ctx is cancelled first. A
+// read abandoned on cancellation finishes in the background. A context that
+// can never be cancelled is read from directly.
+func await[T any](ctx context.Context, read func() (T, error)) (T, error) {
+	var zero T
+	if ctx.Done() == nil {
+		return read()
+	}
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+	type result struct {
+		value T
+		err   error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		value, err := read()
+		ch <- result{value: value, err: err}
+	}()
+	select {
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case res := <-ch:
+		return res.value, res.err
+	}
+}
+
+// complete replaces the word before the cursor with the longest prefix common
+// to its completion candidates, followed by a space when only one candidate
+// remains. When the word cannot be extended, the candidates are listed beneath
+// the line.
+func (e *Editor) complete(s *state) {
+	if e.Complete == nil {
+		return
+	}
+	head := string(s.buf[:s.pos])
+	candidates := e.Complete(head)
+	if len(candidates) == 0 {
+		return
+	}
+	start := strings.LastIndexFunc(head, unicode.IsSpace) + 1
+	word := head[start:]
+	prefix := commonPrefix(candidates)
+	if len(candidates) == 1 && !strings.HasSuffix(prefix, "/") && !strings.HasSuffix(prefix, "=") {
+		prefix += " "
+	}
+	if prefix != word && strings.HasPrefix(prefix, word) {
+		s.insert([]rune(prefix[len(word):])...)
+		return
+	}
+	if len(candidates) > 1 {
+		_, _ = io.WriteString(e.Out, "\r\n"+strings.Join(candidates, "  ")+"\r\n")
+	}
+}
+
+// commonPrefix returns the longest prefix shared by every candidate.
+func commonPrefix(candidates []string) string {
+	prefix := candidates[0]
+	for _, candidate := range candidates[1:] {
+		for !strings.HasPrefix(candidate, prefix) {
+			_, size := utf8.DecodeLastRuneInString(prefix)
+			prefix = prefix[:len(prefix)-size]
+		}
+	}
+	return prefix
+}
+
+// state is the line being edited: its text, the cursor position within it,
+// the first rune shown on a terminal cols wide, and the position of the
+// history entry it was recalled from.
+type state struct {
+	prompt string
+	out    io.Writer
+	cols   int
+	offset int
+
+	buf []rune
+	pos int
+
+	history []string
+	index   int
+	pending []rune
+}
+
+// edit applies the editing action k, with r the rune typed for [KeyRune].
+func (s *state) edit(k Key, r rune) {
+	switch k {
+	case KeyRune:
+		s.insert(r)
+	case KeyBackspace:
+		if s.pos > 0 {
+			s.pos--
+			s.deleteAt(s.pos)
+		}
+	case KeyDelete:
+		s.deleteAt(s.pos)
+	case KeyLeft:
+		s.pos = max(s.pos-1, 0)
+	case KeyRight:
+		s.pos = min(s.pos+1, len(s.buf))
+	case KeyWordLeft:
+		s.pos = s.wordStart()
+	case KeyWordRight:
+		s.pos = s.wordEnd()
+	case KeyHome:
+		s.pos = 0
+	case KeyEnd:
+		s.pos = len(s.buf)
+	case KeyKillToEnd:
+		s.buf = s.buf[:s.pos]
+	case KeyKillToStart:
+		s.buf = s.buf[s.pos:]
+		s.pos = 0
+	case KeyKillWord:
+		start := s.wordStart()
+		s.buf = append(s.buf[:start], s.buf[s.pos:]...)
+		s.pos = start
+	case KeyUp:
+		s.recall(s.index - 1)
+	case KeyDown:
+		s.recall(s.index + 1)
+	case KeyClear:
+		_, _ = io.WriteString(s.out, "\x1b[H\x1b[2J")
+	}
+}
+
+// insert inserts runes at the cursor, advancing it past them.
+func (s *state) insert(runes ...rune) {
+	buf := make([]rune, 0, len(s.buf)+len(runes))
+	buf = append(buf, s.buf[:s.pos]...)
+	buf = append(buf, runes...)
+	s.buf = append(buf, s.buf[s.pos:]...)
+	s.pos += len(runes)
+}
+
+// deleteAt removes the rune at index i, if there is one.
+func (s *state) deleteAt(i int) {
+	if i < len(s.buf) {
+		s.buf = append(s.buf[:i], s.buf[i+1:]...)
+	}
+}
+
+// wordStart returns the start of the word before the cursor, skipping any
+// spaces immediately before it.
+func (s *state) wordStart() int {
+	i := s.pos
+	for i > 0 && unicode.IsSpace(s.buf[i-1]) {
+		i--
+	}
+	for i > 0 && !unicode.IsSpace(s.buf[i-1]) {
+		i--
+	}
+	return i
+}
+
+// wordEnd returns the end of the word after the cursor, skipping any spaces
+// immediately after it.
+func (s *state) wordEnd() int {
+	i := s.pos
+	for i < len(s.buf) && unicode.IsSpace(s.buf[i]) {
+		i++
+	}
+	for i < len(s.buf) && !unicode.IsSpace(s.buf[i]) {
+		i++
+	}
+	return i
+}
+
+// recall replaces the line with the history entry at index, where the index
+// one past the newest entry holds the line as it was before recall began.
+func (s *state) recall(index int) {
+	if index < 0 || index > len(s.history) {
+		return
+	}
+	if s.index == len(s.history) {
+		s.pending = s.buf
+	}
+	s.index = index
+	if index == len(s.history) {
+		s.buf = s.pending
+	} else {
+		s.buf = []rune(s.history[index])
+	}
+	s.pos = len(s.buf)
+}
+
+// refresh redraws the prompt and line, and places the cursor. Columns are
+// counted by display width, so wide characters and combining marks are
+// stepped over as the terminal draws them. Only as much of the line as fits
+// beside the prompt is drawn, scrolled to keep the cursor in view; the last
+// column is left empty, since writing to it wraps on some terminals.
+func (s *state) refresh() {
+	room := max(s.cols-1-format.Width(s.prompt), 1)
+	if s.pos < s.offset {
+		s.offset = s.pos
+	}
+	for s.offset < s.pos && format.Width(string(s.buf[s.offset:s.pos])) > room {
+		s.offset++
+	}
+	end := s.pos
+	for end < len(s.buf) && format.Width(string(s.buf[s.offset:end+1])) <= room {
+		end++
+	}
+	shown := s.buf[s.offset:end]
+
+	var sb strings.Builder
+	sb.WriteString("\r" + s.prompt + string(shown) + "\x1b[K")
+	if back := format.Width(string(shown)) - format.Width(string(s.buf[s.offset:s.pos])); back > 0 {
+		fmt.Fprintf(&sb, "\x1b[%dD", back)
+	}
+	_, _ = io.WriteString(s.out, sb.String())
+}
+
+// newline moves to the start of the next line. The terminal is in raw mode, so
+// "\r\n" is needed to return to the first column as well as move down.
+func (s *state) newline() {
+	_, _ = io.WriteString(s.out, "\r\n")
+}
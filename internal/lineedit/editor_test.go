@@ -0,0 +1,381 @@
+package lineedit_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/bitwizeshift/go-cli/internal/lineedit"
+	"github.com/bitwizeshift/go-cli/internal/term"
+	"github.com/bitwizeshift/go-cli/internal/term/termtest"
+)
+
+func TestEditor_ReadLine_Raw(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		input   string
+		history []string
+		want    string
+		wantErr error
+	}{
+		{
+			name:  "PlainText",
+			input: "hello\r",
+			want:  "hello",
+		}, {
+			name:  "Backspace",
+			input: "helpx\x7f\x7flo\r",
+			want:  "hello",
+		}, {
+			name:  "InsertAfterLeftArrow",
+			input: "hllo\x1b[D\x1b[D\x1b[De\r",
+			want:  "hello",
+		}, {
+			name:  "HomeAndEnd",
+			input: "ello\x01h\x05!\r",
+			want:  "hello!",
+		}, {
+			name:  "DeleteKey",
+			input: "hxello\x01\x1b[C\x1b[3~\r",
+			want:  "hello",
+		}, {
+			name:  "KillToEnd",
+			input: "hello world\x1bb\x0b\r",
+			want:  "hello ",
+		}, {
+			name:  "KillToStart",
+			input: "hello world\x1bb\x15\r",
+			want:  "world",
+		}, {
+			name:  "KillWord",
+			input: "hello big world\x1bb\x17\r",
+			want:  "hello world",
+		}, {
+			name:  "WordRight",
+			input: "one three\x01\x1b[1;5C two\r",
+			want:  "one two three",
+		}, {
+			name:    "HistoryUp",
+			input:   "\x1b[A\x1b[A\r",
+			history: []string{"first", "second"},
+			want:    "first",
+		}, {
+			name:    "HistoryDownRestoresPending",
+			input:   "draft\x1b[A\x1b[B\r",
+			history: []string{"first"},
+			want:    "draft",
+		}, {
+			name:    "HistoryUpStopsAtOldest",
+			input:   "\x10\x10\x10\r",
+			history: []string{"first"},
+			want:    "first",
+		}, {
+			name:    "Interrupt",
+			input:   "partial\x03",
+			wantErr: lineedit.ErrInterrupted,
+		}, {
+			name:    "CtrlDOnEmptyLine",
+			input:   "\x04",
+			wantErr: io.EOF,
+		}, {
+			name:  "CtrlDDeletesUnderCursor",
+			input: "hello!\x1b[D\x04\r",
+			want:  "hello",
+		}, {
+			name:  "EndOfInputReturnsLine",
+			input: "unterminated",
+			want:  "unterminated",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			history := lineedit.NewHistory(0)
+			for _, entry := range tc.history {
+				history.Add(entry)
+			}
+			sut := &lineedit.Editor{
+				Out:     io.Discard,
+				In:      strings.NewReader(tc.input),
+				Console: termtest.NoOpEchoDisabler(),
+				History: history,
+			}
+
+			// Act
+			got, err := sut.ReadLine("> ")
+
+			// Assert
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("ReadLine(...) error = %v, want %v", got, want)
+			}
+			if want := tc.want; got != want {
+				t.Errorf("ReadLine(...) = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestEditor_ReadLine_Complete(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		input      string
+		candidates []string
+		want       string
+	}{
+		{
+			name:       "SingleCandidateAddsSpace",
+			input:      "remote a\t\r",
+			candidates: []string{"add"},
+			want:       "remote add ",
+		}, {
+			name:       "CommonPrefixExtends",
+			input:      "remote re\t\r",
+			candidates: []string{"remove", "removal"},
+			want:       "remote remov",
+		}, {
+			name:       "DirectoryKeepsCursorAdjacent",
+			input:      "open do\t\r",
+			candidates: []string{"docs/"},
+			want:       "open docs/",
+		}, {
+			name:       "NoCandidates",
+			input:      "remote x\t\r",
+			candidates: nil,
+			want:       "remote x",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			var heads []string
+			sut := &lineedit.Editor{
+				Out:     io.Discard,
+				In:      strings.NewReader(tc.input),
+				Console: termtest.NoOpEchoDisabler(),
+				Complete: func(head string) []string {
+					heads = append(heads, head)
+					return tc.candidates
+				},
+			}
+
+			// Act
+			got, err := sut.ReadLine("> ")
+
+			// Assert
+			if err != nil {
+				t.Fatalf("ReadLine(...) error = %v, want nil", err)
+			}
+			if want := tc.want; got != want {
+				t.Errorf("ReadLine(...) = %q, want %q", got, want)
+			}
+			if got, want := heads, []string{strings.TrimSuffix(tc.input, "\t\r")}; !cmp.Equal(got, want) {
+				t.Errorf("Complete(...) heads = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestEditor_ReadLine_NotTerminal_ReadsWholeLines(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	var out bytes.Buffer
+	history := lineedit.NewHistory(0)
+	sut := &lineedit.Editor{
+		Out:     &out,
+		In:      strings.NewReader("first\x1b[D\nsecond\n"),
+		Console: termtest.ErrEchoDisabler(term.ErrNotDescriptor),
+		History: history,
+	}
+
+	// Act
+	first, firstErr := sut.ReadLine("> ")
+	second, secondErr := sut.ReadLine("> ")
+	_, lastErr := sut.ReadLine("> ")
+
+	// Assert
+	if err := errors.Join(firstErr, secondErr); err != nil {
+		t.Fatalf("ReadLine(...) error = %v, want nil", err)
+	}
+	if got, want := []string{first, second}, []string{"first\x1b[D", "second"}; !cmp.Equal(got, want) {
+		t.Errorf("ReadLine(...) = %q, want %q", got, want)
+	}
+	if got, want := lastErr, io.EOF; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Errorf("ReadLine(...) at end of input = %v, want %v", got, want)
+	}
+	if got, want := out.String(), "> > > "; got != want {
+		t.Errorf("ReadLine(...) wrote %q, want %q", got, want)
+	}
+	if got, want := history.Entries(), []string{"first\x1b[D", "second"}; !cmp.Equal(got, want) {
+		t.Errorf("History.Entries() = %q, want %q", got, want)
+	}
+}
+
+func TestEditor_ReadLine_RestoresTerminal(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	console := &termtest.Recorder{}
+	sut := &lineedit.Editor{
+		Out:     io.Discard,
+		In:      strings.NewReader("line\r"),
+		Console: console,
+	}
+
+	// Act
+	_, err := sut.ReadLine("> ")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("ReadLine(...) error = %v, want nil", err)
+	}
+	if got, want := console.Disabled, false; got != want {
+		t.Errorf("ReadLine(...) left raw mode enabled = %t, want %t", got, want)
+	}
+}
+
+func TestEditor_ReadLine_Redraw(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		input string
+		cols  int
+		want  string
+	}{
+		{
+			name:  "WideCharacters",
+			input: "日本語\x1b[D",
+			cols:  80,
+			want:  "\r> 日本語\x1b[K\x1b[2D",
+		}, {
+			name:  "CombiningMark",
+			input: "e\u0301x\x1b[D",
+			cols:  80,
+			want:  "\r> e\u0301x\x1b[K\x1b[1D",
+		}, {
+			name:  "LongLineScrollsToCursor",
+			input: "abcdefghij",
+			cols:  10,
+			want:  "\r> defghij\x1b[K",
+		}, {
+			name:  "LongLineScrollsBackToStart",
+			input: "abcdefghij\x01",
+			cols:  10,
+			want:  "\r> abcdefg\x1b[K\x1b[7D",
+		}, {
+			name:  "WideCharacterNotSplitAtEdge",
+			input: "日本語日本",
+			cols:  10,
+			want:  "\r> 語日本\x1b[K",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			var out bytes.Buffer
+			sut := &lineedit.Editor{
+				Out:     &out,
+				In:      strings.NewReader(tc.input + "\r"),
+				Console: termtest.NoOpEchoDisabler(),
+				Sizer:   term.FixedSizer(tc.cols),
+			}
+
+			// Act
+			_, err := sut.ReadLine("> ")
+
+			// Assert
+			if err != nil {
+				t.Fatalf("ReadLine(...) error = %v, want nil", err)
+			}
+			if got, want := out.String(), tc.want+"\r\n"; !strings.HasSuffix(got, want) {
+				t.Errorf("ReadLine(...) wrote %q, want suffix %q", got, want)
+			}
+		})
+	}
+}
+
+// blockingReader is an io.Reader whose Read never returns, simulating input that
+// never arrives so cancellation can be exercised.
+type blockingReader struct{}
+
+func (blockingReader) Read([]byte) (int, error) {
+	<-make(chan struct{})
+	return 0, nil
+}
+
+func TestEditor_ReadLineContext_Cancelled(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		console term.EchoDisabler
+		wantOut string
+	}{
+		{
+			name:    "Terminal",
+			console: &termtest.Recorder{},
+			wantOut: "\r> \x1b[K\r\n",
+		}, {
+			name:    "NotTerminal",
+			console: termtest.ErrEchoDisabler(term.ErrNotDescriptor),
+			wantOut: "> \n",
+		}, {
+			name:    "NoConsole",
+			console: nil,
+			wantOut: "> \n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			var out bytes.Buffer
+			sut := &lineedit.Editor{
+				Out:     &out,
+				In:      blockingReader{},
+				Console: tc.console,
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			// Act
+			got, err := sut.ReadLineContext(ctx, "> ")
+
+			// Assert
+			if got, want := err, context.Canceled; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("ReadLineContext(...) error = %v, want %v", got, want)
+			}
+			if want := ""; got != want {
+				t.Errorf("ReadLineContext(...) = %q, want %q", got, want)
+			}
+			if got, want := out.String(), tc.wantOut; got != want {
+				t.Errorf("ReadLineContext(...) wrote %q, want %q", got, want)
+			}
+			if recorder, ok := tc.console.(*termtest.Recorder); ok && recorder.Disabled {
+				t.Errorf("ReadLineContext(...) left raw mode enabled")
+			}
+		})
+	}
+}
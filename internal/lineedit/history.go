@@ -0,0 +1,59 @@
+package lineedit
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// History is an ordered record of entered lines, oldest first, holding at most
+// a fixed number of entries. The zero value holds no entries and is unbounded.
+type History struct {
+	entries []string
+	limit   int
+}
+
+// NewHistory returns an empty [History] that retains at most limit entries,
+// discarding the oldest once full. A non-positive limit retains every entry.
+func NewHistory(limit int) *History {
+	return &History{limit: limit}
+}
+
+// Add records line as the newest entry. A blank line, or one repeating the
+// newest entry, is not recorded.
+func (h *History) Add(line string) {
+	if strings.TrimSpace(line) == "" {
+		return
+	}
+	if n := len(h.entries); n > 0 && h.entries[n-1] == line {
+		return
+	}
+	h.entries = append(h.entries, line)
+	if h.limit > 0 && len(h.entries) > h.limit {
+		h.entries = h.entries[len(h.entries)-h.limit:]
+	}
+}
+
+// Entries returns the recorded entries, oldest first.
+func (h *History) Entries() []string {
+	return h.entries
+}
+
+// Load records each line read from r as an entry, as [History.Add] would.
+func (h *History) Load(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		h.Add(scanner.Text())
+	}
+	return scanner.Err()
+}
+
+// Save writes the entries to w, oldest first, one per line.
+func (h *History) Save(w io.Writer) error {
+	for _, entry := range h.entries {
+		if _, err := io.WriteString(w, entry+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
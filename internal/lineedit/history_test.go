@@ -0,0 +1,85 @@
+package lineedit_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/bitwizeshift/go-cli/internal/lineedit"
+)
+
+func TestHistory_Add(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		limit int
+		lines []string
+		want  []string
+	}{
+		{
+			name:  "RecordsInOrder",
+			lines: []string{"first", "second"},
+			want:  []string{"first", "second"},
+		}, {
+			name:  "SkipsBlankLines",
+			lines: []string{"first", "  ", ""},
+			want:  []string{"first"},
+		}, {
+			name:  "SkipsImmediateRepeats",
+			lines: []string{"first", "first", "second", "first"},
+			want:  []string{"first", "second", "first"},
+		}, {
+			name:  "DiscardsOldestBeyondLimit",
+			limit: 2,
+			lines: []string{"first", "second", "third"},
+			want:  []string{"second", "third"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			sut := lineedit.NewHistory(tc.limit)
+
+			// Act
+			for _, line := range tc.lines {
+				sut.Add(line)
+			}
+
+			// Assert
+			if got, want := sut.Entries(), tc.want; !cmp.Equal(got, want, cmpopts.EquateEmpty()) {
+				t.Errorf("Entries() = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestHistory_SaveLoad_RoundTrips(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	saved := lineedit.NewHistory(0)
+	saved.Add("remote add origin")
+	saved.Add("status")
+	var sb strings.Builder
+	if err := saved.Save(&sb); err != nil {
+		t.Fatalf("Save(...) = %v, want nil", err)
+	}
+	sut := lineedit.NewHistory(0)
+
+	// Act
+	err := sut.Load(strings.NewReader(sb.String()))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Load(...) = %v, want nil", err)
+	}
+	if got, want := sut.Entries(), saved.Entries(); !cmp.Equal(got, want) {
+		t.Errorf("Entries() = %q, want %q", got, want)
+	}
+}
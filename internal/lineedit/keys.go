@@ -0,0 +1,185 @@
+package lineedit
+
+import (
+	"bufio"
+	"context"
+)
+
+// Key identifies an action decoded from terminal input. Keys that name
+// editing actions, such as [KeyKillWord], are named for what [Editor] does with
+// them; other readers of [ReadKey] may interpret them as they see fit.
+type Key int
+
+const (
+	KeyRune        Key = iota // a printable rune
+	KeyEnter                  // Enter, Ctrl-J, Ctrl-M
+	KeyInterrupt              // Ctrl-C
+	KeyEOF                    // Ctrl-D
+	KeyTab                    // Tab
+	KeyBackspace              // Backspace, Ctrl-H
+	KeyDelete                 // Delete
+	KeyLeft                   // Left, Ctrl-B
+	KeyRight                  // Right, Ctrl-F
+	KeyWordLeft               // Alt-B, Ctrl-Left, Alt-Left
+	KeyWordRight              // Alt-F, Ctrl-Right, Alt-Right
+	KeyHome                   // Home, Ctrl-A
+	KeyEnd                    // End, Ctrl-E
+	KeyUp                     // Up, Ctrl-P
+	KeyDown                   // Down, Ctrl-N
+	KeyPageUp                 // Page Up
+	KeyPageDown               // Page Down
+	KeyKillToEnd              // Ctrl-K
+	KeyKillToStart            // Ctrl-U
+	KeyKillWord               // Ctrl-W
+	KeyClear                  // Ctrl-L
+	KeyIgnored                // any input without an action
+)
+
+// controlKeys maps the control characters the editor interprets to their
+// actions.
+var controlKeys = map[rune]Key{
+	0x01: KeyHome,        // Ctrl-A
+	0x02: KeyLeft,        // Ctrl-B
+	0x03: KeyInterrupt,   // Ctrl-C
+	0x04: KeyEOF,         // Ctrl-D
+	0x05: KeyEnd,         // Ctrl-E
+	0x06: KeyRight,       // Ctrl-F
+	0x08: KeyBackspace,   // Ctrl-H
+	0x09: KeyTab,         // Tab
+	0x0a: KeyEnter,       // Ctrl-J
+	0x0b: KeyKillToEnd,   // Ctrl-K
+	0x0c: KeyClear,       // Ctrl-L
+	0x0d: KeyEnter,       // Ctrl-M
+	0x0e: KeyDown,        // Ctrl-N
+	0x10: KeyUp,          // Ctrl-P
+	0x15: KeyKillToStart, // Ctrl-U
+	0x17: KeyKillWord,    // Ctrl-W
+	0x7f: KeyBackspace,   // Delete, as sent by the backspace key
+}
+
+// csiKeys maps the final byte of an unparameterised CSI or SS3 sequence to its
+// action.
+var csiKeys = map[rune]Key{
+	'A': KeyUp,
+	'B': KeyDown,
+	'C': KeyRight,
+	'D': KeyLeft,
+	'H': KeyHome,
+	'F': KeyEnd,
+}
+
+// tildeKeys maps the parameter of a "CSI n ~" sequence to its action.
+var tildeKeys = map[string]Key{
+	"1": KeyHome,
+	"3": KeyDelete,
+	"4": KeyEnd,
+	"5": KeyPageUp,
+	"6": KeyPageDown,
+	"7": KeyHome,
+	"8": KeyEnd,
+}
+
+// ReadKey decodes the next keystroke from r, returning its action and, for
+// [KeyRune], the rune typed. The terminal must be in raw mode for keystrokes to
+// arrive as they are typed.
+func ReadKey(r *bufio.Reader) (Key, rune, error) {
+	c, _, err := r.ReadRune()
+	if err != nil {
+		return KeyIgnored, 0, err
+	}
+	if c == 0x1b {
+		return readEscape(r)
+	}
+	if k, ok := controlKeys[c]; ok {
+		return k, 0, nil
+	}
+	if c < 0x20 {
+		return KeyIgnored, 0, nil
+	}
+	return KeyRune, c, nil
+}
+
+// ReadKeyContext behaves as [ReadKey], except that it returns ctx.Err() if ctx
+// is cancelled before a keystroke arrives. Nothing is read from r until it is
+// called, so input after the keystroke is left for the next reader of r.
+func ReadKeyContext(ctx context.Context, r *bufio.Reader) (Key, rune, error) {
+	ks, err := await(ctx, func() (keystroke, error) {
+		k, c, err := ReadKey(r)
+		return keystroke{key: k, rune: c}, err
+	})
+	return ks.key, ks.rune, err
+}
+
+// keystroke is a key decoded by [ReadKey], with the rune typed for [KeyRune].
+type keystroke struct {
+	key  Key
+	rune rune
+}
+
+// readEscape decodes the remainder of an escape sequence whose introducing ESC
+// has been read. Unrecognised sequences are consumed and ignored.
+func readEscape(r *bufio.Reader) (Key, rune, error) {
+	c, _, err := r.ReadRune()
+	if err != nil {
+		return KeyIgnored, 0, err
+	}
+	switch c {
+	case 'b':
+		return KeyWordLeft, 0, nil
+	case 'f':
+		return KeyWordRight, 0, nil
+	case 'O':
+		c, _, err := r.ReadRune()
+		if err != nil {
+			return KeyIgnored, 0, err
+		}
+		if k, ok := csiKeys[c]; ok {
+			return k, 0, nil
+		}
+		return KeyIgnored, 0, nil
+	case '[':
+		return readCSI(r)
+	}
+	return KeyIgnored, 0, nil
+}
+
+// readCSI decodes a control sequence whose "ESC [" introducer has been read,
+// consuming parameters up to and including its final byte.
+func readCSI(r *bufio.Reader) (Key, rune, error) {
+	var params []rune
+	for {
+		c, _, err := r.ReadRune()
+		if err != nil {
+			return KeyIgnored, 0, err
+		}
+		if c >= 0x40 && c <= 0x7e {
+			return csiKey(string(params), c), 0, nil
+		}
+		params = append(params, c)
+	}
+}
+
+// csiKey returns the action of the control sequence with the given parameters
+// and final byte.
+func csiKey(params string, final rune) Key {
+	if final == '~' {
+		if k, ok := tildeKeys[params]; ok {
+			return k
+		}
+		return KeyIgnored
+	}
+	k, ok := csiKeys[final]
+	if !ok {
+		return KeyIgnored
+	}
+	// A modifier of Ctrl (5) or Alt (3) moves the cursor by words.
+	if params == "1;5" || params == "1;3" {
+		switch k {
+		case KeyLeft:
+			return KeyWordLeft
+		case KeyRight:
+			return KeyWordRight
+		}
+	}
+	return k
+}
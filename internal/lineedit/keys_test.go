@@ -0,0 +1,55 @@
+package lineedit_test
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/bitwizeshift/go-cli/internal/lineedit"
+)
+
+func TestReadKey(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		input    string
+		want     lineedit.Key
+		wantRune rune
+	}{
+		{name: "Rune", input: "é", want: lineedit.KeyRune, wantRune: 'é'},
+		{name: "Enter", input: "\r", want: lineedit.KeyEnter},
+		{name: "ControlKey", input: "\x17", want: lineedit.KeyKillWord},
+		{name: "UnboundControl", input: "\x07", want: lineedit.KeyIgnored},
+		{name: "ArrowCSI", input: "\x1b[A", want: lineedit.KeyUp},
+		{name: "ArrowSS3", input: "\x1bOB", want: lineedit.KeyDown},
+		{name: "CtrlArrow", input: "\x1b[1;5D", want: lineedit.KeyWordLeft},
+		{name: "PageUp", input: "\x1b[5~", want: lineedit.KeyPageUp},
+		{name: "PageDown", input: "\x1b[6~", want: lineedit.KeyPageDown},
+		{name: "UnknownTilde", input: "\x1b[99~", want: lineedit.KeyIgnored},
+		{name: "AltWord", input: "\x1bf", want: lineedit.KeyWordRight},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			r := bufio.NewReader(strings.NewReader(tc.input))
+
+			// Act
+			k, got, err := lineedit.ReadKey(r)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("ReadKey(...) error = %v, want nil", err)
+			}
+			if want := tc.want; k != want {
+				t.Errorf("ReadKey(...) key = %d, want %d", k, want)
+			}
+			if want := tc.wantRune; got != want {
+				t.Errorf("ReadKey(...) rune = %q, want %q", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,4 @@
+// Package session holds state that outlives a single command, for commands run
+// one after another within the same process, such as from an interactive
+// shell.
+package session
@@ -0,0 +1,88 @@
+package session
+
+import (
+	"errors"
+	"slices"
+	"sync"
+)
+
+// Session is a store of values shared by the commands run within it, with
+// cleanup that runs when the session ends. A command reaches it through its
+// context, and uses it to keep expensive resources -- a connection, a client,
+// an authenticated token -- alive for the commands that follow.
+//
+// A Session is safe for concurrent use.
+type Session struct {
+	mu      sync.Mutex
+	values  map[any]any
+	closers []func() error
+
+	// creating serialises the create functions of [Session.LoadOrStore], which
+	// run without mu held so they may use the session themselves.
+	creating sync.Mutex
+}
+
+// New returns an empty [Session].
+func New() *Session {
+	return &Session{values: map[any]any{}}
+}
+
+// Load returns the value stored under key, and reports whether one was found.
+func (s *Session) Load(key any) (any, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	value, ok := s.values[key]
+	return value, ok
+}
+
+// Store records value under key, replacing any value already stored there.
+func (s *Session) Store(key, value any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[key] = value
+}
+
+// LoadOrStore returns the value stored under key if there is one. Otherwise it
+// stores and returns the value returned by create, and reports whether the
+// value was loaded. An error from create is returned and nothing is stored.
+//
+// Only one create function runs at a time, so concurrent calls for the same
+// key create its value once. create may call the session's other methods, such
+// as [Session.OnClose] to release the value it creates, but must not call
+// LoadOrStore.
+func (s *Session) LoadOrStore(key any, create func() (any, error)) (value any, loaded bool, err error) {
+	s.creating.Lock()
+	defer s.creating.Unlock()
+	if value, ok := s.Load(key); ok {
+		return value, true, nil
+	}
+	value, err = create()
+	if err != nil {
+		return nil, false, err
+	}
+	s.Store(key, value)
+	return value, false, nil
+}
+
+// OnClose registers fn to run when the session is closed. Functions run in the
+// reverse of the order they were registered, as deferred calls do.
+func (s *Session) OnClose(fn func() error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closers = append(s.closers, fn)
+}
+
+// Close runs every function registered with [Session.OnClose] and discards the
+// stored values, returning the errors of the functions joined together.
+func (s *Session) Close() error {
+	s.mu.Lock()
+	closers := s.closers
+	s.closers, s.values = nil, map[any]any{}
+	s.mu.Unlock()
+
+	var errs []error
+	for _, fn := range slices.Backward(closers) {
+		errs = append(errs, fn())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,150 @@
+package session_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/bitwizeshift/go-cli/internal/session"
+)
+
+// connKey is the key the tests store a value under.
+type connKey struct{}
+
+func TestSession_StoreLoad(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	sut := session.New()
+
+	// Act
+	_, foundBefore := sut.Load(connKey{})
+	sut.Store(connKey{}, "conn")
+	got, found := sut.Load(connKey{})
+
+	// Assert
+	if foundBefore {
+		t.Errorf("Load(...) before Store reported found, want not found")
+	}
+	if !found || got != "conn" {
+		t.Errorf("Load(...) = %v, %t, want %v, %t", got, found, "conn", true)
+	}
+}
+
+func TestSession_LoadOrStore(t *testing.T) {
+	t.Parallel()
+
+	errDial := errors.New("dial failed")
+
+	testCases := []struct {
+		name       string
+		stored     any
+		create     func() (any, error)
+		want       any
+		wantLoaded bool
+		wantErr    error
+	}{
+		{
+			name:       "LoadsExisting",
+			stored:     "existing",
+			create:     func() (any, error) { return "created", nil },
+			want:       "existing",
+			wantLoaded: true,
+		}, {
+			name:   "CreatesMissing",
+			create: func() (any, error) { return "created", nil },
+			want:   "created",
+		}, {
+			name:    "CreateError",
+			create:  func() (any, error) { return nil, errDial },
+			wantErr: errDial,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			sut := session.New()
+			if tc.stored != nil {
+				sut.Store(connKey{}, tc.stored)
+			}
+
+			// Act
+			got, loaded, err := sut.LoadOrStore(connKey{}, tc.create)
+
+			// Assert
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("LoadOrStore(...) error = %v, want %v", got, want)
+			}
+			if want := tc.want; got != want {
+				t.Errorf("LoadOrStore(...) = %v, want %v", got, want)
+			}
+			if got, want := loaded, tc.wantLoaded; got != want {
+				t.Errorf("LoadOrStore(...) loaded = %t, want %t", got, want)
+			}
+		})
+	}
+}
+
+func TestSession_LoadOrStore_CreateRegistersCloser(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	sut := session.New()
+	closed := false
+
+	// Act
+	_, _, err := sut.LoadOrStore(connKey{}, func() (any, error) {
+		sut.OnClose(func() error {
+			closed = true
+			return nil
+		})
+		return "conn", nil
+	})
+	closeErr := sut.Close()
+
+	// Assert
+	if err := errors.Join(err, closeErr); err != nil {
+		t.Fatalf("LoadOrStore(...) then Close() = %v, want nil", err)
+	}
+	if got, want := closed, true; got != want {
+		t.Errorf("closer registered by create ran = %t, want %t", got, want)
+	}
+}
+
+func TestSession_Close(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	errClose := errors.New("close failed")
+	sut := session.New()
+	sut.Store(connKey{}, "conn")
+	var order []string
+	sut.OnClose(func() error {
+		order = append(order, "first")
+		return errClose
+	})
+	sut.OnClose(func() error {
+		order = append(order, "second")
+		return nil
+	})
+
+	// Act
+	err := sut.Close()
+	_, found := sut.Load(connKey{})
+
+	// Assert
+	if got, want := err, errClose; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Errorf("Close() = %v, want %v", got, want)
+	}
+	if got, want := order, []string{"second", "first"}; !cmp.Equal(got, want) {
+		t.Errorf("Close() ran closers in order %v, want %v", got, want)
+	}
+	if found {
+		t.Errorf("Load(...) after Close reported found, want not found")
+	}
+}
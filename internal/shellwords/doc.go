@@ -0,0 +1,4 @@
+// Package shellwords splits a command line into words the way a POSIX shell
+// would, for lines typed into the interactive shell and editor commands named
+// by the environment.
+package shellwords
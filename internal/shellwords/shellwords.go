@@ -0,0 +1,62 @@
+package shellwords
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrUnterminatedQuote is returned by [Split] when a line ends inside a quoted
+// string, or after an escaping backslash.
+var ErrUnterminatedQuote = errors.New("unterminated quoted string")
+
+// Split splits line into words at unquoted whitespace, the way a POSIX shell
+// would. Single quotes preserve their contents literally, and within double
+// quotes or unquoted text a backslash escapes the character after it.
+func Split(line string) ([]string, error) {
+	var (
+		words  []string
+		word   strings.Builder
+		inWord bool
+		quote  rune
+		escape bool
+	)
+	for _, r := range line {
+		switch {
+		case escape:
+			word.WriteRune(r)
+			escape = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\\':
+			escape, inWord = true, true
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inWord = r, true
+		case r == ' ' || r == '\t':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 || escape {
+		return nil, ErrUnterminatedQuote
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
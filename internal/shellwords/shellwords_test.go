@@ -0,0 +1,71 @@
+package shellwords_test
+
+import (
+	"testing"
+
+	"github.com/bitwizeshift/go-cli/internal/shellwords"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestSplit(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		line    string
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "Blank",
+			line: " \t ",
+			want: nil,
+		}, {
+			name: "SplitsAtWhitespace",
+			line: "greet  --loud\talice",
+			want: []string{"greet", "--loud", "alice"},
+		}, {
+			name: "SingleQuotesAreLiteral",
+			line: `'a \"b' c`,
+			want: []string{`a \"b`, "c"},
+		}, {
+			name: "DoubleQuotesHonourEscapes",
+			line: `"/Applications/Sublime Text.app/subl" -w "say \"hi\""`,
+			want: []string{"/Applications/Sublime Text.app/subl", "-w", `say "hi"`},
+		}, {
+			name: "EscapedSpaceJoinsWords",
+			line: `my\ editor -n`,
+			want: []string{"my editor", "-n"},
+		}, {
+			name: "EmptyQuotesAreAWord",
+			line: `'' x`,
+			want: []string{"", "x"},
+		}, {
+			name:    "UnterminatedQuote",
+			line:    `"open`,
+			wantErr: shellwords.ErrUnterminatedQuote,
+		}, {
+			name:    "TrailingBackslash",
+			line:    `open\`,
+			wantErr: shellwords.ErrUnterminatedQuote,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			words, err := shellwords.Split(tc.line)
+
+			// Assert
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("Split(%q) error = %v, want %v", tc.line, got, want)
+			}
+			if got, want := words, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Split(%q) = %q, want %q", tc.line, got, want)
+			}
+		})
+	}
+}
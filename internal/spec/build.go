@@ -11,10 +11,10 @@ import (
 
 	"github.com/bitwizeshift/go-cli/arg"
 	"github.com/bitwizeshift/go-cli/internal/argdef"
-	"github.com/bitwizeshift/go-cli/internal/arity"
 	"github.com/bitwizeshift/go-cli/internal/completion"
 	"github.com/bitwizeshift/go-cli/internal/storage"
 	"github.com/bitwizeshift/go-cli/internal/template"
+	"github.com/bitwizeshift/go-cli/internal/term"
 	"github.com/bitwizeshift/go-cli/richtext"
 	"github.com/spf13/cobra"
 	"go.yaml.in/yaml/v4"
@@ -53,12 +53,17 @@ type Options struct {
 	Builders map[string]Builder
 
 	// Theme resolves the styling tags emitted by the output templates. A nil
-	// Theme uses [richtext.DefaultTheme].
+	// Theme uses [richtext.DefaultTheme]. Its styles are overridden by the
+	// specification's theme section, and then by the user's [ThemeFile].
 	Theme *richtext.Theme
 
 	// Colour selects the colour policy applied to the wrapped output streams.
 	Colour ColourMode
 
+	// Background selects the background an adaptive Theme is resolved against.
+	// [richtext.UnknownBackground] detects it from the destination terminal.
+	Background richtext.Background
+
 	// Version is the running build's version, reported by the root command.
 	Version string
 
@@ -70,6 +75,28 @@ type Options struct {
 	// Update configures update-availability checking. Checking is enabled only
 	// when it carries a version, a source, and at least one provider.
 	Update UpdateOptions
+
+	// Shell adds a "shell" command to the root, which reads commands of the
+	// tree interactively. See [ShellCommand].
+	Shell bool
+
+	// Stdin is the stream the shell reads commands from. A nil value uses
+	// [os.Stdin].
+	Stdin io.Reader
+
+	// Console places the terminal into raw mode while the shell reads a line. A
+	// nil value uses [term.DefaultConsole].
+	Console term.EchoDisabler
+
+	// Prompt asks for every required argument left without a value, as though
+	// each had been registered with
+	// [github.com/bitwizeshift/go-cli/arg.PromptIfMissing].
+	Prompt bool
+
+	// Interactive reports whether the user may be prompted, unless --no-input
+	// was given. A nil value reports whether Stdin and Stderr, where prompts
+	// are written, are both terminals, outside of continuous integration.
+	Interactive func() bool
 }
 
 // Build decodes an [Application] specification from r and constructs the
@@ -79,38 +106,77 @@ type Options struct {
 // be flushed by [Execute] once the tree has run.
 //
 // It returns [ErrUnboundRunner] if a runner is bound to an id with no matching
-// command, or a decoding error if r does not hold a valid specification.
+// command, or a decoding error if r does not hold a valid specification. A
+// user's [ThemeFile] that cannot be parsed is not an error: it is reported as a
+// warning on the error stream and ignored.
 func Build(r io.Reader, opts Options) (*cobra.Command, error) {
 	var app Application
 	if err := yaml.NewDecoder(r).Decode(&app); err != nil {
 		return nil, err
 	}
-
-	unbound := make(map[string]Builder, len(opts.Builders))
-	maps.Copy(unbound, opts.Builders)
+	theme, err := app.resolveTheme(opts.Theme)
+	if err != nil {
+		return nil, err
+	}
 	store := storage.NewAppStorage(app.resolveAppID(runtime.GOOS))
-	cmd, cl := app.toCobraCommand(app.Name, unbound, store)
+	theme, themeErr := userTheme(store.Config, theme)
+	opts.Theme = theme
+
+	t := &tree{
+		builders: make(map[string]Builder, len(opts.Builders)),
+		store:    store,
+		prompt:   opts.Prompt,
+		prompter: newPrompter(opts),
+	}
+	maps.Copy(t.builders, opts.Builders)
+	cmd, cl := app.toCobraCommand(app.Name, t)
 	cmd.Version = opts.Version
-	if len(unbound) > 0 {
-		return nil, fmt.Errorf("%w: %s", ErrUnboundRunner, strings.Join(sortedKeys(unbound), ", "))
+	if len(t.builders) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnboundRunner, strings.Join(sortedKeys(t.builders), ", "))
 	}
 	argdef.AddIssueURL(cmd, app.IssueURL)
-	checker, err := opts.Update.checker(&app, store.Cache)
+	installDebugArgs(cmd)
+	t.prompter.installInteraction(cmd)
+	checker, err := opts.Update.checker(&app, t.store.Cache)
 	if err != nil {
 		return nil, err
 	}
 	if checker != nil {
 		installUpdateHelp(cmd, checker, cl)
 	}
-	setStreams(cmd,
-		opts.newWriter(opts.Stdout, os.Stdout),
-		opts.newWriter(opts.Stderr, os.Stderr),
-	)
+	if opts.Shell {
+		installShell(cmd, t, opts)
+		app.setUsage(cmd, cl)
+	}
+	stdout, stderr := opts.newWriter(opts.Stdout, os.Stdout), opts.newWriter(opts.Stderr, os.Stderr)
+	if themeErr != nil {
+		warnTheme(stderr, themeErr)
+	}
+	installRenderHTML(cmd, stdout, stderr)
+	setStreams(cmd, stdout, stderr)
 	return cmd, nil
 }
 
+// tree carries the state shared by every command of a tree as it is built.
+type tree struct {
+	// builders holds the builders not yet bound to a command, keyed by id path.
+	builders map[string]Builder
+
+	// store holds the application's storage roots, shared by every command.
+	store *storage.AppStorage
+
+	// lines holds the argument command line of every command with a bound
+	// builder, in the order the commands were built.
+	lines []*arg.CommandLine
+
+	// prompt marks every required argument to be prompted for when missing,
+	// and prompter asks for them.
+	prompt   bool
+	prompter *prompter
+}
+
 // newWriter wraps base (or fallback when base is nil) in a [richtext.Writer]
-// configured for the options' theme and colour policy.
+// configured for the options' theme, colour policy and background.
 func (o Options) newWriter(base, fallback io.Writer) *richtext.Writer {
 	if base == nil {
 		base = fallback
@@ -126,6 +192,9 @@ func (o Options) newWriter(base, fallback io.Writer) *richtext.Writer {
 	case ColourEnabled:
 		w.ForceColour()
 	}
+	if o.Background != richtext.UnknownBackground {
+		w.SetBackground(o.Background)
+	}
 	return w
 }
 
@@ -149,12 +218,12 @@ func sortedKeys(builders map[string]Builder) []string {
 }
 
 // toCobraCommand converts the command info into a [github.com/spf13/cobra.Command],
-// removing each bound runner from runners as it is consumed. path is the
-// dot-delimited id path identifying this command, and is the key a runner is
-// bound under. store is shared by every command so a bound runner can reach the
-// application's storage roots. It returns the command alongside its argument cl,
-// which is nil when no runner is bound.
-func (i *CommandInfo) toCobraCommand(path string, builders map[string]Builder, store *storage.AppStorage) (*cobra.Command, *arg.CommandLine) {
+// removing each bound builder from t as it is consumed. path is the
+// dot-delimited id path identifying this command, and is the key a builder is
+// bound under. t's storage is shared by every command so a bound runner can
+// reach the application's storage roots. It returns the command alongside its
+// argument cl, which is nil when no builder is bound.
+func (i *CommandInfo) toCobraCommand(path string, t *tree) (*cobra.Command, *arg.CommandLine) {
 	cmd := &cobra.Command{
 		Short:         i.Summary,
 		Long:          i.Description,
@@ -172,21 +241,25 @@ func (i *CommandInfo) toCobraCommand(path string, builders map[string]Builder, s
 		SuggestionsMinimumDistance: 1,
 	}
 	var cl *arg.CommandLine
-	if builder := builders[path]; builder != nil {
-		delete(builders, path)
+	if builder := t.builders[path]; builder != nil {
+		delete(t.builders, path)
 		cl = (*arg.CommandLine)(argdef.FromFlagSet(cmd.Flags()))
+		t.lines = append(t.lines, cl)
+		argdef.SetInterspersed((*argdef.CommandLine)(cl), i.interspersed())
 		arg.Register(cl, builder)
+		if t.prompt {
+			argdef.PromptRequired((*argdef.CommandLine)(cl))
+		}
 		argdef.VerifyPositionals((*argdef.CommandLine)(cl))
-		cmd.Args = positionalArgs(argdef.Arity((*argdef.CommandLine)(cl)))
+		cmd.Args = positionalArgs((*argdef.CommandLine)(cl))
 		argdef.ConfigureFlags(cmd)
-		completion.RegisterFlags(cmd)
-		cmd.ValidArgsFunction = completion.ForArgs(
-			argdef.PositionalCompletions((*argdef.CommandLine)(cl)),
-			argdef.UnmatchedCompletion((*argdef.CommandLine)(cl)),
-		)
-		cmd.RunE = i.run(builder, store, cl)
+		prepare := i.prepareCompletion(t.store, cl)
+		completion.RegisterFlags(cmd, prepare)
+		cmd.ValidArgsFunction = completion.Prepared(argCompletion((*argdef.CommandLine)(cl)), prepare)
+		cmd.RunE = i.run(builder, t, cl)
 	} else {
-		cmd.Args = positionalArgs(argdef.Arity(argdef.New()))
+		cmd.Flags().SetInterspersed(i.interspersed())
+		cmd.Args = positionalArgs(argdef.New())
 		cmd.RunE = i.showHelp
 	}
 	cmd.SetHelpFunc(template.DefaultRenderEngine.HelpFunc(cl))
@@ -194,16 +267,26 @@ func (i *CommandInfo) toCobraCommand(path string, builders map[string]Builder, s
 	cmd.SetVersionTemplate(template.DefaultRenderEngine.VersionTemplate())
 
 	for _, group := range i.Commands {
-		i.addGroup(cmd, path, group, builders, store)
+		i.addGroup(cmd, path, group, t)
 	}
-	cmd.Use = i.usage(cmd, cl)
+	i.setUsage(cmd, cl)
 	return cmd, cl
 }
 
-// usage returns the synopsis cobra displays for cmd: the command's name
-// followed by the arguments registered on cl. A command with subcommands names
-// one as an operand, since a subcommand must be chosen to reach a runner.
-func (i *CommandInfo) usage(cmd *cobra.Command, cl *arg.CommandLine) string {
+// setUsage assigns the synopsis cobra displays for cmd: the command's name
+// followed by the docopt-style synopsis of the arguments registered on cl. The
+// compact form, which folds optional flags into "[flags]", is recorded as the
+// fallback for a terminal too narrow to show the full one. A command with
+// subcommands names one as an operand, since a subcommand must be chosen to
+// reach a runner.
+//
+// A hand-written [CommandInfo.Usage] replaces the synopsis outright, and has no
+// fallback.
+func (i *CommandInfo) setUsage(cmd *cobra.Command, cl *arg.CommandLine) {
+	if i.Usage != "" {
+		cmd.Use = i.Name + " " + i.Usage
+		return
+	}
 	if cl == nil {
 		cl = (*arg.CommandLine)(argdef.FromFlagSet(cmd.Flags()))
 	}
@@ -211,19 +294,41 @@ func (i *CommandInfo) usage(cmd *cobra.Command, cl *arg.CommandLine) string {
 	if cmd.HasAvailableSubCommands() {
 		operands = append(operands, commandOperand)
 	}
-	usage := argdef.Usage((*argdef.CommandLine)(cl), operands...)
-	if usage == "" {
-		return i.Name
+	full := argdef.Synopsis((*argdef.CommandLine)(cl), operands...)
+	compact := argdef.Usage((*argdef.CommandLine)(cl), operands...)
+	cmd.Use = strings.TrimSpace(i.Name + " " + full)
+	if compact != full {
+		argdef.SetCompactUsage(cmd, strings.TrimSpace(i.Name+" "+compact))
 	}
-	return i.Name + " " + usage
 }
 
-// positionalArgs adapts a permitted argument count into cobra's
-// positional-argument validator.
-func positionalArgs(a arity.Arity) cobra.PositionalArgs {
+// interspersed reports whether the command accepts flags after its first
+// positional argument, which it does unless the specification says otherwise.
+func (i *CommandInfo) interspersed() bool {
+	return i.Interspersed == nil || *i.Interspersed
+}
+
+// positionalArgs adapts the argument count permitted by cl into cobra's
+// positional-argument validator. Arguments claimed by a passthrough binding are
+// not counted.
+func positionalArgs(cl *argdef.CommandLine) cobra.PositionalArgs {
+	a := argdef.Arity(cl)
 	return func(_ *cobra.Command, args []string) error {
-		return a.Validate(len(args))
+		operands, _ := argdef.Split(cl, args)
+		return a.Validate(len(operands))
+	}
+}
+
+// argCompletion builds the completion function for the arguments registered on
+// cl. Arguments claimed by a passthrough binding defer to file completion.
+func argCompletion(cl *argdef.CommandLine) cobra.CompletionFunc {
+	complete := completion.ForArgs(argdef.PositionalCompletions(cl), argdef.UnmatchedCompletion(cl))
+	if argdef.GetPassthrough(cl) == nil {
+		return complete
 	}
+	return completion.ForPassthrough(complete, func(args []string) bool {
+		return argdef.InPassthrough(cl, args)
+	})
 }
 
 // showHelp is the default action for a command with no bound runner. Printing
@@ -236,7 +341,7 @@ func (*CommandInfo) showHelp(cmd *cobra.Command, _ []string) error {
 // addGroup adds the commands of group to cmd, each identified by its name
 // appended to path. A group named [DefaultGroup] is left ungrouped; any other
 // group is registered as a titled cobra group.
-func (i *CommandInfo) addGroup(cmd *cobra.Command, path string, group GroupCommandInfo, builders map[string]Builder, store *storage.AppStorage) {
+func (i *CommandInfo) addGroup(cmd *cobra.Command, path string, group GroupCommandInfo, t *tree) {
 	groupID := ""
 	if group.Name != DefaultGroup {
 		groupID = strings.ReplaceAll(group.Name, " ", "-")
@@ -246,7 +351,7 @@ func (i *CommandInfo) addGroup(cmd *cobra.Command, path string, group GroupComma
 		})
 	}
 	for _, c := range group.Commands {
-		command, _ := c.toCobraCommand(path+idSeparator+c.Name, builders, store)
+		command, _ := c.toCobraCommand(path+idSeparator+c.Name, t)
 		command.GroupID = groupID
 		cmd.AddCommand(command)
 	}
@@ -3,6 +3,8 @@ package spec_test
 import (
 	"bytes"
 	"context"
+	"errors"
+	"io"
 	"strings"
 	"testing"
 
@@ -10,6 +12,7 @@ import (
 	"github.com/bitwizeshift/go-cli/arg/argtest"
 	"github.com/bitwizeshift/go-cli/internal/argdef"
 	"github.com/bitwizeshift/go-cli/internal/arity"
+	"github.com/bitwizeshift/go-cli/internal/clictx"
 	"github.com/bitwizeshift/go-cli/internal/spec"
 	"github.com/bitwizeshift/go-cli/internal/spec/spectest"
 	"github.com/google/go-cmp/cmp"
@@ -129,6 +132,31 @@ var (
 	_ arg.Registrar = (*gappedRunner)(nil)
 )
 
+// execRunner is a [spec.Runner] handing the arguments after its target to a
+// wrapped command, in the manner of "exec <target> -- <cmd> [args...]".
+type execRunner struct {
+	verbose bool
+	target  string
+	command []string
+}
+
+func (er *execRunner) RegisterArgs(cl *arg.CommandLine) {
+	cl.Add(
+		arg.Flag("verbose", &er.verbose),
+		arg.Positional("target", 0, &er.target, arg.Required(), arg.CompleteFrom("prod", "dev")),
+		arg.Passthrough("cmd", &er.command, arg.Required()),
+	)
+}
+
+func (er *execRunner) Run(context.Context) error {
+	return nil
+}
+
+var (
+	_ spec.Runner   = (*execRunner)(nil)
+	_ arg.Registrar = (*execRunner)(nil)
+)
+
 // offered is what a command offers for a word being completed: the candidates it
 // returns, and the cobra directive telling a shell how to treat them.
 type offered struct {
@@ -214,10 +242,16 @@ func TestBuild(t *testing.T) {
 			want:    "root <src> [dst]",
 		},
 		{
-			name:    "ReportsOptionalFlagsAsPlaceholder",
+			name:    "SpellsOutOptionalFlags",
 			input:   "name: root\n",
 			runners: map[string]spec.Runner{"root": &flaggedRunner{}},
-			want:    "root [flags]",
+			want:    "root [--format <string>] [--verbose]",
+		},
+		{
+			name:    "UsageOverrideReplacesSynopsis",
+			input:   "name: root\nusage: \"<src>... <dst>\"\n",
+			runners: map[string]spec.Runner{"root": &requiredPositionalRunner{}},
+			want:    "root <src>... <dst>",
 		},
 		{
 			name:    "BindsRunnerByIDPath",
@@ -267,6 +301,53 @@ func commandUse(cmd *cobra.Command) string {
 	return cmd.Use
 }
 
+func TestBuild_CompactUsage(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		input  string
+		runner spec.Runner
+		want   string
+	}{
+		{
+			name:   "OptionalFlagsFoldIntoPlaceholder",
+			input:  "name: root\n",
+			runner: &flaggedRunner{},
+			want:   "root [flags]",
+		}, {
+			name:   "NoFallbackWhenSynopsesAgree",
+			input:  "name: root\n",
+			runner: &requiredPositionalRunner{},
+			want:   "",
+		}, {
+			name:   "NoFallbackForUsageOverride",
+			input:  "name: root\nusage: \"[options]\"\n",
+			runner: &flaggedRunner{},
+			want:   "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			sut := build(t, tc.input, spec.Options{
+				Builders: toBuilders(map[string]spec.Runner{"root": tc.runner}),
+			})
+
+			// Act
+			compact := argdef.CompactUsage(sut)
+
+			// Assert
+			if got, want := compact, tc.want; got != want {
+				t.Errorf("CompactUsage(...) = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
 func TestBuild_Colour(t *testing.T) {
 	t.Parallel()
 
@@ -788,3 +869,203 @@ func groupTitles(cmd *cobra.Command) []string {
 	}
 	return titles
 }
+
+func TestBuild_Passthrough(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		input      string
+		args       []string
+		wantUse    string
+		wantRunner execRunner
+	}{
+		{
+			name:    "ArgumentsAfterDash",
+			input:   "name: root\n",
+			args:    []string{"prod", "--verbose", "--", "kubectl", "get", "-o", "yaml"},
+			wantUse: "root <target> [--verbose] -- <cmd> [args...]",
+			wantRunner: execRunner{
+				verbose: true,
+				target:  "prod",
+				command: []string{"kubectl", "get", "-o", "yaml"},
+			},
+		}, {
+			name:    "NotInterspersed",
+			input:   "name: root\ninterspersed: false\n",
+			args:    []string{"--verbose", "prod", "kubectl", "--verbose"},
+			wantUse: "root <target> [--verbose] [--] <cmd> [args...]",
+			wantRunner: execRunner{
+				verbose: true,
+				target:  "prod",
+				command: []string{"kubectl", "--verbose"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			runner := &execRunner{}
+			sut := build(t, tc.input, spec.Options{
+				Builders: toBuilders(map[string]spec.Runner{"root": runner}),
+			})
+			sut.SetArgs(tc.args)
+
+			// Act
+			err := sut.ExecuteContext(context.Background())
+
+			// Assert
+			if got, want := err, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("ExecuteContext(...) = %v, want %v", got, want)
+			}
+			if got, want := sut.Use, tc.wantUse; got != want {
+				t.Errorf("Use = %q, want %q", got, want)
+			}
+			opts := cmp.AllowUnexported(execRunner{})
+			if got, want := *runner, tc.wantRunner; !cmp.Equal(got, want, opts) {
+				t.Errorf("runner mismatch (-want +got):\n%s", cmp.Diff(want, got, opts))
+			}
+		})
+	}
+}
+
+func TestBuild_Passthrough_MissingIsUsageError(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	sut := buildRoot(t, &execRunner{})
+	sut.SetArgs([]string{"prod"})
+
+	// Act
+	err := sut.ExecuteContext(context.Background())
+
+	// Assert
+	if got, want := err, argdef.ErrMissingPassthrough; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Errorf("ExecuteContext(...) = %v, want %v", got, want)
+	}
+}
+
+func TestBuild_Passthrough_Completion(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		input string
+		args  []string
+		want  offered
+	}{
+		{
+			name:  "TargetOffersCandidates",
+			input: "name: root\n",
+			args:  nil,
+			want: offered{
+				Candidates: []string{"prod", "dev"},
+				Directive:  cobra.ShellCompDirectiveNoFileComp,
+			},
+		}, {
+			name:  "AfterDashDefersToFiles",
+			input: "name: root\n",
+			args:  []string{"prod", "--"},
+			want: offered{
+				Candidates: nil,
+				Directive:  cobra.ShellCompDirectiveDefault,
+			},
+		}, {
+			name:  "NotInterspersedDefersToFilesBeyondTarget",
+			input: "name: root\ninterspersed: false\n",
+			args:  []string{"prod"},
+			want: offered{
+				Candidates: nil,
+				Directive:  cobra.ShellCompDirectiveDefault,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			sut := build(t, tc.input, spec.Options{
+				Builders: toBuilders(map[string]spec.Runner{"root": &execRunner{}}),
+			})
+			if err := sut.ParseFlags(tc.args); err != nil {
+				t.Fatalf("ParseFlags(...) = %v, want nil", err)
+			}
+
+			// Act
+			candidates, directive := sut.ValidArgsFunction(sut, sut.Flags().Args(), "")
+
+			// Assert
+			offer := offered{
+				Candidates: candidates,
+				Directive:  directive,
+			}
+			if got, want := offer, tc.want; !cmp.Equal(got, want, cmpopts.EquateEmpty()) {
+				t.Errorf("ValidArgsFunction(...) = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+// clusterBuilder is a [spec.Builder] whose pod argument completes from the
+// cluster named by its flag, recording whether it was ever asked to build.
+type clusterBuilder struct {
+	cluster string
+	pod     string
+	built   bool
+}
+
+func (cb *clusterBuilder) RegisterArgs(cl *arg.CommandLine) {
+	cl.Add(
+		arg.Flag("cluster", &cb.cluster, arg.DefaultFromEnv("CLUSTER_BUILDER_CLUSTER")),
+		arg.Positional("pod", 0, &cb.pod, arg.CompleteWith(func(ctx context.Context, c arg.CompletionContext) ([]arg.Candidate, error) {
+			if clictx.Storage(ctx) == nil {
+				return nil, errors.New("no storage on context")
+			}
+			return []arg.Candidate{{Value: cb.cluster + "-web", Description: c.CommandPath}}, nil
+		})),
+	)
+}
+
+func (cb *clusterBuilder) Build(context.Context) (spec.Runner, error) {
+	cb.built = true
+	return spectest.NoOpRunner(), nil
+}
+
+var (
+	_ spec.Builder  = (*clusterBuilder)(nil)
+	_ arg.Registrar = (*clusterBuilder)(nil)
+)
+
+func TestBuild_CompleteWith_ResolvesFallbacksWithoutBuilding(t *testing.T) {
+	t.Setenv("CLUSTER_BUILDER_CLUSTER", "staging")
+
+	// Arrange
+	builder := &clusterBuilder{}
+	var stdout bytes.Buffer
+	sut := build(t, "name: root\n", spec.Options{
+		Builders: map[string]spec.Builder{"root": builder},
+	})
+	sut.SetOut(&stdout)
+	sut.SetErr(io.Discard)
+	sut.SetArgs([]string{cobra.ShellCompRequestCmd, ""})
+
+	// Act
+	err := sut.ExecuteContext(context.Background())
+
+	// Assert
+	if got, want := err, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Fatalf("ExecuteContext(...) = %v, want %v", got, want)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if got, want := lines[0], "staging-web\troot"; got != want {
+		t.Errorf("completion candidate = %q, want %q", got, want)
+	}
+	if got, want := builder.built, false; got != want {
+		t.Errorf("Builder built = %t, want %t", got, want)
+	}
+}
@@ -0,0 +1,140 @@
+package spec
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/bitwizeshift/go-cli/internal/argdef"
+	"github.com/bitwizeshift/go-cli/internal/template/tag"
+	"github.com/bitwizeshift/go-cli/richtext"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// debugArgsFlag names the hidden global flag that reports how each argument of
+// the running command was resolved.
+const debugArgsFlag = "debug-args"
+
+// renderHTMLFlag names the hidden global flag that renders the command's
+// output as HTML rather than for the terminal.
+const renderHTMLFlag = "render-html"
+
+// secretMask stands in for the value of a secret argument in the argument
+// resolution table.
+const secretMask = "********"
+
+// frameworkFlags names the flags the framework itself registers, which are
+// omitted from the argument resolution table. Only help and version are
+// registered by cobra on each command; the others are inherited from the root.
+var frameworkFlags = map[string]struct{}{
+	"help":         {},
+	"version":      {},
+	debugArgsFlag:  {},
+	renderHTMLFlag: {},
+	noInputFlag:    {},
+	yesFlag:        {},
+}
+
+// installDebugArgs registers the hidden --debug-args flag on cmd, inherited by
+// every command beneath it.
+func installDebugArgs(cmd *cobra.Command) {
+	cmd.PersistentFlags().Bool(debugArgsFlag, false, "print how each argument was resolved")
+	// The flag was registered on this same set above, so it cannot be missing.
+	_ = cmd.PersistentFlags().MarkHidden(debugArgsFlag)
+}
+
+// debugArgsRequested reports whether --debug-args was given to cmd.
+func debugArgsRequested(cmd *cobra.Command) bool {
+	requested, err := cmd.Flags().GetBool(debugArgsFlag)
+	return err == nil && requested
+}
+
+// installRenderHTML registers the hidden --render-html flag on cmd, inherited
+// by every command beneath it. Giving it switches each of writers to render
+// HTML as soon as the flag is parsed, so that everything the command writes,
+// its help included, can be captured for documentation or a bug report.
+// Resetting the flag, as the shell does between lines, switches them back.
+func installRenderHTML(cmd *cobra.Command, writers ...*richtext.Writer) {
+	flag := cmd.PersistentFlags().VarPF(&renderHTML{writers: writers}, renderHTMLFlag, "", "render output as HTML")
+	flag.NoOptDefVal = "true"
+	flag.Hidden = true
+}
+
+// renderHTML is the value of the --render-html flag, which switches its writers
+// to HTML when set, and back to the formats they had before when unset.
+type renderHTML struct {
+	set     bool
+	writers []*richtext.Writer
+	formats []richtext.Format
+}
+
+// String implements [github.com/spf13/pflag.Value].
+func (r *renderHTML) String() string {
+	return strconv.FormatBool(r.set)
+}
+
+// Set implements [github.com/spf13/pflag.Value].
+func (r *renderHTML) Set(s string) error {
+	set, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	switch {
+	case set && !r.set:
+		r.formats = make([]richtext.Format, len(r.writers))
+		for i, w := range r.writers {
+			r.formats[i] = w.Format()
+			w.SetFormat(richtext.HTML)
+		}
+	case !set && r.set:
+		for i, w := range r.writers {
+			w.SetFormat(r.formats[i])
+		}
+	}
+	r.set = set
+	return nil
+}
+
+// Type implements [github.com/spf13/pflag.Value].
+func (r *renderHTML) Type() string {
+	return "bool"
+}
+
+// renderResolution writes a table to w listing every argument registered on
+// cl, the command line of cmd, alongside its resolved value and where that
+// value came from. The value of a secret argument is masked.
+func renderResolution(w io.Writer, cmd *cobra.Command, cl *argdef.CommandLine) {
+	var sb strings.Builder
+	tw := tabwriter.NewWriter(&sb, 0, 0, 3, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ARGUMENT\tVALUE\tSOURCE")
+	for _, ref := range argdef.Refs(cl) {
+		if ref.Flag != nil && frameworkFlag(cmd, ref.Flag) {
+			continue
+		}
+		value, _ := ref.Resolved()
+		if ref.Secret() && value != "" {
+			value = secretMask
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\n", ref.Synopsis(), value, ref.Source())
+	}
+	_ = tw.Flush()
+	_, _ = io.WriteString(w, tag.Raw(sb.String()))
+}
+
+// frameworkFlag reports whether f, a flag of cmd, was registered by the
+// framework rather than by the application. A flag named in frameworkFlags
+// that the application defines itself, leaving the framework's uninstalled, is
+// the application's.
+func frameworkFlag(cmd *cobra.Command, f *pflag.Flag) bool {
+	if _, ok := frameworkFlags[f.Name]; !ok {
+		return false
+	}
+	switch f.Name {
+	case "help", "version":
+		return true
+	}
+	return cmd.Root().PersistentFlags().Lookup(f.Name) == f
+}
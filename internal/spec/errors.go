@@ -29,6 +29,10 @@ var (
 	// ErrUnknownHostOS indicates an app-id mapping was keyed by a host operating
 	// system that is not recognized.
 	ErrUnknownHostOS = errors.New("unknown host os")
+
+	// ErrNestedShell indicates the shell command was entered from within a
+	// running shell.
+	ErrNestedShell = errors.New("shell is already running")
 )
 
 // PanicError is the error produced when a [Runner] terminates by panicking. It
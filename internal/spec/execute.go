@@ -8,10 +8,12 @@ import (
 	"os"
 	"os/signal"
 	"runtime/debug"
+	"strings"
 
 	"github.com/bitwizeshift/go-cli/arg"
 	"github.com/bitwizeshift/go-cli/internal/argdef"
 	"github.com/bitwizeshift/go-cli/internal/clictx"
+	"github.com/bitwizeshift/go-cli/internal/completion"
 	"github.com/bitwizeshift/go-cli/internal/storage"
 	"github.com/bitwizeshift/go-cli/internal/template"
 	"github.com/bitwizeshift/go-cli/internal/template/panichandler"
@@ -26,20 +28,40 @@ import (
 // an explicit usage error, or the runner's error otherwise. The returned error
 // has already been reported to the user and is intended only for exit-status
 // classification.
+//
+// Work that a completion defers, such as recomputing stale cached candidates,
+// runs once the streams are flushed. A shell reads the completion's output
+// until it ends, which it does only when the process exits, so under a context
+// returned by [WithDetachedRevalidation] the work is instead handed to a
+// detached copy of the process; see [WithDetachedRevalidation].
 func Execute(ctx context.Context, cmd *cobra.Command) error {
+	ctx, runDeferred := completion.WithDeferred(ctx)
 	stdout := cmd.OutOrStdout()
 	stderr := cmd.ErrOrStderr()
+	defer revalidate(ctx, runDeferred)
 	defer closeStream(stdout)
 	defer closeStream(stderr)
 
 	target, err := cmd.ExecuteContextC(ctx)
+	report(target, err)
+	return err
+}
+
+// report renders err, as returned by executing target, to target's error
+// stream, followed by its usage when the error was caused by how the command
+// was invoked. A nil err is not reported.
+func report(target *cobra.Command, err error) {
 	if err == nil {
-		return nil
+		return
 	}
+	stderr := target.ErrOrStderr()
 	switch {
 	case errors.Is(err, ErrPanic):
 		// The panic report was already rendered while unwinding the runner.
 	case errors.Is(err, ErrUsage):
+		if hasDetail(err) {
+			renderError(stderr, err)
+		}
 		_ = target.Usage()
 	case fromRunner(err):
 		renderError(stderr, err)
@@ -47,7 +69,6 @@ func Execute(ctx context.Context, cmd *cobra.Command) error {
 		renderError(stderr, err)
 		_ = target.Usage()
 	}
-	return err
 }
 
 // renderError writes a styled, newline-terminated error message to w.
@@ -63,6 +84,16 @@ func closeStream(w io.Writer) {
 	}
 }
 
+// hasDetail reports whether the usage error err explains what was wrong, as
+// opposed to being a bare [ErrUsage] that only requests the usage be shown.
+func hasDetail(err error) bool {
+	var re runnerError
+	if errors.As(err, &re) {
+		err = re.err
+	}
+	return err != ErrUsage
+}
+
 // fromRunner reports whether err originated from a [Runner], as opposed to an
 // argument-parsing failure produced before the runner was reached.
 func fromRunner(err error) bool {
@@ -73,17 +104,19 @@ func fromRunner(err error) bool {
 // run adapts a [Runner] into a cobra RunE, installing signal-cancellation and
 // panic recovery. A recovered panic is rendered as a crash report and returned
 // as a [PanicError]; any other error is wrapped so that [Execute] can tell it
-// apart from an argument-parsing failure. store is placed on the context so the
-// runner can reach the application's storage roots.
-func (i *CommandInfo) run(builder Builder, store *storage.AppStorage, cl *arg.CommandLine) func(cmd *cobra.Command, args []string) error {
+// apart from an argument-parsing failure. The tree's storage is placed on the
+// context so the runner can reach the application's storage roots, and cl so
+// the provenance of each resolved argument can be queried. Required arguments
+// still missing once cl is bound are asked for by the tree's prompter, whose
+// buffered input becomes the command's input stream so that nothing read ahead
+// by one is lost to the other.
+func (i *CommandInfo) run(builder Builder, t *tree, cl *arg.CommandLine) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) (err error) {
 		ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt)
 		defer cancel()
-		stdout := cmd.OutOrStdout()
 		stderr := cmd.ErrOrStderr()
-		ctx = clictx.WithWriters(ctx, stdout, stderr)
-		ctx = clictx.WithSizer(ctx, term.DefaultSizer)
-		ctx = clictx.WithStorage(ctx, store)
+		ctx = withEnvironment(ctx, t.prompter.replies, cmd.OutOrStdout(), stderr, t.store)
+		ctx = clictx.WithInteraction(ctx, t.prompter.policy(cmd))
 
 		defer func() {
 			if e := recover(); e != nil {
@@ -110,6 +143,30 @@ func (i *CommandInfo) run(builder Builder, store *storage.AppStorage, cl *arg.Co
 			return fmt.Errorf("%w: %w", ErrUsage, e)
 		}
 
+		// Ask for the required arguments that are still missing, when they may
+		// be prompted for. Failing that, they are reported as cobra reports the
+		// arguments it checks itself.
+		missing := argdef.Missing((*argdef.CommandLine)(cl))
+		if e := t.prompter.resolve(ctx, (*argdef.CommandLine)(cl), args, missing); e != nil {
+			return e
+		}
+
+		// Apply and check the conditional constraints now that every argument
+		// has been resolved.
+		if e := argdef.Enforce((*argdef.CommandLine)(cl)); e != nil {
+			return fmt.Errorf("%w: %w", ErrUsage, e)
+		}
+		ctx = clictx.WithArgs(ctx, (*argdef.CommandLine)(cl))
+		if debugArgsRequested(cmd) {
+			renderResolution(stderr, cmd, (*argdef.CommandLine)(cl))
+		}
+
+		// Give the registered arguments a chance to check one another before the
+		// builder consumes them.
+		if e := argdef.Validate(ctx, (*argdef.CommandLine)(cl)); e != nil {
+			return fmt.Errorf("%w: %w", ErrUsage, e)
+		}
+
 		runner, e := builder.Build(ctx)
 		if e != nil {
 			return e
@@ -122,6 +179,35 @@ func (i *CommandInfo) run(builder Builder, store *storage.AppStorage, cl *arg.Co
 	}
 }
 
+// withEnvironment returns ctx carrying the streams, terminal sizer, and storage
+// a command runs with.
+func withEnvironment(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer, store *storage.AppStorage) context.Context {
+	ctx = clictx.WithInput(ctx, stdin)
+	ctx = clictx.WithWriters(ctx, stdout, stderr)
+	ctx = clictx.WithSizer(ctx, term.DefaultSizer)
+	return clictx.WithStorage(ctx, store)
+}
+
+// prepareCompletion returns the [completion.Preparer] for a command whose
+// arguments are registered on cl. It resolves the arguments typed so far the
+// way [CommandInfo.run] would -- applying fallbacks and binding positionals --
+// but never builds or runs the command. Resolution is best effort: a failure
+// leaves the affected destinations unassigned rather than abandoning
+// completion. Standard output carries the completion protocol, so the context
+// discards anything written to it, and has no input to read.
+func (i *CommandInfo) prepareCompletion(store *storage.AppStorage, cl *arg.CommandLine) completion.Preparer {
+	return func(cmd *cobra.Command, args []string) context.Context {
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		ctx = withEnvironment(ctx, strings.NewReader(""), io.Discard, cmd.ErrOrStderr(), store)
+		_ = argdef.SetFlagFallbacks(ctx, cmd.Flags())
+		_ = argdef.Bind(ctx, (*argdef.CommandLine)(cl), args)
+		return clictx.WithArgs(ctx, (*argdef.CommandLine)(cl))
+	}
+}
+
 // runnerError marks an error as originating from a [Runner], distinguishing a
 // runtime failure from a usage error produced by argument parsing.
 type runnerError struct {
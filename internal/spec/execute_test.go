@@ -3,13 +3,17 @@ package spec_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
+	"os"
 	"strings"
+	"sync/atomic"
 	"testing"
 
 	"github.com/bitwizeshift/go-cli/arg"
 	"github.com/bitwizeshift/go-cli/internal/argdef"
 	"github.com/bitwizeshift/go-cli/internal/clictx"
+	"github.com/bitwizeshift/go-cli/internal/completion"
 	"github.com/bitwizeshift/go-cli/internal/spec"
 	"github.com/bitwizeshift/go-cli/internal/spec/spectest"
 	"github.com/google/go-cmp/cmp"
@@ -292,6 +296,291 @@ func TestExecute_PositionalBindError_ShowsUsage(t *testing.T) {
 	}
 }
 
+// constrainedRunner is a [spec.Runner] and [arg.Registrar] whose certificate
+// flag is required only when TLS is enabled.
+type constrainedRunner struct {
+	tls  bool
+	cert string
+}
+
+func (cr *constrainedRunner) RegisterArgs(cl *arg.CommandLine) {
+	tls := arg.Flag("tls", &cr.tls)
+	cert := arg.Flag("tls-cert", &cr.cert)
+	cl.Add(tls, cert, arg.RequiredIf(arg.Equals(tls, "true"), cert))
+}
+
+func (cr *constrainedRunner) Run(context.Context) error {
+	return nil
+}
+
+func TestExecute_ConstraintViolation_ShowsErrorAndUsage(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	var stderr strings.Builder
+	sut := build(t, "name: root\n", spec.Options{
+		Builders: toBuilders(map[string]spec.Runner{"root": &constrainedRunner{}}),
+		Stdout:   io.Discard,
+		Stderr:   &stderr,
+	})
+	sut.SetArgs([]string{"--tls"})
+	ctx := context.Background()
+
+	// Act
+	err := spec.Execute(ctx, sut)
+
+	// Assert
+	if got, want := err, spec.ErrUsage; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Fatalf("spec.Execute(...) = %v, want %v", got, want)
+	}
+	// The message is wrapped to the terminal width, so compare it unwrapped.
+	unwrapped := strings.Join(strings.Fields(stderr.String()), " ")
+	if got, want := strings.Contains(unwrapped, "--tls-cert is required when --tls is true"), true; got != want {
+		t.Errorf("stderr explains violation = %t, want %t", got, want)
+	}
+	if got, want := strings.Contains(stderr.String(), "--help"), true; got != want {
+		t.Errorf("stderr shows usage = %t, want %t", got, want)
+	}
+}
+
+// validatedRunner is a [spec.Runner], [arg.Registrar], and [arg.Validator]
+// whose bounds must be ordered.
+type validatedRunner struct {
+	min, max int
+	ran      bool
+}
+
+func (vr *validatedRunner) RegisterArgs(cl *arg.CommandLine) {
+	cl.Add(arg.Flag("min", &vr.min), arg.Flag("max", &vr.max))
+}
+
+func (vr *validatedRunner) ValidateArgs(context.Context) error {
+	if vr.min > vr.max {
+		return fmt.Errorf("--min %d exceeds --max %d", vr.min, vr.max)
+	}
+	return nil
+}
+
+func (vr *validatedRunner) Run(context.Context) error {
+	vr.ran = true
+	return nil
+}
+
+func TestExecute_ValidatorError_ShowsErrorAndUsage(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	var stderr strings.Builder
+	runner := &validatedRunner{}
+	sut := build(t, "name: root\n", spec.Options{
+		Builders: toBuilders(map[string]spec.Runner{"root": runner}),
+		Stdout:   io.Discard,
+		Stderr:   &stderr,
+	})
+	sut.SetArgs([]string{"--min", "10", "--max", "1"})
+	ctx := context.Background()
+
+	// Act
+	err := spec.Execute(ctx, sut)
+
+	// Assert
+	if got, want := err, spec.ErrUsage; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Fatalf("spec.Execute(...) = %v, want %v", got, want)
+	}
+	unwrapped := strings.Join(strings.Fields(stderr.String()), " ")
+	if got, want := strings.Contains(unwrapped, "--min 10 exceeds --max 1"), true; got != want {
+		t.Errorf("stderr explains failure = %t, want %t", got, want)
+	}
+	if got, want := strings.Contains(stderr.String(), "--help"), true; got != want {
+		t.Errorf("stderr shows usage = %t, want %t", got, want)
+	}
+	if got, want := runner.ran, false; got != want {
+		t.Errorf("runner ran = %t, want %t", got, want)
+	}
+}
+
+func TestExecute_DebugArgs_PrintsResolution(t *testing.T) {
+	t.Setenv("DEBUG_ARGS_CERT", "env.pem")
+
+	// Arrange
+	var stderr strings.Builder
+	sut := build(t, "name: root\n", spec.Options{
+		Builders: toBuilders(map[string]spec.Runner{"root": &constrainedRunner{}}),
+		Stdout:   io.Discard,
+		Stderr:   &stderr,
+	})
+	sut.Flags().Lookup("tls-cert").Annotations = map[string][]string{
+		argdef.AnnotationENVFallback: {"DEBUG_ARGS_CERT"},
+	}
+	sut.SetArgs([]string{"--tls", "--debug-args"})
+	ctx := context.Background()
+
+	// Act
+	err := spec.Execute(ctx, sut)
+
+	// Assert
+	if got, want := err, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Fatalf("spec.Execute(...) = %v, want %v", got, want)
+	}
+	want := "ARGUMENT     VALUE     SOURCE\n" +
+		"--tls        true      command line\n" +
+		"--tls-cert   env.pem   env DEBUG_ARGS_CERT\n"
+	if got := stderr.String(); !cmp.Equal(got, want) {
+		t.Errorf("stderr mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
+
+// copyRunner is a [spec.Runner] and [arg.Registrar] with a required source
+// and an optional destination.
+type copyRunner struct {
+	src string
+	dst string
+}
+
+func (cr *copyRunner) RegisterArgs(cl *arg.CommandLine) {
+	cl.Add(
+		arg.Positional("src", 0, &cr.src, arg.Required()),
+		arg.Positional("dst", 1, &cr.dst),
+	)
+}
+
+func (cr *copyRunner) Run(context.Context) error {
+	return nil
+}
+
+func TestExecute_DebugArgs_BracketsOptionalPositionals(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	var stderr strings.Builder
+	sut := build(t, "name: root\n", spec.Options{
+		Builders: toBuilders(map[string]spec.Runner{"root": &copyRunner{}}),
+		Stdout:   io.Discard,
+		Stderr:   &stderr,
+	})
+	sut.SetArgs([]string{"a.txt", "--debug-args"})
+	ctx := context.Background()
+
+	// Act
+	err := spec.Execute(ctx, sut)
+
+	// Assert
+	if got, want := err, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Fatalf("spec.Execute(...) = %v, want %v", got, want)
+	}
+	want := "ARGUMENT   VALUE   SOURCE\n" +
+		"<src>      a.txt   command line\n" +
+		"[dst]              default\n"
+	if got := stderr.String(); !cmp.Equal(got, want) {
+		t.Errorf("stderr mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestExecute_RenderHTML_RendersHelpAsHTML(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	var stdout strings.Builder
+	sut := build(t, "name: root\nsummary: Does <things>\n", spec.Options{
+		Stdout: &stdout,
+		Stderr: io.Discard,
+	})
+	sut.SetArgs([]string{"--render-html", "--help"})
+	ctx := context.Background()
+
+	// Act
+	err := spec.Execute(ctx, sut)
+
+	// Assert
+	if got, want := err, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Fatalf("spec.Execute(...) = %v, want %v", got, want)
+	}
+	if got, want := strings.Contains(stdout.String(), "<span style="), true; got != want {
+		t.Errorf("stdout styled as HTML = %t, want %t", got, want)
+	}
+	if got, want := strings.Contains(stdout.String(), "Does &lt;things&gt;"), true; got != want {
+		t.Errorf("stdout escapes text = %t, want %t", got, want)
+	}
+	if got, want := strings.Contains(stdout.String(), "\x1b"), false; got != want {
+		t.Errorf("stdout contains escapes = %t, want %t", got, want)
+	}
+	if got, want := strings.Contains(stdout.String(), "render-html"), false; got != want {
+		t.Errorf("help lists hidden flag = %t, want %t", got, want)
+	}
+}
+
+// deferringBuilder is a [spec.Builder] whose positional argument completes
+// to "alpha" and defers work that records it ran.
+type deferringBuilder struct {
+	name string
+	ran  atomic.Bool
+}
+
+func (db *deferringBuilder) RegisterArgs(cl *arg.CommandLine) {
+	cl.Add(arg.Positional("name", 0, &db.name,
+		arg.CompleteWith(func(ctx context.Context, _ arg.CompletionContext) ([]arg.Candidate, error) {
+			completion.Defer(ctx, func() { db.ran.Store(true) })
+			return []arg.Candidate{{Value: "alpha"}}, nil
+		}),
+	))
+}
+
+func (db *deferringBuilder) Build(context.Context) (spec.Runner, error) {
+	return spectest.NoOpRunner(), nil
+}
+
+func TestExecute_DeferredCompletionWork_RunsBeforeReturning(t *testing.T) {
+	testCases := []struct {
+		name       string
+		ctx        context.Context
+		revalidate string
+	}{
+		{
+			name: "NotDetached",
+			ctx:  context.Background(),
+		}, {
+			name:       "DetachedCopy",
+			ctx:        spec.WithDetachedRevalidation(context.Background()),
+			revalidate: "1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("GO_CLI_COMPLETION_REVALIDATE", tc.revalidate)
+
+			// Arrange
+			r, w, err := os.Pipe()
+			if err != nil {
+				t.Fatalf("os.Pipe() = _, _, %v, want nil", err)
+			}
+			defer r.Close()
+			defer w.Close()
+			builder := &deferringBuilder{}
+			sut := build(t, "name: root\n", spec.Options{
+				Builders: map[string]spec.Builder{"root": builder},
+				Stdout:   w,
+				Stderr:   io.Discard,
+			})
+			sut.SetArgs([]string{cobra.ShellCompRequestCmd, ""})
+
+			// Act
+			err = spec.Execute(tc.ctx, sut)
+
+			// Assert
+			if got, want := err, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("spec.Execute(...) = %v, want %v", got, want)
+			}
+			if got, want := builder.ran.Load(), true; got != want {
+				t.Errorf("deferred work ran = %t, want %t", got, want)
+			}
+			if _, err := io.WriteString(w, "after"); err != nil {
+				t.Errorf("writing to stdout after spec.Execute(...) = %v, want nil", err)
+			}
+		})
+	}
+}
+
 // newRootCommand builds a single root command bound to runner, routing both of
 // its output streams to w.
 func newRootCommand(t testing.TB, runner spec.Runner, w io.Writer) *cobra.Command {
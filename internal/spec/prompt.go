@@ -0,0 +1,204 @@
+package spec
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bitwizeshift/go-cli/internal/argdef"
+	"github.com/bitwizeshift/go-cli/internal/clictx"
+	"github.com/bitwizeshift/go-cli/prompt"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+const (
+	// noInputFlag names the global flag that forbids prompting the user.
+	noInputFlag = "no-input"
+
+	// yesFlag names the global flag that accepts every confirmation.
+	yesFlag = "yes"
+
+	// maxAttempts is the number of rejected replies after which a prompt for a
+	// missing argument gives up.
+	maxAttempts = 3
+)
+
+// installInteraction registers the --no-input and --yes flags on cmd,
+// inherited by every command beneath it, and records those it registered on p.
+//
+// A flag is not registered when cmd or a command beneath it already defines a
+// flag of the same name: the application's own flag keeps its meaning, and is
+// never read as the framework's.
+func (p *prompter) installInteraction(cmd *cobra.Command) {
+	p.flags = map[string]bool{}
+	for _, name := range []string{noInputFlag, yesFlag} {
+		p.flags[name] = !definesFlag(cmd, name)
+	}
+	if p.flags[noInputFlag] {
+		cmd.PersistentFlags().Bool(noInputFlag, false, "never prompt for input; fail instead")
+	}
+	if p.flags[yesFlag] {
+		cmd.PersistentFlags().Bool(yesFlag, false, "answer yes to every confirmation")
+	}
+}
+
+// definesFlag reports whether cmd or any command beneath it defines a flag
+// named name.
+func definesFlag(cmd *cobra.Command, name string) bool {
+	if cmd.Flags().Lookup(name) != nil || cmd.PersistentFlags().Lookup(name) != nil {
+		return true
+	}
+	return slices.ContainsFunc(cmd.Commands(), func(sub *cobra.Command) bool {
+		return definesFlag(sub, name)
+	})
+}
+
+// prompter asks for the required arguments a command line was left without,
+// as marked by [github.com/bitwizeshift/go-cli/arg.PromptIfMissing].
+//
+// Replies are read through one buffered reader shared by every prompt, so the
+// input one prompt reads ahead is not lost to the next. It becomes the input
+// stream of each command, through which the prompts are made, so they write to
+// the command's error stream like any prompt its runner makes.
+type prompter struct {
+	in          io.Reader
+	replies     *bufio.Reader
+	prompts     io.Writer
+	interactive func() bool
+
+	// flags records which of the --no-input and --yes flags were registered
+	// by installInteraction.
+	flags map[string]bool
+}
+
+// newPrompter returns the prompter configured by opts. The base streams that
+// replies are read from and prompts written to, rather than the styled writers,
+// decide whether the user can be prompted; standard output may be redirected
+// without preventing it.
+func newPrompter(opts Options) *prompter {
+	p := &prompter{
+		in:          opts.Stdin,
+		prompts:     opts.Stderr,
+		interactive: opts.Interactive,
+	}
+	if p.in == nil {
+		p.in = os.Stdin
+	}
+	p.replies = bufio.NewReader(p.in)
+	if p.prompts == nil {
+		p.prompts = os.Stderr
+	}
+	if p.interactive == nil {
+		p.interactive = func() bool {
+			return !underCI() && isTerminal(p.in) && isTerminal(p.prompts)
+		}
+	}
+	return p
+}
+
+// policy returns the interaction policy for a run of cmd: the user may be
+// prompted unless --no-input was given or the streams are not interactive,
+// and --yes accepts every confirmation.
+func (p *prompter) policy(cmd *cobra.Command) clictx.Interaction {
+	return clictx.Interaction{
+		Interactive: !p.given(cmd, noInputFlag) && p.interactive(),
+		AssumeYes:   p.given(cmd, yesFlag),
+	}
+}
+
+// given reports whether the framework flag name was registered and given to
+// cmd.
+func (p *prompter) given(cmd *cobra.Command, name string) bool {
+	if !p.flags[name] {
+		return false
+	}
+	set, _ := cmd.Flags().GetBool(name)
+	return set
+}
+
+// resolve prompts for every argument of cl in missing, in order, when the
+// interaction policy on ctx allows it. args are the positional arguments of
+// the command line, used to report a positional that cannot be prompted for
+// exactly as a short command line would have been reported without prompting.
+//
+// A prompt that fails, because it was interrupted, input ended, or its replies
+// were rejected [maxAttempts] times, is reported as a usage error, as the
+// argument would have been without prompting.
+func (p *prompter) resolve(ctx context.Context, cl *argdef.CommandLine, args []string, missing []argdef.Ref) error {
+	if len(missing) == 0 {
+		return nil
+	}
+	if !clictx.InteractionPolicy(ctx).Interactive {
+		return notPrompted(cl, args, missing)
+	}
+	for _, ref := range missing {
+		if err := p.ask(ctx, ref); err != nil {
+			return fmt.Errorf("%w: %v: %w", ErrUsage, ref, err)
+		}
+	}
+	return nil
+}
+
+// ask prompts for the value of ref and assigns the answer to it: a choice among
+// the argument's choices, a masked reply for a secret, or otherwise a line that
+// is re-asked until the argument accepts it, up to [maxAttempts] times.
+func (p *prompter) ask(ctx context.Context, ref argdef.Ref) error {
+	pr := prompt.From(ctx)
+	label := ref.String()
+	if choices := ref.Choices(); len(choices) > 0 {
+		i, err := pr.Select(ctx, label+":", choices)
+		if err != nil {
+			return err
+		}
+		return ref.Answer(choices[i])
+	}
+	if ref.Secret() {
+		answer, err := pr.Secret(ctx, label+": ")
+		if err != nil {
+			return err
+		}
+		return ref.Answer(answer)
+	}
+	options := []prompt.Option{prompt.Validate(ref.Answer), prompt.MaxAttempts(maxAttempts)}
+	if usage := ref.Usage(); usage != "" {
+		options = append(options, prompt.Help(usage))
+	}
+	_, err := pr.Line(ctx, label+": ", options...)
+	return err
+}
+
+// notPrompted returns the error reported for the arguments in missing when no
+// prompt can be shown, matching the error reported for a command without
+// prompting: a short command line first, then the flags left unset.
+func notPrompted(cl *argdef.CommandLine, args []string, missing []argdef.Ref) error {
+	operands, _ := argdef.Split(cl, args)
+	if err := argdef.RequiredArity(cl).Validate(len(operands)); err != nil {
+		return err
+	}
+	var names []string
+	for _, ref := range missing {
+		if ref.Flag != nil {
+			names = append(names, ref.Flag.Name)
+		}
+	}
+	return fmt.Errorf(`required flag(s) "%s" not set`, strings.Join(names, `", "`))
+}
+
+// underCI reports whether the process runs under continuous integration, as
+// signalled by a true CI environment variable.
+func underCI() bool {
+	ci, err := strconv.ParseBool(os.Getenv("CI"))
+	return err == nil && ci
+}
+
+// isTerminal reports whether v is a file descriptor attached to a terminal.
+func isTerminal(v any) bool {
+	f, ok := v.(interface{ Fd() uintptr })
+	return ok && term.IsTerminal(int(f.Fd()))
+}
@@ -0,0 +1,512 @@
+package spec_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/bitwizeshift/go-cli/arg"
+	"github.com/bitwizeshift/go-cli/internal/arity"
+	"github.com/bitwizeshift/go-cli/internal/clictx"
+	"github.com/bitwizeshift/go-cli/internal/spec"
+	"github.com/bitwizeshift/go-cli/prompt"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+// deployment records the arguments of one run of a [deployRunner].
+type deployment struct {
+	Env    string
+	Port   int
+	Region string
+	Target string
+}
+
+// deployRunner is a [spec.Runner] whose flags may be prompted for: --env is
+// chosen from a fixed set, and --port must decode as an integer. Its required
+// positional is only prompted for when prompting is enabled for the tree.
+type deployRunner struct {
+	args deployment
+	ran  bool
+}
+
+func (dr *deployRunner) RegisterArgs(cl *arg.CommandLine) {
+	cl.Add(
+		arg.Flag("env", &dr.args.Env, arg.Required(), arg.PromptIfMissing(), arg.CompleteFrom("dev", "prod")),
+		arg.Flag("port", &dr.args.Port, arg.Required(), arg.PromptIfMissing()),
+		arg.Flag("region", &dr.args.Region, arg.Required(), arg.PromptIfMissing(), arg.Usage("region to deploy to")),
+		arg.Positional("target", 0, &dr.args.Target, arg.Required()),
+	)
+}
+
+func (dr *deployRunner) Run(context.Context) error {
+	dr.ran = true
+	return nil
+}
+
+var (
+	_ spec.Runner   = (*deployRunner)(nil)
+	_ arg.Registrar = (*deployRunner)(nil)
+)
+
+func TestRun_PromptIfMissing(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		prompt bool
+		args   []string
+		input  string
+		want   deployment
+	}{
+		{
+			name:  "GivenArgumentsAreNotPrompted",
+			args:  []string{"--env", "dev", "--port", "80", "--region", "eu", "web"},
+			input: "",
+			want:  deployment{Env: "dev", Port: 80, Region: "eu", Target: "web"},
+		}, {
+			name:  "PromptsForMissingFlag",
+			args:  []string{"--env", "dev", "--port", "80", "web"},
+			input: "us-east\n",
+			want:  deployment{Env: "dev", Port: 80, Region: "us-east", Target: "web"},
+		}, {
+			name:  "RepromptsUntilValueDecodes",
+			args:  []string{"--env", "dev", "--region", "eu", "web"},
+			input: "http\n8080\n",
+			want:  deployment{Env: "dev", Port: 8080, Region: "eu", Target: "web"},
+		}, {
+			name:  "ChoosesFromChoices",
+			args:  []string{"--port", "80", "--region", "eu", "web"},
+			input: "2\n",
+			want:  deployment{Env: "prod", Port: 80, Region: "eu", Target: "web"},
+		}, {
+			name:  "PromptsInOrder",
+			args:  []string{"web"},
+			input: "1\n443\nus-west\n",
+			want:  deployment{Env: "dev", Port: 443, Region: "us-west", Target: "web"},
+		}, {
+			name:   "PromptOptionCoversEveryRequiredArgument",
+			prompt: true,
+			args:   []string{"--env", "dev", "--port", "80", "--region", "eu"},
+			input:  "api\n",
+			want:   deployment{Env: "dev", Port: 80, Region: "eu", Target: "api"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			runner := &deployRunner{}
+			sut := build(t, "name: app", spec.Options{
+				Builders:    toBuilders(map[string]spec.Runner{"app": runner}),
+				Prompt:      tc.prompt,
+				Stdin:       strings.NewReader(tc.input),
+				Stdout:      io.Discard,
+				Stderr:      io.Discard,
+				Interactive: func() bool { return true },
+			})
+			sut.SetArgs(tc.args)
+
+			// Act
+			err := sut.Execute()
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Execute() = %v, want nil", err)
+			}
+			if got, want := runner.args, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Execute() ran with %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestRun_PromptIfMissing_NotInteractive(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		prompt      bool
+		args        []string
+		wantErr     error
+		wantMessage string
+	}{
+		{
+			name:        "MissingFlagsAreReported",
+			args:        []string{"--port", "80", "web"},
+			wantErr:     cmpopts.AnyError,
+			wantMessage: `required flag(s) "env", "region" not set`,
+		}, {
+			name:        "MissingPositionalIsReported",
+			prompt:      true,
+			args:        []string{"--env", "dev", "--port", "80", "--region", "eu"},
+			wantErr:     arity.ErrBadArity,
+			wantMessage: "accepts exactly 1 argument, but received 0",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			runner := &deployRunner{}
+			sut := build(t, "name: app", spec.Options{
+				Builders:    toBuilders(map[string]spec.Runner{"app": runner}),
+				Prompt:      tc.prompt,
+				Stdin:       strings.NewReader("unread\n"),
+				Stdout:      io.Discard,
+				Stderr:      io.Discard,
+				Interactive: func() bool { return false },
+			})
+			sut.SetArgs(tc.args)
+
+			// Act
+			err := sut.Execute()
+
+			// Assert
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("Execute() = %v, want %v", got, want)
+			}
+			if got, want := err.Error(), tc.wantMessage; got != want {
+				t.Errorf("Execute() error = %q, want %q", got, want)
+			}
+			if runner.ran {
+				t.Errorf("Execute() ran the runner, want it not run")
+			}
+		})
+	}
+}
+
+func TestRun_PromptIfMissing_PromptFails(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		input   string
+		wantErr error
+	}{
+		{
+			name:    "TooManyAttempts",
+			input:   "http\nhttps\nftp\n8080\n",
+			wantErr: prompt.ErrTooManyAttempts,
+		}, {
+			name:    "EndOfInput",
+			input:   "",
+			wantErr: io.EOF,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			runner := &deployRunner{}
+			sut := build(t, "name: app", spec.Options{
+				Builders:    toBuilders(map[string]spec.Runner{"app": runner}),
+				Stdin:       strings.NewReader(tc.input),
+				Stdout:      io.Discard,
+				Stderr:      io.Discard,
+				Interactive: func() bool { return true },
+			})
+			sut.SetArgs([]string{"--env", "dev", "--region", "eu", "web"})
+
+			// Act
+			err := sut.Execute()
+
+			// Assert
+			if got, want := err, spec.ErrUsage; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("Execute() = %v, want %v", got, want)
+			}
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Errorf("Execute() = %v, want %v", got, want)
+			}
+			if runner.ran {
+				t.Errorf("Execute() ran the runner, want it not run")
+			}
+		})
+	}
+}
+
+// tokenRunner is a [spec.Runner] with a secret token and a region that is
+// prompted for when missing.
+type tokenRunner struct {
+	token  string
+	region string
+}
+
+func (tr *tokenRunner) RegisterArgs(cl *arg.CommandLine) {
+	cl.Add(
+		arg.Flag("region", &tr.region, arg.Required(), arg.PromptIfMissing()),
+		arg.Flag("token", &tr.token, arg.Secret()),
+	)
+}
+
+func (tr *tokenRunner) Run(context.Context) error {
+	return nil
+}
+
+var (
+	_ spec.Runner   = (*tokenRunner)(nil)
+	_ arg.Registrar = (*tokenRunner)(nil)
+)
+
+func TestExecute_DebugArgs_ShowsPromptedAndMasksSecret(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	var stderr strings.Builder
+	sut := build(t, "name: root\n", spec.Options{
+		Builders:    toBuilders(map[string]spec.Runner{"root": &tokenRunner{}}),
+		Stdin:       strings.NewReader("eu\n"),
+		Stdout:      io.Discard,
+		Stderr:      &stderr,
+		Interactive: func() bool { return true },
+	})
+	sut.SetArgs([]string{"--token", "s3cr3t", "--debug-args"})
+	ctx := context.Background()
+
+	// Act
+	err := spec.Execute(ctx, sut)
+
+	// Assert
+	if got, want := err, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Fatalf("spec.Execute(...) = %v, want %v", got, want)
+	}
+	want := "--region: " +
+		"ARGUMENT   VALUE      SOURCE\n" +
+		"--region   eu         prompt\n" +
+		"--token    ********   command line\n"
+	if got := stderr.String(); !cmp.Equal(got, want) {
+		t.Errorf("stderr mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
+
+// confirmRunner is a [spec.Runner] that asks for confirmation, recording the
+// answer and whether the run was interactive.
+type confirmRunner struct {
+	in string
+
+	interactive bool
+	answer      bool
+	err         error
+}
+
+func (cr *confirmRunner) Run(ctx context.Context) error {
+	pr := &prompt.Prompter{Out: io.Discard, In: strings.NewReader(cr.in)}
+	cr.interactive = clictx.InteractionPolicy(ctx).Interactive
+	cr.answer, cr.err = pr.Confirm(ctx, "Continue?")
+	return nil
+}
+
+var _ spec.Runner = (*confirmRunner)(nil)
+
+func TestRun_InteractionPolicy(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name            string
+		args            []string
+		interactive     bool
+		wantInteractive bool
+		wantAnswer      bool
+		wantErr         error
+	}{
+		{
+			name:            "InteractiveAsks",
+			interactive:     true,
+			wantInteractive: true,
+			wantAnswer:      true,
+		}, {
+			name:            "NoInputFailsFast",
+			args:            []string{"--no-input"},
+			interactive:     true,
+			wantInteractive: false,
+			wantErr:         prompt.ErrNonInteractive,
+		}, {
+			name:            "NotInteractiveFailsFast",
+			interactive:     false,
+			wantInteractive: false,
+			wantErr:         prompt.ErrNonInteractive,
+		}, {
+			name:            "YesAcceptsWithoutAsking",
+			args:            []string{"--yes"},
+			interactive:     false,
+			wantInteractive: false,
+			wantAnswer:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			runner := &confirmRunner{in: "y\n"}
+			sut := build(t, "name: app", spec.Options{
+				Builders:    toBuilders(map[string]spec.Runner{"app": runner}),
+				Stdout:      io.Discard,
+				Stderr:      io.Discard,
+				Interactive: func() bool { return tc.interactive },
+			})
+			sut.SetArgs(tc.args)
+
+			// Act
+			err := sut.Execute()
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Execute() = %v, want nil", err)
+			}
+			if got, want := runner.err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Errorf("Confirm(...) error = %v, want %v", got, want)
+			}
+			if got, want := runner.answer, tc.wantAnswer; got != want {
+				t.Errorf("Confirm(...) = %t, want %t", got, want)
+			}
+			if got, want := runner.interactive, tc.wantInteractive; got != want {
+				t.Errorf("Interactive = %t, want %t", got, want)
+			}
+		})
+	}
+}
+
+// ownYesRunner is a [confirmRunner] that defines a --yes flag of its own.
+type ownYesRunner struct {
+	confirmRunner
+	yes bool
+}
+
+func (yr *ownYesRunner) RegisterArgs(cl *arg.CommandLine) {
+	cl.Add(arg.Flag("yes", &yr.yes))
+}
+
+var (
+	_ spec.Runner   = (*ownYesRunner)(nil)
+	_ arg.Registrar = (*ownYesRunner)(nil)
+)
+
+func TestRun_InteractionPolicy_KeepsApplicationFlag(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	runner := &ownYesRunner{confirmRunner: confirmRunner{in: "y\n"}}
+	sut := build(t, "name: app", spec.Options{
+		Builders:    toBuilders(map[string]spec.Runner{"app": runner}),
+		Stdout:      io.Discard,
+		Stderr:      io.Discard,
+		Interactive: func() bool { return false },
+	})
+	sut.SetArgs([]string{"--yes", "--no-input"})
+
+	// Act
+	err := sut.Execute()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Execute() = %v, want nil", err)
+	}
+	if got, want := runner.yes, true; got != want {
+		t.Errorf("application --yes = %t, want %t", got, want)
+	}
+	if got, want := runner.err, prompt.ErrNonInteractive; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Errorf("Confirm(...) error = %v, want %v", got, want)
+	}
+	if got := sut.PersistentFlags().Lookup("yes"); got != nil {
+		t.Errorf("framework --yes = %v, want nil", got)
+	}
+	if got := sut.PersistentFlags().Lookup("no-input"); got == nil {
+		t.Errorf("framework --no-input = nil, want registered")
+	}
+}
+
+func TestExecute_DebugArgs_ListsApplicationYesFlag(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	var stderr strings.Builder
+	runner := &ownYesRunner{confirmRunner: confirmRunner{in: "y\n"}}
+	sut := build(t, "name: app", spec.Options{
+		Builders:    toBuilders(map[string]spec.Runner{"app": runner}),
+		Stdout:      io.Discard,
+		Stderr:      &stderr,
+		Interactive: func() bool { return false },
+	})
+	sut.SetArgs([]string{"--yes", "--no-input", "--debug-args"})
+
+	// Act
+	err := spec.Execute(context.Background(), sut)
+
+	// Assert
+	if got, want := err, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Fatalf("spec.Execute(...) = %v, want %v", got, want)
+	}
+	want := "ARGUMENT   VALUE   SOURCE\n" +
+		"--yes      true    command line\n"
+	if got := stderr.String(); !cmp.Equal(got, want) {
+		t.Errorf("stderr mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
+
+// greetRunner is a [spec.Runner] with a region prompted for when missing, that
+// then asks for a name itself.
+type greetRunner struct {
+	region string
+	name   string
+	err    error
+}
+
+func (gr *greetRunner) RegisterArgs(cl *arg.CommandLine) {
+	cl.Add(arg.Flag("region", &gr.region, arg.Required(), arg.PromptIfMissing()))
+}
+
+func (gr *greetRunner) Run(ctx context.Context) error {
+	gr.name, gr.err = prompt.Line(ctx, "[theme:label]Name:[/theme] ")
+	return nil
+}
+
+var (
+	_ spec.Runner   = (*greetRunner)(nil)
+	_ arg.Registrar = (*greetRunner)(nil)
+)
+
+func TestRun_RunnerPromptsThroughCommandStreams(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	var stdout, stderr strings.Builder
+	runner := &greetRunner{}
+	sut := build(t, "name: app", spec.Options{
+		Builders:    toBuilders(map[string]spec.Runner{"app": runner}),
+		Stdin:       strings.NewReader("eu\nbob\n"),
+		Stdout:      &stdout,
+		Stderr:      &stderr,
+		Colour:      spec.ColourDisabled,
+		Interactive: func() bool { return true },
+	})
+	sut.SetArgs(nil)
+
+	// Act
+	err := sut.Execute()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Execute() = %v, want nil", err)
+	}
+	if got, want := runner.err, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Fatalf("prompt.Line(...) = %v, want %v", got, want)
+	}
+	if got, want := []string{runner.region, runner.name}, []string{"eu", "bob"}; !cmp.Equal(got, want) {
+		t.Errorf("Execute() answers = %q, want %q", got, want)
+	}
+	if got, want := stderr.String(), "--region: Name: "; got != want {
+		t.Errorf("stderr = %q, want %q", got, want)
+	}
+	if got, want := stdout.String(), ""; got != want {
+		t.Errorf("stdout = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,59 @@
+package spec
+
+import (
+	"context"
+	"os"
+	"os/exec"
+
+	"github.com/bitwizeshift/go-cli/internal/completion"
+)
+
+// revalidateEnv names the environment variable marking a process started to
+// run the work a completion deferred, rather than to serve the completion.
+const revalidateEnv = "GO_CLI_COMPLETION_REVALIDATE"
+
+// ctxKeyDetached is the context key under which [WithDetachedRevalidation]
+// marks a context.
+type ctxKeyDetached struct{}
+
+// WithDetachedRevalidation returns a copy of ctx under which [Execute] hands
+// the work a completion deferred to a copy of the running process, started
+// with the same arguments and left running once Execute returns. The copy
+// writes nothing to the caller's streams; it serves the completion again, with
+// its output discarded, and runs the work itself. Should the copy fail to
+// start, the work runs before Execute returns, as it does without this option.
+//
+// Only the entry point of a program that exits once Execute returns should
+// request it, since the copy re-runs that program.
+func WithDetachedRevalidation(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxKeyDetached{}, true)
+}
+
+// revalidate runs the work deferred under ctx by calling run, unless ctx was
+// returned by [WithDetachedRevalidation] and a copy of the process can be
+// started to run it instead. The copy runs the work itself rather than
+// starting another.
+func revalidate(ctx context.Context, run func()) {
+	detached, _ := ctx.Value(ctxKeyDetached{}).(bool)
+	if detached && completion.Pending(ctx) && os.Getenv(revalidateEnv) == "" {
+		if startRevalidation() == nil {
+			return
+		}
+	}
+	run()
+}
+
+// startRevalidation starts a copy of the running process, with its standard
+// streams attached to the null device, and does not wait for it to exit.
+func startRevalidation() error {
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(exe, os.Args[1:]...)
+	cmd.Env = append(os.Environ(), revalidateEnv+"=1")
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	return cmd.Process.Release()
+}
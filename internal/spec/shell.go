@@ -0,0 +1,335 @@
+package spec
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/bitwizeshift/go-cli/internal/argdef"
+	"github.com/bitwizeshift/go-cli/internal/clictx"
+	"github.com/bitwizeshift/go-cli/internal/completion"
+	"github.com/bitwizeshift/go-cli/internal/lineedit"
+	"github.com/bitwizeshift/go-cli/internal/session"
+	"github.com/bitwizeshift/go-cli/internal/shellwords"
+	"github.com/bitwizeshift/go-cli/internal/template"
+	"github.com/bitwizeshift/go-cli/internal/template/panichandler"
+	"github.com/bitwizeshift/go-cli/internal/term"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// ShellCommand names the command added to the root by [Options.Shell].
+	ShellCommand = "shell"
+
+	// historyFile names the file, within the application's data storage, that
+	// holds the shell's history between sessions.
+	historyFile = "shell_history"
+
+	// historyLimit is the number of lines of history the shell retains.
+	historyLimit = 1000
+)
+
+// exitCommands names the words that end a shell session, unless the tree
+// defines a command of the same name.
+var exitCommands = []string{"exit", "quit"}
+
+// shell reads command lines interactively and dispatches each through the tree
+// rooted at root, as though it had been given on the command line.
+//
+// The work a completion defers runs in the background while the line is still
+// being edited, and revalidating tracks it: the tree is not reset for the next
+// line or completion until it finishes, since it reads the arguments the
+// completion resolved.
+type shell struct {
+	root    *cobra.Command
+	tree    *tree
+	in      io.Reader
+	out     io.Writer
+	console term.EchoDisabler
+
+	revalidating sync.WaitGroup
+}
+
+// installShell adds the [ShellCommand] to root, reading lines from the input
+// stream configured by opts.
+func installShell(root *cobra.Command, t *tree, opts Options) {
+	s := &shell{
+		root:    root,
+		tree:    t,
+		in:      opts.Stdin,
+		out:     opts.Stdout,
+		console: opts.Console,
+	}
+	if s.in == nil {
+		s.in = os.Stdin
+	}
+	if s.out == nil {
+		s.out = os.Stdout
+	}
+	if s.console == nil {
+		s.console = term.DefaultConsole
+	}
+	root.AddCommand(&cobra.Command{
+		Use:   ShellCommand,
+		Short: "Start an interactive shell",
+		Long: "Start an interactive shell that runs each line entered as a command of " +
+			root.Name() + ". Enter \"exit\" or press Ctrl-D to leave the shell.",
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE:              s.run,
+	})
+}
+
+// run reads and dispatches lines until input ends or an exit command is
+// entered. Every line runs against a context carrying the same
+// [session.Session], which is closed when the shell exits.
+func (s *shell) run(cmd *cobra.Command, _ []string) (err error) {
+	ctx := cmd.Context()
+	if clictx.Session(ctx) != nil {
+		return ErrNestedShell
+	}
+	sess := session.New()
+	defer func() {
+		err = errors.Join(err, sess.Close())
+	}()
+	defer s.revalidating.Wait()
+	ctx = clictx.WithSession(ctx, sess)
+
+	history := s.loadHistory()
+	defer s.saveHistory(history)
+
+	editor := &lineedit.Editor{
+		Out:     s.out,
+		In:      s.in,
+		Console: s.console,
+		History: history,
+		Complete: func(head string) []string {
+			return s.complete(ctx, head)
+		},
+	}
+	prompt := s.root.Name() + "> "
+	for {
+		line, err := editor.ReadLine(prompt)
+		switch {
+		case errors.Is(err, lineedit.ErrInterrupted):
+			continue
+		case errors.Is(err, io.EOF):
+			return nil
+		case err != nil:
+			return err
+		}
+		words, err := shellwords.Split(line)
+		if err != nil {
+			renderError(s.root.ErrOrStderr(), err)
+			continue
+		}
+		if len(words) == 0 {
+			continue
+		}
+		if s.isExit(words[0]) {
+			return nil
+		}
+		s.dispatch(ctx, words)
+	}
+}
+
+// isExit reports whether word ends the session, which it does when it is one
+// of the exitCommands and names no command of the tree.
+func (s *shell) isExit(word string) bool {
+	if !slices.Contains(exitCommands, word) {
+		return false
+	}
+	for _, sub := range s.root.Commands() {
+		if sub.Name() == word || sub.HasAlias(word) {
+			return false
+		}
+	}
+	return true
+}
+
+// dispatch executes the command line words, reporting any error the way
+// [Execute] would. A panic is rendered as a crash report rather than ending the
+// session.
+func (s *shell) dispatch(ctx context.Context, words []string) {
+	stderr := s.root.ErrOrStderr()
+	defer flushStream(s.root.OutOrStdout())
+	defer flushStream(stderr)
+	defer func() {
+		if e := recover(); e != nil {
+			pctx := panichandler.PanicContext{
+				Err:      e,
+				Stack:    debug.Stack(),
+				IssueURL: argdef.IssueURL(s.root),
+			}
+			_ = template.DefaultRenderEngine.PanicRenderer().Render(stderr, pctx)
+		}
+	}()
+	s.reset(ctx)
+	s.root.SetArgs(words)
+	target, err := s.root.ExecuteContextC(ctx)
+	report(target, err)
+}
+
+// reset returns every command of the tree to the state it was built in, so the
+// next line is not affected by the arguments given to the last, and makes ctx
+// the context each command runs against. It first waits for the work deferred
+// by the last completion.
+func (s *shell) reset(ctx context.Context) {
+	s.revalidating.Wait()
+	for _, cl := range s.tree.lines {
+		argdef.Reset((*argdef.CommandLine)(cl))
+	}
+	walk(s.root, func(cmd *cobra.Command) {
+		argdef.ResetFlags(cmd.Flags())
+		argdef.ResetFlags(cmd.PersistentFlags())
+		cmd.SetContext(ctx)
+	})
+}
+
+// complete returns the candidates for the last word of head, as produced by
+// the tree's shell completion. Candidates are filtered to those extending the
+// word, and file names are offered when the completion directive asks for
+// them. The work the completion defers is started in the background once its
+// candidates are known.
+func (s *shell) complete(ctx context.Context, head string) []string {
+	words, err := shellwords.Split(head)
+	if err != nil {
+		return nil
+	}
+	toComplete := ""
+	if len(words) > 0 && !strings.HasSuffix(head, " ") {
+		toComplete = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+
+	ctx, runDeferred := completion.WithDeferred(ctx)
+	s.reset(ctx)
+	defer s.revalidating.Go(runDeferred)
+	var out bytes.Buffer
+	stdout, stderr := s.root.OutOrStdout(), s.root.ErrOrStderr()
+	setStreams(s.root, &out, io.Discard)
+	defer setStreams(s.root, stdout, stderr)
+	defer removeCompletionCommand(s.root)
+	args := append([]string{cobra.ShellCompNoDescRequestCmd}, words...)
+	s.root.SetArgs(append(args, toComplete))
+	if _, err := s.root.ExecuteContextC(ctx); err != nil {
+		return nil
+	}
+
+	candidates, directive := parseCompletion(out.String())
+	switch {
+	case directive&cobra.ShellCompDirectiveError != 0:
+		return nil
+	case directive&cobra.ShellCompDirectiveFilterFileExt != 0:
+		return completeFiles(toComplete, candidates, false)
+	case directive&cobra.ShellCompDirectiveFilterDirs != 0:
+		return completeFiles(toComplete, nil, true)
+	}
+	var matches []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, toComplete) {
+			matches = append(matches, candidate)
+		}
+	}
+	if len(matches) == 0 && directive&cobra.ShellCompDirectiveNoFileComp == 0 {
+		return completeFiles(toComplete, nil, false)
+	}
+	return matches
+}
+
+// removeCompletionCommand removes the hidden completion request commands that
+// cobra leaves on root after serving a completion request.
+func removeCompletionCommand(root *cobra.Command) {
+	for _, sub := range root.Commands() {
+		if sub.Name() == cobra.ShellCompRequestCmd {
+			root.RemoveCommand(sub)
+		}
+	}
+}
+
+// parseCompletion splits the output of a completion request into its
+// candidates and the directive reported on its final line.
+func parseCompletion(output string) ([]string, cobra.ShellCompDirective) {
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	last := lines[len(lines)-1]
+	directive, err := strconv.Atoi(strings.TrimPrefix(last, ":"))
+	if !strings.HasPrefix(last, ":") || err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	var candidates []string
+	for _, line := range lines[:len(lines)-1] {
+		if line != "" {
+			candidates = append(candidates, line)
+		}
+	}
+	return candidates, cobra.ShellCompDirective(directive)
+}
+
+// completeFiles returns the paths that extend prefix, suffixing directories
+// with a separator. Files are omitted when dirsOnly is set, or when exts is
+// non-empty and holds none of their extensions.
+func completeFiles(prefix string, exts []string, dirsOnly bool) []string {
+	matches, _ := filepath.Glob(prefix + "*")
+	var paths []string
+	for _, match := range matches {
+		info, err := os.Stat(match)
+		switch {
+		case err != nil:
+			continue
+		case info.IsDir():
+			paths = append(paths, match+string(filepath.Separator))
+		case dirsOnly:
+			continue
+		case len(exts) == 0 || slices.Contains(exts, strings.TrimPrefix(filepath.Ext(match), ".")):
+			paths = append(paths, match)
+		}
+	}
+	return paths
+}
+
+// loadHistory returns the history saved by earlier sessions. A history that
+// cannot be read is treated as empty.
+func (s *shell) loadHistory() *lineedit.History {
+	history := lineedit.NewHistory(historyLimit)
+	if data, err := s.tree.store.Data.ReadFile(historyFile); err == nil {
+		_ = history.Load(bytes.NewReader(data))
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		renderError(s.root.ErrOrStderr(), fmt.Errorf("loading shell history: %w", err))
+	}
+	return history
+}
+
+// saveHistory records history for later sessions, reporting but otherwise
+// ignoring a failure to do so.
+func (s *shell) saveHistory(history *lineedit.History) {
+	var buf bytes.Buffer
+	_ = history.Save(&buf)
+	if err := s.tree.store.Data.WriteFile(historyFile, buf.Bytes()); err != nil {
+		renderError(s.root.ErrOrStderr(), fmt.Errorf("saving shell history: %w", err))
+	}
+}
+
+// walk calls fn for cmd and every command beneath it.
+func walk(cmd *cobra.Command, fn func(*cobra.Command)) {
+	fn(cmd)
+	for _, sub := range cmd.Commands() {
+		walk(sub, fn)
+	}
+}
+
+// flushStream writes out anything w has buffered, when it is able to.
+func flushStream(w io.Writer) {
+	if f, ok := w.(interface{ Flush() error }); ok {
+		_ = f.Flush()
+	}
+}
@@ -0,0 +1,377 @@
+package spec_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bitwizeshift/go-cli/arg"
+	"github.com/bitwizeshift/go-cli/internal/clictx"
+	"github.com/bitwizeshift/go-cli/internal/spec"
+	"github.com/bitwizeshift/go-cli/internal/spec/spectest"
+	"github.com/bitwizeshift/go-cli/internal/term"
+	"github.com/bitwizeshift/go-cli/internal/term/termtest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/spf13/cobra"
+)
+
+// shellSpec is a command tree with a greeting command and one that panics.
+const shellSpec = `
+name: app
+commands:
+  default:
+    - name: greet
+    - name: boom
+`
+
+// greeting records the arguments of one run of a [greetBuilder].
+type greeting struct {
+	Name string
+	Loud bool
+}
+
+// greetBuilder is a [spec.Builder] that records the arguments of each run, and
+// the number of distinct connections the runs shared through their session.
+type greetBuilder struct {
+	name string
+	loud bool
+
+	runs    []greeting
+	opened  int
+	closed  int
+	lastErr error
+}
+
+// connection is the session key a [greetBuilder] stores its connection under.
+type connection struct{}
+
+func (gb *greetBuilder) RegisterArgs(cl *arg.CommandLine) {
+	cl.Add(
+		arg.Flag("loud", &gb.loud),
+		arg.Positional("name", 0, &gb.name),
+	)
+}
+
+func (gb *greetBuilder) Build(ctx context.Context) (spec.Runner, error) {
+	if sess := clictx.Session(ctx); sess != nil {
+		_, _, gb.lastErr = sess.LoadOrStore(connection{}, func() (any, error) {
+			gb.opened++
+			sess.OnClose(func() error {
+				gb.closed++
+				return nil
+			})
+			return connection{}, nil
+		})
+	}
+	gb.runs = append(gb.runs, greeting{Name: gb.name, Loud: gb.loud})
+	return spectest.NoOpRunner(), nil
+}
+
+var (
+	_ spec.Builder  = (*greetBuilder)(nil)
+	_ arg.Registrar = (*greetBuilder)(nil)
+)
+
+// buildShell builds shellSpec with the shell enabled, reading input and
+// editing lines through console. Application data is redirected to a
+// temporary directory, which is returned.
+func buildShell(t *testing.T, builder *greetBuilder, input string, console term.EchoDisabler, stderr io.Writer) (*cobra.Command, string) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", dir)
+	t.Setenv("HOME", dir)
+	sut := build(t, shellSpec, spec.Options{
+		Builders: map[string]spec.Builder{
+			"app.greet": builder,
+			"app.boom":  spectest.PassThroughBuilder(spectest.PanicRunner("kaboom")),
+		},
+		Shell:   true,
+		Stdin:   strings.NewReader(input),
+		Stdout:  io.Discard,
+		Stderr:  stderr,
+		Console: console,
+	})
+	sut.SetArgs([]string{spec.ShellCommand})
+	return sut, dir
+}
+
+func TestShell_DispatchesLines(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+		want  []greeting
+	}{
+		{
+			name:  "ResetsArgumentsBetweenLines",
+			input: "greet --loud alice\ngreet bob\n",
+			want:  []greeting{{Name: "alice", Loud: true}, {Name: "bob"}},
+		}, {
+			name:  "SplitsQuotedWords",
+			input: "greet 'alice smith'\ngreet \"bob \\\"b\\\" jones\"\n",
+			want:  []greeting{{Name: "alice smith"}, {Name: "bob \"b\" jones"}},
+		}, {
+			name:  "SkipsBlankLines",
+			input: "\n   \ngreet alice\n",
+			want:  []greeting{{Name: "alice"}},
+		}, {
+			name:  "StopsAtExit",
+			input: "greet alice\nexit\ngreet bob\n",
+			want:  []greeting{{Name: "alice"}},
+		}, {
+			name:  "SurvivesPanic",
+			input: "boom\ngreet alice\n",
+			want:  []greeting{{Name: "alice"}},
+		}, {
+			name:  "SurvivesUsageError",
+			input: "greet --nope\ngreet alice\n",
+			want:  []greeting{{Name: "alice"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			builder := &greetBuilder{}
+			sut, _ := buildShell(t, builder, tc.input, termtest.ErrEchoDisabler(term.ErrNotDescriptor), io.Discard)
+
+			// Act
+			err := spec.Execute(context.Background(), sut)
+
+			// Assert
+			if got, want := err, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("spec.Execute(...) = %v, want %v", got, want)
+			}
+			if got, want := builder.runs, tc.want; !cmp.Equal(got, want, cmpopts.EquateEmpty()) {
+				t.Errorf("runs = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestShell_SharesSessionAcrossLines(t *testing.T) {
+	// Arrange
+	builder := &greetBuilder{}
+	sut, _ := buildShell(t, builder, "greet alice\ngreet bob\n", termtest.ErrEchoDisabler(term.ErrNotDescriptor), io.Discard)
+
+	// Act
+	err := spec.Execute(context.Background(), sut)
+
+	// Assert
+	if err := errors.Join(err, builder.lastErr); err != nil {
+		t.Fatalf("spec.Execute(...) = %v, want nil", err)
+	}
+	if got, want := builder.opened, 1; got != want {
+		t.Errorf("connections opened = %d, want %d", got, want)
+	}
+	if got, want := builder.closed, 1; got != want {
+		t.Errorf("connections closed = %d, want %d", got, want)
+	}
+}
+
+func TestShell_PersistsHistory(t *testing.T) {
+	// Arrange
+	builder := &greetBuilder{}
+	sut, dir := buildShell(t, builder, "greet alice\n", termtest.ErrEchoDisabler(term.ErrNotDescriptor), io.Discard)
+
+	// Act
+	err := spec.Execute(context.Background(), sut)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("spec.Execute(...) = %v, want nil", err)
+	}
+	matches, _ := filepath.Glob(filepath.Join(dir, "*", "*", "shell_history"))
+	if len(matches) == 0 {
+		matches, _ = filepath.Glob(filepath.Join(dir, "*", "shell_history"))
+	}
+	if got, want := len(matches), 1; got != want {
+		t.Fatalf("history files = %q, want %d", matches, want)
+	}
+	data, err := os.ReadFile(matches[0])
+	if err != nil {
+		t.Fatalf("os.ReadFile(...) = %v, want nil", err)
+	}
+	if got, want := string(data), "greet alice\n"; got != want {
+		t.Errorf("history = %q, want %q", got, want)
+	}
+}
+
+func TestShell_Nested_ReportsError(t *testing.T) {
+	// Arrange
+	var stderr strings.Builder
+	builder := &greetBuilder{}
+	sut, _ := buildShell(t, builder, "shell\ngreet alice\n", termtest.ErrEchoDisabler(term.ErrNotDescriptor), &stderr)
+
+	// Act
+	err := spec.Execute(context.Background(), sut)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("spec.Execute(...) = %v, want nil", err)
+	}
+	if got, want := stderr.String(), spec.ErrNestedShell.Error(); !strings.Contains(got, want) {
+		t.Errorf("stderr = %q, want it to contain %q", got, want)
+	}
+	if got, want := builder.runs, []greeting{{Name: "alice"}}; !cmp.Equal(got, want) {
+		t.Errorf("runs = %+v, want %+v", got, want)
+	}
+}
+
+func TestShell_TabCompletesCommandsAndFlags(t *testing.T) {
+	// Arrange
+	builder := &greetBuilder{}
+	sut, _ := buildShell(t, builder, "gr\t--lo\talice\r\x04", termtest.NoOpEchoDisabler(), io.Discard)
+
+	// Act
+	err := spec.Execute(context.Background(), sut)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("spec.Execute(...) = %v, want nil", err)
+	}
+	if got, want := builder.runs, []greeting{{Name: "alice", Loud: true}}; !cmp.Equal(got, want) {
+		t.Errorf("runs = %+v, want %+v", got, want)
+	}
+}
+
+// podsSpec is a command tree with one command whose positional argument has
+// cached completions.
+const podsSpec = `
+name: app
+commands:
+  default:
+    - name: pods
+`
+
+// podsTTL is how long the candidates of a [podsBuilder] are cached fresh.
+const podsTTL = 400 * time.Millisecond
+
+// pod records the arguments of one run of a [podsBuilder].
+type pod struct {
+	Cluster string
+	Name    string
+}
+
+// podsBuilder is a [spec.Builder] whose pod argument completes the pods of the
+// cluster given by a flag, caching them for podsTTL. The second completion is
+// delayed until the cached pods are stale, so they are recomputed once it has
+// been served.
+type podsBuilder struct {
+	cluster string
+	name    string
+
+	keys     atomic.Int32
+	computed atomic.Int32
+	runs     []pod
+}
+
+func (pb *podsBuilder) RegisterArgs(cl *arg.CommandLine) {
+	cl.Add(
+		// Completion parses the flags typed so far more than once.
+		arg.Flag("cluster", &pb.cluster, arg.Repeatable()),
+		arg.Positional("pod", 0, &pb.name,
+			arg.CompleteWith(func(_ context.Context, c arg.CompletionContext) ([]arg.Candidate, error) {
+				pb.computed.Add(1)
+				cluster, _ := c.Flag("cluster")
+				return []arg.Candidate{{Value: "web-" + cluster}, {Value: "worker-" + cluster}}, nil
+			}),
+			arg.CacheCompletion(podsTTL, func(c arg.CompletionContext) string {
+				if pb.keys.Add(1) == 2 {
+					time.Sleep(podsTTL * 3 / 2)
+				}
+				cluster, _ := c.Flag("cluster")
+				return "pods/" + cluster
+			}),
+		),
+	)
+}
+
+func (pb *podsBuilder) Build(context.Context) (spec.Runner, error) {
+	pb.runs = append(pb.runs, pod{Cluster: pb.cluster, Name: pb.name})
+	return spectest.NoOpRunner(), nil
+}
+
+var (
+	_ spec.Builder  = (*podsBuilder)(nil)
+	_ arg.Registrar = (*podsBuilder)(nil)
+)
+
+func TestShell_TabCompletion_RevalidatesBeforeNextLine(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", dir)
+	t.Setenv("XDG_DATA_HOME", dir)
+	t.Setenv("HOME", dir)
+	builder := &podsBuilder{}
+	sut := build(t, podsSpec, spec.Options{
+		Builders: map[string]spec.Builder{"app.pods": builder},
+		Shell:    true,
+		Stdin:    strings.NewReader("pods --cluster prod w\t\teb-prod\rpods --cluster dev web-dev\r\x04"),
+		Stdout:   io.Discard,
+		Stderr:   io.Discard,
+		Console:  termtest.NoOpEchoDisabler(),
+	})
+	sut.SetArgs([]string{spec.ShellCommand})
+
+	// Act
+	err := spec.Execute(context.Background(), sut)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("spec.Execute(...) = %v, want nil", err)
+	}
+	want := []pod{{Cluster: "prod", Name: "web-prod"}, {Cluster: "dev", Name: "web-dev"}}
+	if got := builder.runs; !cmp.Equal(got, want) {
+		t.Errorf("runs = %+v, want %+v", got, want)
+	}
+	if got, want := builder.computed.Load(), int32(2); got != want {
+		t.Errorf("pods computed %d times, want %d", got, want)
+	}
+}
+
+func TestShell_RenderHTML_LastsOneLine(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", dir)
+	t.Setenv("HOME", dir)
+	var stdout strings.Builder
+	sut := build(t, shellSpec, spec.Options{
+		Builders: map[string]spec.Builder{"app.greet": &greetBuilder{}},
+		Shell:    true,
+		Stdin:    strings.NewReader("greet --render-html --help\ngreet --help\n"),
+		Stdout:   &stdout,
+		Stderr:   io.Discard,
+		Console:  termtest.ErrEchoDisabler(term.ErrNotDescriptor),
+		Colour:   spec.ColourEnabled,
+	})
+	sut.SetArgs([]string{spec.ShellCommand})
+
+	// Act
+	err := spec.Execute(context.Background(), sut)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("spec.Execute(...) = %v, want nil", err)
+	}
+	lines := strings.Split(stdout.String(), "app> ")
+	if got, want := len(lines), 4; got != want {
+		t.Fatalf("stdout = %q, want %d prompts", stdout.String(), want-1)
+	}
+	html, ansi := lines[1], lines[2]
+	if got, want := strings.Contains(html, "<span style="), true; got != want {
+		t.Errorf("first line styled as HTML = %t, want %t", got, want)
+	}
+	if got, want := strings.Contains(ansi, "<span style="), false; got != want {
+		t.Errorf("second line styled as HTML = %t, want %t", got, want)
+	}
+	if got, want := strings.Contains(ansi, "\x1b["), true; got != want {
+		t.Errorf("second line styled with escapes = %t, want %t", got, want)
+	}
+}
@@ -29,6 +29,11 @@ type Application struct {
 	// the source name. Each value is decoded into the update provider registered
 	// under that name.
 	UpdateSources map[string]yaml.Node `yaml:"update-sources"`
+
+	// Theme overrides the styles of the theme the application's output is
+	// rendered with, as a mapping of theme name to style in the form read by
+	// [github.com/bitwizeshift/go-cli/richtext.ParseTheme].
+	Theme yaml.Node `yaml:"theme,omitempty"`
 }
 
 // resolveAppID returns the effective application id used to scope storage on
@@ -55,6 +60,16 @@ type CommandInfo struct {
 	Hidden      bool     `yaml:"hidden,omitempty"`
 	Deprecated  string   `yaml:"deprecated,omitempty"`
 
+	// Usage, when set, replaces the synopsis derived from the command's
+	// registered arguments. It follows the command's name in usage output.
+	Usage string `yaml:"usage,omitempty"`
+
+	// Interspersed selects whether flags may follow the command's positional
+	// arguments. When false, flag parsing stops at the first positional, so the
+	// flags of a wrapped command reach it without a "--". A nil value permits
+	// interspersed flags.
+	Interspersed *bool `yaml:"interspersed,omitempty"`
+
 	Commands GroupCommands `yaml:"commands"`
 }
 
@@ -0,0 +1,65 @@
+package spec
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/bitwizeshift/go-cli/internal/storage"
+	"github.com/bitwizeshift/go-cli/internal/template/tag"
+	"github.com/bitwizeshift/go-cli/richtext"
+	"go.yaml.in/yaml/v4"
+)
+
+// ThemeFile is the name of the file in the application's configuration storage
+// that overrides the styles of its theme, in the form read by
+// [richtext.ParseTheme].
+const ThemeFile = "theme.yaml"
+
+// resolveTheme returns the theme the application is styled with: base, or
+// [richtext.DefaultTheme] when base is nil, overridden by the spec's theme
+// section. It returns an error when the section cannot be parsed.
+func (a *Application) resolveTheme(base *richtext.Theme) (*richtext.Theme, error) {
+	if base == nil {
+		base = richtext.DefaultTheme
+	}
+	if a.Theme.IsZero() {
+		return base, nil
+	}
+	data, err := yaml.Marshal(&a.Theme)
+	if err != nil {
+		return nil, err
+	}
+	return base.Parse(bytes.NewReader(data))
+}
+
+// userTheme returns base overridden by the user's [ThemeFile] in config. It
+// returns base alone when the file does not exist or config cannot be read,
+// and base alone with an error when the file cannot be parsed.
+func userTheme(config *storage.Storage, base *richtext.Theme) (*richtext.Theme, error) {
+	data, err := config.ReadFile(ThemeFile)
+	if err != nil {
+		return base, nil
+	}
+	theme, err := base.Parse(bytes.NewReader(data))
+	if err != nil {
+		return base, fmt.Errorf("%s: %w", themePath(config), err)
+	}
+	return theme, nil
+}
+
+// themePath returns the path of the user's [ThemeFile] in config, for
+// reporting.
+func themePath(config *storage.Storage) string {
+	if path, err := config.Path(ThemeFile); err == nil {
+		return path
+	}
+	return ThemeFile
+}
+
+// warnTheme reports to w that the user's theme override was ignored because
+// of err.
+func warnTheme(w io.Writer, err error) {
+	_, _ = fmt.Fprintf(w, "%s ignoring theme override: %s\n",
+		tag.Themed("warning", "warning:"), tag.Raw(err.Error()))
+}
@@ -0,0 +1,137 @@
+package spec_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bitwizeshift/go-cli/internal/spec"
+	"github.com/bitwizeshift/go-cli/internal/spec/spectest"
+	"github.com/bitwizeshift/go-cli/richtext"
+	"github.com/bitwizeshift/go-cli/richtext/style"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestBuild_Theme(t *testing.T) {
+	const (
+		yellowHeading  = "\x1b[33mUSAGE"
+		magentaHeading = "\x1b[35mUSAGE"
+		redHeading     = "\x1b[31mUSAGE"
+		blueHeading    = "\x1b[34mUSAGE"
+	)
+
+	testCases := []struct {
+		name        string
+		lines       []string
+		background  richtext.Background
+		userTheme   string
+		wantHeading string
+		wantWarning bool
+	}{
+		{
+			name:        "DefaultDark",
+			lines:       []string{"name: root"},
+			background:  richtext.DarkBackground,
+			wantHeading: yellowHeading,
+		}, {
+			name:        "DefaultLight",
+			lines:       []string{"name: root"},
+			background:  richtext.LightBackground,
+			wantHeading: magentaHeading,
+		}, {
+			name:        "SpecOverridesDefault",
+			lines:       []string{"name: root", "theme:", "  heading: fg:red"},
+			wantHeading: redHeading,
+		}, {
+			name:        "UserFileOverridesSpec",
+			lines:       []string{"name: root", "theme:", "  heading: fg:red"},
+			userTheme:   "heading: fg:blue\n",
+			wantHeading: blueHeading,
+		}, {
+			name:        "UserFileFallsBackToSpec",
+			lines:       []string{"name: root", "theme:", "  heading: fg:red"},
+			userTheme:   "title: fg:blue\n",
+			wantHeading: redHeading,
+		}, {
+			name:        "MalformedUserFileIgnored",
+			lines:       []string{"name: root", "theme:", "  heading: fg:red"},
+			userTheme:   "heading: fg:octarine\n",
+			wantHeading: redHeading,
+			wantWarning: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			dir := t.TempDir()
+			t.Setenv("XDG_CONFIG_HOME", dir)
+			t.Setenv("HOME", dir)
+			t.Setenv("AppData", dir)
+			if tc.userTheme != "" {
+				writeUserTheme(t, "root", tc.userTheme)
+			}
+			var stdout, stderr bytes.Buffer
+			sut := build(t, strings.Join(tc.lines, "\n")+"\n", spec.Options{
+				Builders: toBuilders(map[string]spec.Runner{
+					"root": spectest.NoOpRunner(),
+				}),
+				Colour:     spec.ColourEnabled,
+				Background: tc.background,
+				Stdout:     &stdout,
+				Stderr:     &stderr,
+			})
+
+			// Act
+			err := sut.Help()
+
+			// Assert
+			if got, want := err, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("Help() = %v, want nil", err)
+			}
+			if got, want := strings.Contains(stdout.String(), tc.wantHeading), true; got != want {
+				t.Errorf("Help() output = %q, want it to contain %q", stdout.String(), tc.wantHeading)
+			}
+			if got, want := strings.Contains(stderr.String(), "ignoring theme override"), tc.wantWarning; got != want {
+				t.Errorf("Build() warned = %t, want %t (stderr %q)", got, want, stderr.String())
+			}
+		})
+	}
+}
+
+func TestBuild_InvalidTheme_ReturnsError(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	input := "name: root\ntheme:\n  heading: fg:octarine\n"
+
+	// Act
+	_, err := spec.Build(strings.NewReader(input), spec.Options{})
+
+	// Assert
+	if got, want := err, style.ErrUnknownColour; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Errorf("Build() = %v, want %v", got, want)
+	}
+}
+
+// writeUserTheme writes content as the user's theme override for the
+// application appID, in the configuration directory of the current
+// environment.
+func writeUserTheme(t *testing.T, appID, content string) {
+	t.Helper()
+
+	config, err := os.UserConfigDir()
+	if err != nil {
+		t.Fatalf("os.UserConfigDir() = %v", err)
+	}
+	dir := filepath.Join(config, appID)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		t.Fatalf("os.MkdirAll() = %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, spec.ThemeFile), []byte(content), 0o600); err != nil {
+		t.Fatalf("os.WriteFile() = %v", err)
+	}
+}
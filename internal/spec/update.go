@@ -99,5 +99,6 @@ func updateNotice(ctx context.Context, checker *updatecheck.Checker) *help.Notic
 	return &help.Notice{
 		Current: result.Current,
 		Latest:  result.Latest,
+		URL:     result.URL,
 	}
 }
@@ -82,5 +82,6 @@ func (e errFS) Stat(string) (fs.FileInfo, error)         { return nil, e.err }
 func (e errFS) ReadDir(string) ([]fs.DirEntry, error)    { return nil, e.err }
 func (e errFS) Remove(string) error                      { return e.err }
 func (e errFS) RemoveAll(string) error                   { return e.err }
+func (e errFS) Rename(string, string) error              { return e.err }
 
 var _ FS = errFS{}
@@ -46,4 +46,9 @@ func (osFS) RemoveAll(name string) error {
 	return os.RemoveAll(name)
 }
 
+// Rename moves oldname to newname, replacing any file at newname.
+func (osFS) Rename(oldname, newname string) error {
+	return os.Rename(oldname, newname)
+}
+
 var _ FS = osFS{}
@@ -33,6 +33,11 @@ type FS interface {
 
 	// RemoveAll removes name and any children it contains.
 	RemoveAll(name string) error
+
+	// Rename moves oldname to newname, replacing any file already present at
+	// newname. The replacement is atomic where the host supports it, so a
+	// concurrent reader observes either the old contents or the new.
+	Rename(oldname, newname string) error
 }
 
 // Storage is a writable filesystem root scoped beneath a single base directory.
@@ -140,6 +145,25 @@ func (s *Storage) RemoveAll(name string) error {
 	return s.backend.RemoveAll(path)
 }
 
+// Rename moves oldname to newname, creating the parent directories of newname
+// as needed and replacing any file already present there. Writing to a
+// temporary name and renaming it into place publishes a file atomically, so a
+// concurrent reader never observes a partial write.
+func (s *Storage) Rename(oldname, newname string) error {
+	oldpath, err := s.resolve("rename", oldname)
+	if err != nil {
+		return err
+	}
+	newpath, err := s.resolve("rename", newname)
+	if err != nil {
+		return err
+	}
+	if err := s.backend.MkdirAll(filepath.Dir(newpath)); err != nil {
+		return err
+	}
+	return s.backend.Rename(oldpath, newpath)
+}
+
 // Sub returns a [Storage] rooted at dir beneath s, sharing the same backend.
 func (s *Storage) Sub(dir string) (*Storage, error) {
 	path, err := s.resolve("sub", dir)
@@ -149,6 +173,17 @@ func (s *Storage) Sub(dir string) (*Storage, error) {
 	return New(path, s.backend), nil
 }
 
+// Path returns the host filesystem path of name beneath the root, for handing
+// to another process. It returns an [io/fs.PathError] wrapping
+// [errors.ErrUnsupported] when s is not backed by the host filesystem, and one
+// wrapping [io/fs.ErrInvalid] when name is not a valid path.
+func (s *Storage) Path(name string) (string, error) {
+	if _, ok := s.backend.(osFS); !ok {
+		return "", &fs.PathError{Op: "path", Path: name, Err: errors.ErrUnsupported}
+	}
+	return s.resolve("path", name)
+}
+
 // resolve validates name and joins it onto the root, returning an
 // [io/fs.PathError] wrapping [io/fs.ErrInvalid] when name is not a valid,
 // non-escaping path.
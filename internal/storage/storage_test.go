@@ -450,6 +450,72 @@ func TestStorage_RemoveAll_InvalidPath(t *testing.T) {
 	}
 }
 
+func TestStorage_Rename(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		oldname string
+		newname string
+		wantErr error
+	}{
+		{
+			name:    "ReplacesExistingFile",
+			oldname: "settings.json.tmp",
+			newname: "settings.json",
+		},
+		{
+			name:    "CreatesParents",
+			oldname: "settings.json.tmp",
+			newname: "profiles/default/settings.json",
+		},
+		{
+			name:    "MissingSource",
+			oldname: "absent.tmp",
+			newname: "settings.json",
+			wantErr: fs.ErrNotExist,
+		},
+		{
+			name:    "InvalidPath",
+			oldname: "settings.json.tmp",
+			newname: "../escape",
+			wantErr: fs.ErrInvalid,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			sut := storagetest.New("root")
+			write(t, sut, "settings.json", "old")
+			write(t, sut, "settings.json.tmp", "new")
+
+			// Act
+			err := sut.Rename(tc.oldname, tc.newname)
+
+			// Assert
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("Rename(%q, %q) = %v, want %v", tc.oldname, tc.newname, got, want)
+			}
+			if tc.wantErr != nil {
+				return
+			}
+			data, err := sut.ReadFile(tc.newname)
+			if err != nil {
+				t.Fatalf("ReadFile(%q) = %v, want nil", tc.newname, err)
+			}
+			if got, want := string(data), "new"; got != want {
+				t.Errorf("ReadFile(%q) = %q, want %q", tc.newname, got, want)
+			}
+			if _, err := sut.Stat(tc.oldname); !errors.Is(err, fs.ErrNotExist) {
+				t.Errorf("Stat(%q) after Rename = %v, want %v", tc.oldname, err, fs.ErrNotExist)
+			}
+		})
+	}
+}
+
 func TestStorage_ErrorBackend(t *testing.T) {
 	t.Parallel()
 
@@ -512,3 +578,21 @@ func TestStorage_Sub_InvalidPath(t *testing.T) {
 		t.Errorf("Sub(...) storage = %v, want %v", got, want)
 	}
 }
+
+func TestStorage_Path_NotHostFilesystem(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	sut := storagetest.New("root")
+
+	// Act
+	path, err := sut.Path("file.txt")
+
+	// Assert
+	if got, want := err, errors.ErrUnsupported; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Fatalf("Path(...) = %v, want %v", got, want)
+	}
+	if got, want := path, ""; got != want {
+		t.Errorf("Path(...) path = %q, want %q", got, want)
+	}
+}
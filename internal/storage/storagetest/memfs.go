@@ -156,6 +156,23 @@ func (m *memFS) RemoveAll(name string) error {
 	return nil
 }
 
+// Rename moves the file oldname to newname, replacing any file at newname and
+// marking the parent directories of newname.
+func (m *memFS) Rename(oldname, newname string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	oldClean, newClean := filepath.Clean(oldname), filepath.Clean(newname)
+	data, ok := m.files[oldClean]
+	if !ok {
+		return &fs.PathError{Op: "rename", Path: oldname, Err: fs.ErrNotExist}
+	}
+	delete(m.files, oldClean)
+	m.files[newClean] = data
+	m.markDirs(filepath.Dir(newClean))
+	return nil
+}
+
 // markDirs records dir and each of its ancestors as directories.
 func (m *memFS) markDirs(dir string) {
 	for dir != "." && dir != string(filepath.Separator) && dir != "" {
@@ -15,6 +15,7 @@ import (
 	"github.com/bitwizeshift/go-cli/internal/template/usage"
 	"github.com/bitwizeshift/go-cli/internal/template/version"
 	"github.com/bitwizeshift/go-cli/internal/term"
+	"github.com/bitwizeshift/go-cli/richtext/layout"
 	"github.com/spf13/cobra"
 )
 
@@ -32,9 +33,10 @@ var DefaultRenderEngine = RenderEngine{
 	Sizer: term.DefaultSizer,
 }
 
-// HelpRenderer returns the help renderer, sized for the terminal behind w.
+// HelpRenderer returns the help renderer, sized for the terminal behind w and
+// drawing with the characters w can show.
 func (re RenderEngine) HelpRenderer(w io.Writer) *help.Renderer {
-	return &help.Renderer{Columns: re.Sizer.Columns(baseWriter(w))}
+	return &help.Renderer{Columns: re.Sizer.Columns(baseWriter(w)), Charset: layout.CharsetFor(w)}
 }
 
 // HelpFunc returns a cobra func that can be installed with
@@ -47,16 +49,17 @@ func (re RenderEngine) HelpFunc(cl *arg.CommandLine) func(cmd *cobra.Command, _
 	}
 }
 
-// UsageRenderer returns the usage renderer.
-func (re RenderEngine) UsageRenderer() *usage.Renderer {
-	return &usage.Renderer{}
+// UsageRenderer returns the usage renderer, sized for the terminal behind w.
+func (re RenderEngine) UsageRenderer(w io.Writer) *usage.Renderer {
+	return &usage.Renderer{Columns: re.Sizer.Columns(baseWriter(w))}
 }
 
 // UsageFunc returns a cobra func that can be installed with
 // cobra.Command.SetUsageFunc
 func (re RenderEngine) UsageFunc() func(cmd *cobra.Command) error {
 	return func(cmd *cobra.Command) error {
-		return re.UsageRenderer().Render(cmd.ErrOrStderr(), cmd)
+		stderr := cmd.ErrOrStderr()
+		return re.UsageRenderer(stderr).Render(stderr, cmd)
 	}
 }
 
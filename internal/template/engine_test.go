@@ -207,7 +207,7 @@ func TestRenderEngine_VersionFuncs_Keys(t *testing.T) {
 
 	// Arrange
 	sut := template.RenderEngine{Sizer: term.FixedSizer(80)}
-	modules := []string{"build", "text"}
+	modules := []string{"build", "markup", "text"}
 
 	// Act
 	funcs := sut.VersionFuncs()
@@ -3,12 +3,12 @@ package help
 import (
 	"strings"
 	"text/template"
-	"unicode/utf8"
 
 	"github.com/bitwizeshift/go-cli/internal/format"
 	"github.com/bitwizeshift/go-cli/internal/template/tag"
 	"github.com/bitwizeshift/go-cli/internal/template/tmplfuncs"
 	"github.com/bitwizeshift/go-cli/richtext"
+	"github.com/bitwizeshift/go-cli/richtext/layout"
 )
 
 // Layout constants shared by the help sections.
@@ -37,7 +37,7 @@ func (s *span) addPlain(text string) {
 // description, sizing the marker column from its visible width.
 func (s *span) row(description string) format.Row {
 	marker := s.b.String()
-	return format.Row{Marker: marker, MarkerWidth: richtext.Len(marker), Description: description}
+	return format.Row{Marker: marker, MarkerWidth: richtext.Width(marker), Description: description}
 }
 
 // maxCommandColumn caps the shared command name column so that a very long name
@@ -50,7 +50,7 @@ func commandColumnWidth(groups []CommandGroup) int {
 	longest := 0
 	for _, group := range groups {
 		for _, c := range group.Commands {
-			longest = max(longest, utf8.RuneCountInString(c.Name))
+			longest = max(longest, format.Width(c.Name))
 		}
 	}
 	return min(longest, maxCommandColumn)
@@ -105,6 +105,20 @@ func argumentGrid(arguments []ArgumentInfo, columns int) string {
 	return format.Grid(rows, columns, sectionIndent, gridGap, 0)
 }
 
+// constraintList renders each constraint as a bullet wrapped to columns. The
+// descriptions name arguments and values verbatim, so they are passed through
+// without being parsed as styling tags.
+func constraintList(constraints []string, columns int) string {
+	lines := make([]string, 0, len(constraints))
+	for _, c := range constraints {
+		wrapped := format.Resize("- "+c, columns-sectionIndent)
+		for line := range strings.SplitSeq(wrapped, "\n") {
+			lines = append(lines, strings.Repeat(" ", sectionIndent)+tag.Raw(line))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
 // flagMarker renders the name column of a flag, aligning long-only flags beneath
 // the position occupied by a shorthand.
 func flagMarker(f FlagInfo) string {
@@ -127,10 +141,25 @@ func hintLine(path string, columns int) string {
 	return strings.Replace(wrapped, phrase, tag.Themed("emphasis", phrase), 1)
 }
 
-// funcs builds the template function map for rendering view at the given width.
-// It extends the shared [tmplfuncs.NewFunc] set with the help grid and hint
-// layout functions.
-func funcs(columns int, view View) template.FuncMap {
+// noticePanel renders the update advisory n in a panel drawn with cs, sized to
+// its text within columns. The versions come from the release source, so they
+// are escaped. The release page is given a line of its own, with its URL as its
+// text, so that a writer spelling out links never widens a line past the
+// border.
+func noticePanel(n *Notice, columns int, cs *layout.Charset) string {
+	body := tag.Themed("emphasis", "A new version is available:") + " " +
+		tag.Themed("label", richtext.Escape(n.Current)) + " " + cs.Arrow + " " +
+		tag.Themed("label", richtext.Escape(n.Latest))
+	if n.URL != "" {
+		body += "\n[link:" + n.URL + "]" + tag.Themed("url", richtext.Escape(n.URL)) + "[/link]"
+	}
+	return layout.Panel{Body: body}.Render(columns, cs)
+}
+
+// funcs builds the template function map for rendering view at the given width,
+// drawing with cs. It extends the shared [tmplfuncs.NewFunc] set with the help
+// grid, hint, and notice layout functions.
+func funcs(columns int, cs *layout.Charset, view View) template.FuncMap {
 	commandWidth := commandColumnWidth(view.CommandGroups)
 	f := tmplfuncs.NewFunc()
 	f["commandGrid"] = func(commands []Command) string {
@@ -140,6 +169,10 @@ func funcs(columns int, view View) template.FuncMap {
 	f["argumentGrid"] = func(arguments []ArgumentInfo) string {
 		return argumentGrid(arguments, columns)
 	}
+	f["constraintList"] = func(constraints []string) string {
+		return constraintList(constraints, columns)
+	}
 	f["hint"] = func(path string) string { return hintLine(path, columns) }
+	f["notice"] = func(n *Notice) string { return noticePanel(n, columns, cs) }
 	return f
 }
@@ -27,11 +27,11 @@ type Case struct {
 }
 
 // Cases returns the golden scenarios shared by the generator and the golden
-// test: the root command and the flag-rich subcommand, each at 60, 80, and 100
-// columns.
+// test: the root command, the flag-rich subcommand, and the wide-character
+// command, each at 60, 80, and 100 columns.
 func Cases() []Case {
 	widths := []int{60, 80, 100}
-	cases := make([]Case, 0, 2*len(widths))
+	cases := make([]Case, 0, 3*len(widths))
 	for _, width := range widths {
 		cases = append(cases, Case{
 			Name:    fmt.Sprintf("root_%d.golden.txt", width),
@@ -48,6 +48,15 @@ func Cases() []Case {
 			CL:      cl,
 		})
 	}
+	for _, width := range widths {
+		wide, cl := Wide()
+		cases = append(cases, Case{
+			Name:    fmt.Sprintf("wide_%d.golden.txt", width),
+			Columns: width,
+			Command: wide,
+			CL:      cl,
+		})
+	}
 	return cases
 }
 
@@ -147,7 +156,8 @@ example-cli sync origin v1.2.0 --force --timeout 1m`,
 }
 
 // registerArgs registers the sync command's positional and unmatched arguments
-// and its flags, the latter across two named groups. It returns the argument
+// and its flags, the latter across two named groups, along with a conditional
+// constraint between two of the flags. It returns the argument
 // registry so the arguments can be rendered in help.
 func registerArgs(cmd *cobra.Command) *arg.CommandLine {
 	cl := (*arg.CommandLine)(argdef.FromFlagSet(cmd.Flags()))
@@ -183,11 +193,16 @@ func registerArgs(cmd *cobra.Command) *arg.CommandLine {
 		verbose     bool
 	)
 
+	authTokenFlag := arg.Flag("auth-token", &authToken,
+		arg.Shorthand("T"),
+		arg.Usage("auth token used to authenticate with the remote"),
+	)
+	remoteFlag := arg.Flag("remote", &remote,
+		arg.Shorthand("r"),
+		arg.Usage("base URL of the remote to synchronize with"),
+	)
 	addGroup(cl, "Connection Flags",
-		arg.Flag("auth-token", &authToken,
-			arg.Shorthand("T"),
-			arg.Usage("auth token used to authenticate with the remote"),
-		),
+		authTokenFlag,
 		arg.Flag("force", &force,
 			arg.Shorthand("f"),
 			arg.Usage("overwrite any item already present in the vault"),
@@ -196,10 +211,7 @@ func registerArgs(cmd *cobra.Command) *arg.CommandLine {
 			arg.Shorthand("p"),
 			arg.Usage("number of transfers to run at once"),
 		),
-		arg.Flag("remote", &remote,
-			arg.Shorthand("r"),
-			arg.Usage("base URL of the remote to synchronize with"),
-		),
+		remoteFlag,
 		arg.Flag("timeout", &timeout,
 			arg.Shorthand("t"),
 			arg.ValueLabel("duration"),
@@ -224,6 +236,7 @@ func registerArgs(cmd *cobra.Command) *arg.CommandLine {
 			arg.Usage("print additional diagnostic output while running"),
 		),
 	)
+	cl.Add(arg.RequiredIf(arg.IsSet(authTokenFlag), remoteFlag))
 	return cl
 }
 
@@ -237,3 +250,45 @@ func addGroup(cl *arg.CommandLine, name string, flags ...*arg.FlagArg) {
 }
 
 func noop(*cobra.Command, []string) {}
+
+// Wide returns a command whose names, descriptions, and flags mix Japanese text,
+// emoji, and combining marks, so its help is laid out by display width rather
+// than by bytes or runes, alongside the argument registry supplying its flags.
+func Wide() (*cobra.Command, *arg.CommandLine) {
+	root := &cobra.Command{
+		Use:   "nihongo <command>",
+		Short: "日本語のヘルプを表示するサンプル",
+		Long: "nihongo は、全角文字や絵文字を含むヘルプの折り返しと桁揃えを" +
+			"確認するためのサンプルです。説明文は単語の間に空白がなくても、" +
+			"端末の幅に合わせて文字の間で折り返されます。",
+	}
+	root.AddCommand(
+		&cobra.Command{
+			Use:   "同期",
+			Short: "リモートのレジストリと保管庫を同期します",
+			Run:   noop,
+		},
+		&cobra.Command{
+			Use:   "deploy",
+			Short: "🚀 Deploy the cafe\u0301 build, then report 👩‍💻 status to the team",
+			Run:   noop,
+		},
+	)
+	cl := (*arg.CommandLine)(argdef.FromFlagSet(root.Flags()))
+	var (
+		name    string
+		verbose bool
+	)
+	cl.Add(
+		arg.Flag("名前", &name,
+			arg.Shorthand("n"),
+			arg.ValueLabel("名前"),
+			arg.Usage("保管庫に表示する名前"),
+		),
+		arg.Flag("verbose", &verbose,
+			arg.Shorthand("v"),
+			arg.Usage("詳細な診断メッセージを出力します（naïve résumé mode）"),
+		),
+	)
+	return root, cl
+}
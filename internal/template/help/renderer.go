@@ -2,11 +2,13 @@ package help
 
 import (
 	"bytes"
+	"cmp"
 	"io"
 	"strings"
 	"text/template"
 
 	"github.com/bitwizeshift/go-cli/arg"
+	"github.com/bitwizeshift/go-cli/richtext/layout"
 	"github.com/spf13/cobra"
 )
 
@@ -18,8 +20,13 @@ type Renderer struct {
 	// wrap to.
 	Columns int
 
-	// Notice, when non-nil, appends an update advisory to the foot of the output.
+	// Notice, when non-nil, appends an update advisory to the foot of the output,
+	// in a panel.
 	Notice *Notice
+
+	// Charset is the set of characters panels are drawn with, [layout.Unicode]
+	// when nil.
+	Charset *layout.Charset
 }
 
 // Render writes the formatted help for cmd to w, listing the positional
@@ -32,7 +39,7 @@ func (r Renderer) Render(w io.Writer, cmd *cobra.Command, cl *arg.CommandLine) e
 		Notice  *Notice
 	}{View: view, Columns: r.Columns, Notice: r.Notice}
 	tmpl := template.Must(template.New("help").
-		Funcs(funcs(r.Columns, view)).
+		Funcs(funcs(r.Columns, cmp.Or(r.Charset, layout.Unicode), view)).
 		ParseFS(templateFS, "templates/*.tmpl"))
 
 	// A static template over a well-formed [View] cannot fail to execute into an
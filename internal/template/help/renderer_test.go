@@ -5,8 +5,10 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/bitwizeshift/go-cli/internal/argdef"
 	"github.com/bitwizeshift/go-cli/internal/template/help"
 	"github.com/bitwizeshift/go-cli/internal/template/plain"
+	"github.com/bitwizeshift/go-cli/richtext/layout"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/spf13/cobra"
@@ -16,18 +18,49 @@ func TestRenderer_Render_Notice(t *testing.T) {
 	t.Parallel()
 
 	testCases := []struct {
-		name   string
-		notice *help.Notice
-		want   string
+		name    string
+		notice  *help.Notice
+		charset *layout.Charset
+		want    []string
 	}{
 		{
 			name:   "WithNotice",
 			notice: &help.Notice{Current: "v1.0.0", Latest: "v2.0.0"},
-			want:   "A new version is available: v1.0.0 → v2.0.0",
+			want: []string{
+				"╭─────────────────────────────────────────────╮",
+				"│ A new version is available: v1.0.0 → v2.0.0 │",
+				"╰─────────────────────────────────────────────╯",
+			},
+		}, {
+			name:   "WithReleasePage",
+			notice: &help.Notice{Current: "v1.0.0", Latest: "v2.0.0", URL: "https://example.com/v2"},
+			want: []string{
+				"╭─────────────────────────────────────────────╮",
+				"│ A new version is available: v1.0.0 → v2.0.0 │",
+				"│ https://example.com/v2                      │",
+				"╰─────────────────────────────────────────────╯",
+			},
+		}, {
+			name:    "ASCII",
+			notice:  &help.Notice{Current: "v1.0.0", Latest: "v2.0.0"},
+			charset: layout.ASCII,
+			want: []string{
+				"+----------------------------------------------+",
+				"| A new version is available: v1.0.0 -> v2.0.0 |",
+				"+----------------------------------------------+",
+			},
+		}, {
+			name:   "VersionsEscaped",
+			notice: &help.Notice{Current: "[fg:red]", Latest: "v2"},
+			want: []string{
+				"╭───────────────────────────────────────────╮",
+				"│ A new version is available: [fg:red] → v2 │",
+				"╰───────────────────────────────────────────╯",
+			},
 		}, {
 			name:   "WithoutNotice",
 			notice: nil,
-			want:   "",
+			want:   nil,
 		},
 	}
 
@@ -36,7 +69,7 @@ func TestRenderer_Render_Notice(t *testing.T) {
 			t.Parallel()
 
 			// Arrange
-			sut := help.Renderer{Columns: 80, Notice: tc.notice}
+			sut := help.Renderer{Columns: 80, Notice: tc.notice, Charset: tc.charset}
 			command := &cobra.Command{Use: "app"}
 			var buf bytes.Buffer
 
@@ -51,19 +84,79 @@ func TestRenderer_Render_Notice(t *testing.T) {
 			if got, want := stripErr, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
 				t.Fatalf("plain.Render() = %v, want %v", got, want)
 			}
-			if got, want := noticeLine(rendered), tc.want; !cmp.Equal(got, want) {
+			if got, want := noticeLines(rendered), tc.want; !cmp.Equal(got, want) {
 				t.Errorf("Renderer.Render() advisory = %q, want %q", got, want)
 			}
 		})
 	}
 }
 
-// noticeLine returns the trimmed advisory line from rendered help output, or the
-// empty string when no advisory is present.
-func noticeLine(rendered string) string {
-	for line := range strings.SplitSeq(rendered, "\n") {
-		if strings.Contains(line, "A new version is available:") {
-			return strings.TrimSpace(line)
+// noticeLines returns the lines of the advisory panel at the foot of rendered
+// help output, or nil when no advisory is present.
+func noticeLines(rendered string) []string {
+	lines := strings.Split(strings.TrimRight(rendered, "\n"), "\n")
+	for i, line := range lines {
+		if strings.Contains(line, "A new version is available:") && i > 0 {
+			return lines[i-1:]
+		}
+	}
+	return nil
+}
+
+func TestRenderer_Render_CompactUsage(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		columns int
+		want    string
+	}{
+		{
+			name:    "FullSynopsisFits",
+			columns: 80,
+			want:    "app [--format <string>] [--verbose]",
+		}, {
+			name:    "NarrowTerminalFallsBack",
+			columns: 20,
+			want:    "app [flags]",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			sut := help.Renderer{Columns: tc.columns}
+			command := &cobra.Command{Use: "app [--format <string>] [--verbose]"}
+			argdef.SetCompactUsage(command, "app [flags]")
+			var buf bytes.Buffer
+
+			// Act
+			renderErr := sut.Render(&buf, command, nil)
+			rendered, stripErr := plain.Render(buf.String())
+
+			// Assert
+			if got, want := renderErr, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("Renderer.Render() = %v, want %v", got, want)
+			}
+			if got, want := stripErr, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("plain.Render() = %v, want %v", got, want)
+			}
+			if got, want := usageLine(rendered), tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Renderer.Render() usage = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+// usageLine returns the trimmed line following the USAGE heading in rendered
+// help output, or the empty string when no usage is present.
+func usageLine(rendered string) string {
+	lines := strings.Split(rendered, "\n")
+	for i, line := range lines[:len(lines)-1] {
+		if line == "USAGE" {
+			return strings.TrimSpace(lines[i+1])
 		}
 	}
 	return ""
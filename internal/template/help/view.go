@@ -12,18 +12,21 @@ import (
 // to any named cobra group.
 const additionalCommands = "Additional Commands"
 
-// View is the resolved help model for a command: its title, description, usage,
-// examples, grouped subcommands and flags, and trailing advice. It is what the
-// help templates render, and it is exported so its derivation can be tested
+// View is the resolved help model for a command: its title, description, usage
+// and the compact usage it falls back to on a narrow terminal, examples,
+// grouped subcommands and flags, and trailing advice. It is what the help
+// templates render, and it is exported so its derivation can be tested
 // directly.
 type View struct {
 	Name          string
 	Description   string
 	Usage         string
+	CompactUsage  string
 	Examples      []string
 	CommandGroups []CommandGroup
 	Arguments     []ArgumentInfo
 	FlagGroups    []FlagGroup
+	Constraints   []string
 	Hint          Hint
 }
 
@@ -73,10 +76,11 @@ type Hint struct {
 
 // Notice models the trailing advisory shown when a newer release of the
 // application is available. Current and Latest are the running and available
-// versions.
+// versions, and URL, when set, is the page describing the Latest release.
 type Notice struct {
 	Current string
 	Latest  string
+	URL     string
 }
 
 // NewView builds the help [View] for cmd. cl supplies the command's
@@ -88,11 +92,13 @@ func NewView(cmd *cobra.Command, cl *arg.CommandLine) View {
 	return View{
 		Name:          cmd.CommandPath(),
 		Description:   descriptionOf(cmd),
-		Usage:         usageLineOf(cmd),
+		Usage:         usageLineOf(cmd, cmd.Use),
+		CompactUsage:  usageLineOf(cmd, argdef.CompactUsage(cmd)),
 		Examples:      examplesOf(cmd),
 		CommandGroups: commandGroupsOf(cmd),
 		Arguments:     argumentsOf(cl),
 		FlagGroups:    flagGroupsOf(cl),
+		Constraints:   constraintsOf(cl),
 		Hint:          hintOf(cmd),
 	}
 }
@@ -106,13 +112,13 @@ func descriptionOf(cmd *cobra.Command) string {
 	return cmd.Short
 }
 
-// usageLineOf returns the usage line for cmd, qualified by the path of the
-// command it is reached through.
-func usageLineOf(cmd *cobra.Command) string {
-	if cmd.HasParent() {
-		return cmd.Parent().CommandPath() + " " + cmd.Use
+// usageLineOf returns use, a usage line for cmd, qualified by the path of the
+// command it is reached through. An empty use stays empty.
+func usageLineOf(cmd *cobra.Command, use string) string {
+	if use != "" && cmd.HasParent() {
+		return cmd.Parent().CommandPath() + " " + use
 	}
-	return cmd.Use
+	return use
 }
 
 // examplesOf returns the non-blank example lines of cmd, in order.
@@ -167,9 +173,9 @@ func visibleCommand(c *cobra.Command) bool {
 }
 
 // argumentsOf returns the arguments registered on cl: the positionals in
-// registration order, followed by the unmatched-argument binding when one is
-// registered. The unmatched entry is variadic, since it claims every remaining
-// argument rather than a single slot.
+// registration order, followed by the unmatched-argument and passthrough
+// bindings when they are registered. Those entries are variadic, since they
+// claim every remaining argument rather than a single slot.
 func argumentsOf(cl *arg.CommandLine) []ArgumentInfo {
 	var arguments []ArgumentInfo
 	for _, p := range argdef.Positionals((*argdef.CommandLine)(cl)) {
@@ -187,6 +193,14 @@ func argumentsOf(cl *arg.CommandLine) []ArgumentInfo {
 			Variadic: true,
 		})
 	}
+	if p := argdef.GetPassthrough((*argdef.CommandLine)(cl)); p != nil {
+		arguments = append(arguments, ArgumentInfo{
+			Name:     p.Name,
+			Usage:    p.Usage,
+			Required: p.Required,
+			Variadic: true,
+		})
+	}
 	return arguments
 }
 
@@ -231,6 +245,19 @@ func flagTypeOf(f *arg.FlagArg) string {
 	return ""
 }
 
+// constraintsOf describes the conditional constraints registered on cl, in
+// registration order. Constraints that refer to a hidden flag are omitted.
+func constraintsOf(cl *arg.CommandLine) []string {
+	var constraints []string
+	for _, c := range argdef.Constraints((*argdef.CommandLine)(cl)) {
+		if c.Hidden() {
+			continue
+		}
+		constraints = append(constraints, c.String())
+	}
+	return constraints
+}
+
 // hintOf returns the trailing help advice for cmd, shown only when cmd has
 // visible subcommands.
 func hintOf(cmd *cobra.Command) Hint {
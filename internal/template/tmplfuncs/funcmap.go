@@ -9,7 +9,8 @@ import (
 // such as {{ build.VCS }} and {{ text.Wrap … }}.
 func NewFunc() template.FuncMap {
 	return template.FuncMap{
-		"build": func() *Build { return &DefaultBuild },
-		"text":  func() Text { return Text{} },
+		"build":  func() *Build { return &DefaultBuild },
+		"markup": func() Markup { return Markup{} },
+		"text":   func() Text { return Text{} },
 	}
 }
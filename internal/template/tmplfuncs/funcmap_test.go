@@ -22,7 +22,7 @@ func TestNewFunc_Keys(t *testing.T) {
 	sort.Strings(keys)
 
 	// Assert
-	if got, want := keys, []string{"build", "text"}; !cmp.Equal(got, want) {
+	if got, want := keys, []string{"build", "markup", "text"}; !cmp.Equal(got, want) {
 		t.Errorf("NewFunc keys = %v, want %v", got, want)
 	}
 }
@@ -43,6 +43,22 @@ func TestNewFunc_Build_ReturnsDefaultBuild(t *testing.T) {
 	}
 }
 
+func TestNewFunc_Markup_ReturnsMarkup(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	funcs := tmplfuncs.NewFunc()
+	provider := funcs["markup"].(func() tmplfuncs.Markup)
+
+	// Act
+	markup := provider()
+
+	// Assert
+	if got, want := markup, (tmplfuncs.Markup{}); !cmp.Equal(got, want) {
+		t.Errorf("markup() = %v, want %v", got, want)
+	}
+}
+
 func TestNewFunc_Text_ReturnsText(t *testing.T) {
 	t.Parallel()
 
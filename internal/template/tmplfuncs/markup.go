@@ -0,0 +1,15 @@
+package tmplfuncs
+
+import (
+	"github.com/bitwizeshift/go-cli/richtext/markdown"
+)
+
+// Markup exposes helpers that convert other markup languages into richtext
+// markup. Unlike the helpers of [Text], its results carry styling tags.
+type Markup struct{}
+
+// Markdown renders the Markdown in s as richtext markup, wrapped to fit within
+// columns of width per line. A non-positive columns leaves lines unwrapped.
+func (Markup) Markdown(columns int, s string) string {
+	return markdown.Render(s, columns)
+}
@@ -0,0 +1,49 @@
+package tmplfuncs_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/bitwizeshift/go-cli/internal/template/tmplfuncs"
+)
+
+func TestMarkup_Markdown(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		columns int
+		input   string
+		want    string
+	}{
+		{
+			name:    "wraps at word boundary",
+			columns: 7,
+			input:   "one two three",
+			want:    "one two\nthree",
+		}, {
+			name:    "styles emphasis",
+			columns: 80,
+			input:   "one **two**",
+			want:    "one [attr:bold]two[/attr]",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			sut := tmplfuncs.Markup{}
+
+			// Act
+			rendered := sut.Markdown(tc.columns, tc.input)
+
+			// Assert
+			if got, want := rendered, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Markup.Markdown(%d, %q) = %q, want %q", tc.columns, tc.input, got, want)
+			}
+		})
+	}
+}
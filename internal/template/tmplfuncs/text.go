@@ -37,12 +37,22 @@ func (t Text) IndentLines(n int, lines []string) string {
 // already at least that wide. Width is measured on the visible text, so any
 // styling tags in s do not count toward it.
 func (Text) Pad(width int, s string) string {
-	if n := width - richtext.Len(s); n > 0 {
+	if n := width - richtext.Width(s); n > 0 {
 		return s + strings.Repeat(" ", n)
 	}
 	return s
 }
 
+// Fit returns s, or fallback when s indented by indent spaces would be wider
+// than columns and fallback is non-empty. A non-positive columns never falls
+// back. Width is measured on the visible text.
+func (Text) Fit(columns, indent int, s, fallback string) string {
+	if columns <= 0 || fallback == "" || indent+richtext.Width(s) <= columns {
+		return s
+	}
+	return fallback
+}
+
 // Upper returns s with all letters mapped to upper case.
 func (Text) Upper(s string) string {
 	return strings.ToUpper(s)
@@ -54,7 +64,7 @@ func (Text) Upper(s string) string {
 func (Text) MaxWidth(values []string) int {
 	width := 0
 	for _, value := range values {
-		width = max(width, richtext.Len(value))
+		width = max(width, richtext.Width(value))
 	}
 	return width
 }
@@ -123,6 +123,11 @@ func TestText_Pad(t *testing.T) {
 			width: 2,
 			input: "abc",
 			want:  "abc",
+		}, {
+			name:  "wide characters pad by display width",
+			width: 6,
+			input: "[theme:title]日本[/theme]",
+			want:  "[theme:title]日本[/theme]  ",
 		},
 	}
 
@@ -144,6 +149,61 @@ func TestText_Pad(t *testing.T) {
 	}
 }
 
+func TestText_Fit(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		columns  int
+		input    string
+		fallback string
+		want     string
+	}{
+		{
+			name:     "fits within columns",
+			columns:  7,
+			input:    "abcde",
+			fallback: "ab",
+			want:     "abcde",
+		}, {
+			name:     "indent pushes past columns",
+			columns:  6,
+			input:    "abcde",
+			fallback: "ab",
+			want:     "ab",
+		}, {
+			name:     "no fallback keeps input",
+			columns:  3,
+			input:    "abcde",
+			fallback: "",
+			want:     "abcde",
+		}, {
+			name:     "non-positive columns keeps input",
+			columns:  0,
+			input:    "abcde",
+			fallback: "ab",
+			want:     "abcde",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			sut := tmplfuncs.Text{}
+
+			// Act
+			fitted := sut.Fit(tc.columns, 2, tc.input, tc.fallback)
+
+			// Assert
+			if got, want := fitted, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Text.Fit(%d, 2, %q, %q) = %q, want %q", tc.columns, tc.input, tc.fallback, got, want)
+			}
+		})
+	}
+}
+
 func TestText_Upper(t *testing.T) {
 	t.Parallel()
 
@@ -171,6 +231,10 @@ func TestText_MaxWidth(t *testing.T) {
 			name:   "widest string",
 			values: []string{"VCS", "VCS Revision", "Target"},
 			want:   len("VCS Revision"),
+		}, {
+			name:   "wide characters count two columns",
+			values: []string{"sync", "同期する"},
+			want:   8,
 		}, {
 			name:   "empty is zero",
 			values: nil,
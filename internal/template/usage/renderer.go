@@ -6,6 +6,7 @@ import (
 	"strings"
 	"text/template"
 
+	"github.com/bitwizeshift/go-cli/internal/argdef"
 	"github.com/bitwizeshift/go-cli/internal/template/tmplfuncs"
 	"github.com/spf13/cobra"
 )
@@ -15,33 +16,41 @@ import (
 // [cobra.Command] (notably Usage, which executes cobra's default usage func as
 // a side effect).
 type view struct {
-	Name        string
-	Usage       string
-	CommandPath string
+	Name         string
+	Usage        string
+	CompactUsage string
+	CommandPath  string
+	Columns      int
 }
 
-// newView builds the usage [view] for cmd.
-func newView(cmd *cobra.Command) view {
+// newView builds the usage [view] for cmd, fitted to columns.
+func newView(cmd *cobra.Command, columns int) view {
 	return view{
-		Name:        cmd.Name(),
-		Usage:       usageLineOf(cmd),
-		CommandPath: cmd.CommandPath(),
+		Name:         cmd.Name(),
+		Usage:        usageLineOf(cmd, cmd.Use),
+		CompactUsage: usageLineOf(cmd, argdef.CompactUsage(cmd)),
+		CommandPath:  cmd.CommandPath(),
+		Columns:      columns,
 	}
 }
 
-// usageLineOf returns the usage line for cmd, qualified by the path of the
-// command it is reached through.
-func usageLineOf(cmd *cobra.Command) string {
-	if cmd.HasParent() {
-		return cmd.Parent().CommandPath() + " " + cmd.Use
+// usageLineOf returns use, a usage line for cmd, qualified by the path of the
+// command it is reached through. An empty use stays empty.
+func usageLineOf(cmd *cobra.Command, use string) string {
+	if use != "" && cmd.HasParent() {
+		return cmd.Parent().CommandPath() + " " + use
 	}
-	return cmd.Use
+	return use
 }
 
 // Renderer writes the short usage advisory for a [cobra.Command]. The output
 // carries richtext styling tags; a richtext writer decides whether they render
 // as colour.
-type Renderer struct{}
+type Renderer struct {
+	// Columns is the width the usage line must fit within before it falls back
+	// to its compact form. A non-positive value never falls back.
+	Columns int
+}
 
 // Render writes the usage advisory for cmd to w. It reports any error from
 // writing to w.
@@ -54,7 +63,7 @@ func (r Renderer) Render(w io.Writer, cmd *cobra.Command) error {
 	// in-memory buffer, so a failure here is a template bug, handled like
 	// [template.Must]. The only recoverable error is writing to w.
 	var buf bytes.Buffer
-	template.Must(tmpl, tmpl.ExecuteTemplate(&buf, "usage.tmpl", newView(cmd)))
+	template.Must(tmpl, tmpl.ExecuteTemplate(&buf, "usage.tmpl", newView(cmd, r.Columns)))
 
 	body := strings.TrimRight(buf.String(), "\n") + "\n"
 	_, err := io.WriteString(w, body)
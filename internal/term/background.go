@@ -0,0 +1,263 @@
+package term
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// Background is the brightness of a terminal's background colour, which
+// decides the colours that remain legible on it.
+type Background uint8
+
+const (
+	// UnknownBackground is reported when the background could not be
+	// determined. Callers should treat it as [DarkBackground], the more common
+	// default.
+	UnknownBackground Background = iota
+
+	// DarkBackground is a background against which light text is legible.
+	DarkBackground
+
+	// LightBackground is a background against which dark text is legible.
+	LightBackground
+)
+
+// String returns "light", "dark", or "unknown".
+func (b Background) String() string {
+	switch b {
+	case DarkBackground:
+		return "dark"
+	case LightBackground:
+		return "light"
+	default:
+		return "unknown"
+	}
+}
+
+// BackgroundDetector reports the background of the terminal behind a
+// particular writer. A return value of [UnknownBackground] is a signal that
+// callers (or composing detectors) should fall back to another source.
+type BackgroundDetector interface {
+	Background(w io.Writer) Background
+}
+
+// FixedBackground is a BackgroundDetector that always reports the same
+// background. Useful when the caller has already resolved the background from
+// configuration or a user flag.
+type FixedBackground Background
+
+// Background implements [BackgroundDetector].
+func (b FixedBackground) Background(io.Writer) Background {
+	return Background(b)
+}
+
+var _ BackgroundDetector = (*FixedBackground)(nil)
+
+// EnvBackground reads the background from a named environment variable, which
+// may be "light" or "dark" in any case. Any other value, or none, yields
+// [UnknownBackground].
+type EnvBackground struct {
+	Variable string
+}
+
+// Background implements [BackgroundDetector].
+func (e EnvBackground) Background(io.Writer) Background {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(e.Variable))) {
+	case "light":
+		return LightBackground
+	case "dark":
+		return DarkBackground
+	default:
+		return UnknownBackground
+	}
+}
+
+var _ BackgroundDetector = (*EnvBackground)(nil)
+
+// ColorFgBgBackground reads the background from the COLORFGBG environment
+// variable set by rxvt and its descendants, whose last ';'-separated field is
+// the ANSI index of the background colour. White (7) and the bright colours
+// (9-15) are light; black (0), the other normal colours and bright black (8)
+// are dark.
+type ColorFgBgBackground struct{}
+
+// Background implements [BackgroundDetector].
+func (ColorFgBgBackground) Background(io.Writer) Background {
+	fields := strings.Split(os.Getenv("COLORFGBG"), ";")
+	index, err := strconv.Atoi(fields[len(fields)-1])
+	switch {
+	case err != nil, index < 0, index > 15:
+		return UnknownBackground
+	case index == 7, index >= 9:
+		return LightBackground
+	default:
+		return DarkBackground
+	}
+}
+
+var _ BackgroundDetector = (*ColorFgBgBackground)(nil)
+
+// QueryFunc sends query to the terminal behind w and returns the terminal's
+// reply, giving up once timeout has elapsed.
+type QueryFunc func(w io.Writer, query string, timeout time.Duration) ([]byte, error)
+
+// QueryBackground asks the terminal for its background colour with an OSC 11
+// query, and judges the colour it replies with by its luminance. A terminal
+// that does not answer within Timeout, or whose answer cannot be read, yields
+// [UnknownBackground].
+type QueryBackground struct {
+	Query   QueryFunc
+	Timeout time.Duration
+}
+
+// oscBackgroundQuery asks the terminal to report its background colour.
+const oscBackgroundQuery = "\x1b]11;?\x1b\\"
+
+// oscBackgroundReply matches a terminal's reply to [oscBackgroundQuery], which
+// spells the colour as 1-4 hexadecimal digits per component.
+var oscBackgroundReply = regexp.MustCompile(`\x1b\]11;rgb:([0-9a-fA-F]{1,4})/([0-9a-fA-F]{1,4})/([0-9a-fA-F]{1,4})(?:\x07|\x1b\\)`)
+
+// Background implements [BackgroundDetector].
+func (q QueryBackground) Background(w io.Writer) Background {
+	reply, err := q.Query(w, oscBackgroundQuery, q.Timeout)
+	if err != nil {
+		return UnknownBackground
+	}
+	match := oscBackgroundReply.FindSubmatch(reply)
+	if match == nil {
+		return UnknownBackground
+	}
+	var rgb [3]float64
+	for i, component := range match[1:] {
+		value, _ := strconv.ParseUint(string(component), 16, 16) // matched as hex above
+		rgb[i] = float64(value) / float64(uint64(1)<<(4*len(component))-1)
+	}
+	if 0.2126*rgb[0]+0.7152*rgb[1]+0.0722*rgb[2] > 0.5 {
+		return LightBackground
+	}
+	return DarkBackground
+}
+
+var _ BackgroundDetector = (*QueryBackground)(nil)
+
+// primaryAttributesQuery asks the terminal for its primary device attributes.
+// Every terminal answers it, so it is sent after a query the terminal might
+// ignore to mark the end of any reply.
+const primaryAttributesQuery = "\x1b[c"
+
+// primaryAttributesReply matches a terminal's reply to
+// [primaryAttributesQuery].
+var primaryAttributesReply = regexp.MustCompile(`\x1b\[\?[0-9;]*c`)
+
+// QueryTTY is a [QueryFunc] that writes query to the controlling terminal and
+// reads the reply back from it in raw mode. It fails with [ErrNotDescriptor]
+// unless w is itself a terminal, so output redirected elsewhere is never
+// answered on its behalf.
+//
+// The query is followed by a request for the terminal's device attributes, and
+// the reply is read until their answer arrives. A terminal that ignores the
+// query is thereby detected without waiting for the timeout, and no part of a
+// late reply is left behind to be read as input.
+func QueryTTY(w io.Writer, query string, timeout time.Duration) ([]byte, error) {
+	file, ok := w.(interface{ Fd() uintptr })
+	if !ok || !term.IsTerminal(int(file.Fd())) {
+		return nil, fmt.Errorf("query: %w", ErrNotDescriptor)
+	}
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer tty.Close()
+
+	// The descriptor is reached through SyscallConn rather than Fd, which would
+	// put it into blocking mode and so disable the read deadline.
+	conn, err := tty.SyscallConn()
+	if err != nil {
+		return nil, err
+	}
+	var state *term.State
+	if err := conn.Control(func(fd uintptr) { state, err = term.MakeRaw(int(fd)) }); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = conn.Control(func(fd uintptr) { _ = term.Restore(int(fd), state) })
+	}()
+
+	if err := tty.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(tty, query+primaryAttributesQuery); err != nil {
+		return nil, err
+	}
+	var reply bytes.Buffer
+	chunk := make([]byte, 64)
+	for !primaryAttributesReply.Match(reply.Bytes()) {
+		n, err := tty.Read(chunk)
+		reply.Write(chunk[:n])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return reply.Bytes(), nil
+}
+
+// FallbackBackground reports the first known background from its members.
+type FallbackBackground []BackgroundDetector
+
+// Background implements [BackgroundDetector].
+func (f FallbackBackground) Background(w io.Writer) Background {
+	for _, detector := range f {
+		if bg := detector.Background(w); bg != UnknownBackground {
+			return bg
+		}
+	}
+	return UnknownBackground
+}
+
+var _ BackgroundDetector = (*FallbackBackground)(nil)
+
+// CachedBackground remembers the first background its Detector reports, and
+// reports it for every writer thereafter without consulting the Detector again.
+// It suits a detector that asks the one controlling terminal, whatever the
+// writer. An unknown background is remembered too, so a terminal that did not
+// answer is not asked, and waited on, again.
+type CachedBackground struct {
+	Detector BackgroundDetector
+
+	once sync.Once
+	bg   Background
+}
+
+// Background implements [BackgroundDetector].
+func (c *CachedBackground) Background(w io.Writer) Background {
+	c.once.Do(func() {
+		c.bg = c.Detector.Background(w)
+	})
+	return c.bg
+}
+
+var _ BackgroundDetector = (*CachedBackground)(nil)
+
+// DefaultBackgroundDetector is the standard policy: prefer the TERM_BACKGROUND
+// environment variable, then COLORFGBG, and finally ask the terminal itself,
+// waiting at most 100ms for its answer. The terminal's answer is asked for at
+// most once.
+var DefaultBackgroundDetector BackgroundDetector = FallbackBackground{
+	EnvBackground{Variable: "TERM_BACKGROUND"},
+	ColorFgBgBackground{},
+	&CachedBackground{
+		Detector: QueryBackground{Query: QueryTTY, Timeout: 100 * time.Millisecond},
+	},
+}
@@ -0,0 +1,346 @@
+package term_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/bitwizeshift/go-cli/internal/term"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestFixedBackground_Background(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		sut  term.FixedBackground
+		want term.Background
+	}{
+		{
+			name: "Light",
+			sut:  term.FixedBackground(term.LightBackground),
+			want: term.LightBackground,
+		}, {
+			name: "Dark",
+			sut:  term.FixedBackground(term.DarkBackground),
+			want: term.DarkBackground,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			sut := tc.sut
+
+			// Act
+			bg := sut.Background(&bytes.Buffer{})
+
+			// Assert
+			if got, want := bg, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("FixedBackground.Background(...) got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestEnvBackground_Background(t *testing.T) {
+	const varName = "ANSI_TEST_ENV_BACKGROUND_VAR"
+
+	testCases := []struct {
+		name  string
+		set   bool
+		value string
+		want  term.Background
+	}{
+		{
+			name: "NotSet",
+			want: term.UnknownBackground,
+		}, {
+			name:  "Light",
+			set:   true,
+			value: "light",
+			want:  term.LightBackground,
+		}, {
+			name:  "DarkAnyCase",
+			set:   true,
+			value: " Dark ",
+			want:  term.DarkBackground,
+		}, {
+			name:  "Garbage",
+			set:   true,
+			value: "grey",
+			want:  term.UnknownBackground,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			if tc.set {
+				t.Setenv(varName, tc.value)
+			}
+			sut := term.EnvBackground{Variable: varName}
+
+			// Act
+			bg := sut.Background(&bytes.Buffer{})
+
+			// Assert
+			if got, want := bg, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("EnvBackground.Background(...) got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestColorFgBgBackground_Background(t *testing.T) {
+	testCases := []struct {
+		name  string
+		value string
+		want  term.Background
+	}{
+		{
+			name:  "Unset",
+			value: "",
+			want:  term.UnknownBackground,
+		}, {
+			name:  "BlackBackground",
+			value: "15;0",
+			want:  term.DarkBackground,
+		}, {
+			name:  "WhiteBackground",
+			value: "0;15",
+			want:  term.LightBackground,
+		}, {
+			name:  "GreyBackground",
+			value: "0;7",
+			want:  term.LightBackground,
+		}, {
+			name:  "BrightBlackBackground",
+			value: "15;8",
+			want:  term.DarkBackground,
+		}, {
+			name:  "ThreeFields",
+			value: "0;default;15",
+			want:  term.LightBackground,
+		}, {
+			name:  "DefaultBackground",
+			value: "15;default",
+			want:  term.UnknownBackground,
+		}, {
+			name:  "OutOfRange",
+			value: "0;16",
+			want:  term.UnknownBackground,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			t.Setenv("COLORFGBG", tc.value)
+			sut := term.ColorFgBgBackground{}
+
+			// Act
+			bg := sut.Background(&bytes.Buffer{})
+
+			// Assert
+			if got, want := bg, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("ColorFgBgBackground.Background(...) got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestQueryBackground_Background(t *testing.T) {
+	t.Parallel()
+
+	testErr := errors.New("query timed out")
+
+	testCases := []struct {
+		name  string
+		reply string
+		err   error
+		want  term.Background
+	}{
+		{
+			name:  "BlackReply",
+			reply: "\x1b]11;rgb:0000/0000/0000\x1b\\\x1b[?62;c",
+			want:  term.DarkBackground,
+		}, {
+			name:  "WhiteReply",
+			reply: "\x1b]11;rgb:ffff/ffff/ffff\x1b\\\x1b[?62;c",
+			want:  term.LightBackground,
+		}, {
+			name:  "BellTerminatedReply",
+			reply: "\x1b]11;rgb:fd/f6/e3\x07\x1b[?1;2c",
+			want:  term.LightBackground,
+		}, {
+			name:  "SaturatedBlueIsDark",
+			reply: "\x1b]11;rgb:0000/0000/ffff\x1b\\",
+			want:  term.DarkBackground,
+		}, {
+			name:  "QueryIgnored",
+			reply: "\x1b[?62;c",
+			want:  term.UnknownBackground,
+		}, {
+			name: "QueryFails",
+			err:  testErr,
+			want: term.UnknownBackground,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			var query string
+			var timeout time.Duration
+			sut := term.QueryBackground{
+				Query: func(w io.Writer, q string, d time.Duration) ([]byte, error) {
+					query, timeout = q, d
+					return []byte(tc.reply), tc.err
+				},
+				Timeout: time.Second,
+			}
+
+			// Act
+			bg := sut.Background(&bytes.Buffer{})
+
+			// Assert
+			if got, want := bg, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("QueryBackground.Background(...) got %v, want %v", got, want)
+			}
+			if got, want := query, "\x1b]11;?\x1b\\"; !cmp.Equal(got, want) {
+				t.Errorf("QueryBackground.Background(...) queried %q, want %q", got, want)
+			}
+			if got, want := timeout, time.Second; !cmp.Equal(got, want) {
+				t.Errorf("QueryBackground.Background(...) timeout %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestQueryTTY_NotTerminal(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	w := &bytes.Buffer{}
+
+	// Act
+	_, err := term.QueryTTY(w, "\x1b]11;?\x1b\\", time.Millisecond)
+
+	// Assert
+	if got, want := err, term.ErrNotDescriptor; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Errorf("QueryTTY(...) error = %v, want %v", got, want)
+	}
+	if got, want := w.String(), ""; !cmp.Equal(got, want) {
+		t.Errorf("QueryTTY(...) wrote %q, want %q", got, want)
+	}
+}
+
+func TestFallbackBackground_Background(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		detectors []term.Background
+		want      term.Background
+	}{
+		{
+			name:      "Empty",
+			detectors: nil,
+			want:      term.UnknownBackground,
+		}, {
+			name:      "FirstKnownWins",
+			detectors: []term.Background{term.LightBackground, term.DarkBackground},
+			want:      term.LightBackground,
+		}, {
+			name:      "UnknownFallsThrough",
+			detectors: []term.Background{term.UnknownBackground, term.DarkBackground},
+			want:      term.DarkBackground,
+		}, {
+			name:      "AllUnknown",
+			detectors: []term.Background{term.UnknownBackground, term.UnknownBackground},
+			want:      term.UnknownBackground,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			var sut term.FallbackBackground
+			for _, bg := range tc.detectors {
+				sut = append(sut, term.FixedBackground(bg))
+			}
+
+			// Act
+			bg := sut.Background(&bytes.Buffer{})
+
+			// Assert
+			if got, want := bg, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("FallbackBackground.Background(...) got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+// countingBackground reports its background, counting how often it is asked.
+type countingBackground struct {
+	bg    term.Background
+	calls int
+}
+
+func (c *countingBackground) Background(io.Writer) term.Background {
+	c.calls++
+	return c.bg
+}
+
+func TestCachedBackground_Background(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		bg        term.Background
+		wantCalls int
+	}{
+		{
+			name:      "KnownIsRemembered",
+			bg:        term.LightBackground,
+			wantCalls: 1,
+		}, {
+			name:      "UnknownIsRemembered",
+			bg:        term.UnknownBackground,
+			wantCalls: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			detector := &countingBackground{bg: tc.bg}
+			sut := &term.CachedBackground{Detector: detector}
+
+			// Act
+			_ = sut.Background(&bytes.Buffer{})
+			bg := sut.Background(&bytes.Buffer{})
+
+			// Assert
+			if got, want := bg, tc.bg; !cmp.Equal(got, want) {
+				t.Errorf("CachedBackground.Background(...) got %v, want %v", got, want)
+			}
+			if got, want := detector.calls, tc.wantCalls; !cmp.Equal(got, want) {
+				t.Errorf("CachedBackground.Background(...) asked %d times, want %d", got, want)
+			}
+		})
+	}
+}
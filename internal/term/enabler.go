@@ -1,16 +1,47 @@
 package term
 
 import (
+	"cmp"
 	"io"
 	"os"
+	"runtime"
 	"strconv"
+	"strings"
 
 	"golang.org/x/term"
 )
 
-// ColourEnabler decides whether colour should be emitted to a particular writer.
+// ColourDepth is the range of colours a terminal can render. Depths are
+// ordered, so a greater depth renders every colour a lesser one can.
+type ColourDepth uint8
+
+const (
+	// NoColour emits no colour or other styling escapes.
+	NoColour ColourDepth = iota
+
+	// Colour16 renders the sixteen named ANSI colours.
+	Colour16
+
+	// Colour256 renders the 256-colour xterm palette.
+	Colour256
+
+	// TrueColour renders 24-bit colour.
+	TrueColour
+)
+
+// ColourEnabler decides the depth of colour that should be emitted to a
+// particular writer. A depth of [NoColour] disables colour entirely.
 type ColourEnabler interface {
-	EnableColour(w io.Writer) bool
+	ColourDepth(w io.Writer) ColourDepth
+}
+
+// depthOf maps a yes-or-no colour decision onto a [ColourDepth], leaving any
+// narrower limit to the other enablers it is combined with.
+func depthOf(enabled bool) ColourDepth {
+	if enabled {
+		return TrueColour
+	}
+	return NoColour
 }
 
 // InteractiveEnabler decides whether the underlying writer is part of an
@@ -25,9 +56,9 @@ type InteractiveEnabler interface {
 // when the writer exposes an Fd() method and the predicate returns true.
 type IsTTYFuncEnabler func(fd int) bool
 
-// EnableColour implements [ColourEnabler].
-func (f IsTTYFuncEnabler) EnableColour(w io.Writer) bool {
-	return f.EnableInteractive(w)
+// ColourDepth implements [ColourEnabler].
+func (f IsTTYFuncEnabler) ColourDepth(w io.Writer) ColourDepth {
+	return depthOf(f.EnableInteractive(w))
 }
 
 // EnableInteractive implements [InteractiveEnabler].
@@ -44,9 +75,9 @@ var _ ColourEnabler = (*IsTTYFuncEnabler)(nil)
 // caller has already resolved colour from configuration or a user flag.
 type FixedEnabler bool
 
-// EnableColour implements [ColourEnabler].
-func (e FixedEnabler) EnableColour(io.Writer) bool {
-	return bool(e)
+// ColourDepth implements [ColourEnabler].
+func (e FixedEnabler) ColourDepth(io.Writer) ColourDepth {
+	return depthOf(bool(e))
 }
 
 // EnableInteractive implements [InteractiveEnabler].
@@ -56,15 +87,26 @@ func (e FixedEnabler) EnableInteractive(io.Writer) bool {
 
 var _ ColourEnabler = (*FixedEnabler)(nil)
 
+// FixedDepth is an Enabler that always reports the same colour depth. Useful
+// when the caller has already resolved the depth a terminal supports.
+type FixedDepth ColourDepth
+
+// ColourDepth implements [ColourEnabler].
+func (d FixedDepth) ColourDepth(io.Writer) ColourDepth {
+	return ColourDepth(d)
+}
+
+var _ ColourEnabler = (*FixedDepth)(nil)
+
 // EnvEnabler enables colour iff the named environment variable parses as a
 // truthy boolean (see [strconv.ParseBool]).
 type EnvEnabler struct {
 	Variable string
 }
 
-// EnableColour implements [ColourEnabler].
-func (e EnvEnabler) EnableColour(w io.Writer) bool {
-	return e.EnableInteractive(w)
+// ColourDepth implements [ColourEnabler].
+func (e EnvEnabler) ColourDepth(w io.Writer) ColourDepth {
+	return depthOf(e.EnableInteractive(w))
 }
 
 // EnableInteractive implements [InteractiveEnabler]
@@ -82,35 +124,68 @@ type InvertEnabler struct {
 	Enabler ColourEnabler
 }
 
-// EnableColour implements [ColourEnabler].
-func (e InvertEnabler) EnableColour(w io.Writer) bool {
-	return !e.Enabler.EnableColour(w)
+// ColourDepth implements [ColourEnabler]. It reports [TrueColour] when the
+// inner Enabler disables colour, and [NoColour] otherwise.
+func (e InvertEnabler) ColourDepth(w io.Writer) ColourDepth {
+	return depthOf(e.Enabler.ColourDepth(w) == NoColour)
 }
 
 var _ ColourEnabler = (*InvertEnabler)(nil)
 
-// ConjunctiveEnabler enables colour only when every member agrees. An empty
-// value disables colour.
+// ConjunctiveEnabler enables colour only when every member agrees, at the
+// least depth any member reports. An empty value disables colour.
 type ConjunctiveEnabler []ColourEnabler
 
-// EnableColour implements [ColourEnabler].
-func (c ConjunctiveEnabler) EnableColour(w io.Writer) bool {
+// ColourDepth implements [ColourEnabler].
+func (c ConjunctiveEnabler) ColourDepth(w io.Writer) ColourDepth {
 	if len(c) == 0 {
-		return false
+		return NoColour
 	}
+	depth := TrueColour
 	for _, checker := range c {
-		if !checker.EnableColour(w) {
-			return false
+		depth = min(depth, checker.ColourDepth(w))
+		if depth == NoColour {
+			break
 		}
 	}
-	return true
+	return depth
 }
 
 var _ ColourEnabler = (*ConjunctiveEnabler)(nil)
 
+// TermEnvEnabler reports the colour depth the terminal advertises through the
+// COLORTERM and TERM environment variables. A COLORTERM of "truecolor" or
+// "24bit" selects [TrueColour]; otherwise a TERM ending in "-direct" does too,
+// one containing "256color" selects [Colour256], and "dumb" selects
+// [NoColour]. Any other terminal, including one with TERM unset, is assumed to
+// render the sixteen named colours.
+type TermEnvEnabler struct{}
+
+// ColourDepth implements [ColourEnabler].
+func (TermEnvEnabler) ColourDepth(io.Writer) ColourDepth {
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return TrueColour
+	}
+	name := strings.ToLower(os.Getenv("TERM"))
+	switch {
+	case name == "dumb":
+		return NoColour
+	case strings.HasSuffix(name, "-direct"):
+		return TrueColour
+	case strings.Contains(name, "256color"):
+		return Colour256
+	default:
+		return Colour16
+	}
+}
+
+var _ ColourEnabler = (*TermEnvEnabler)(nil)
+
 // DefaultEnabler is the standard policy: colour is enabled only when the
 // writer is a real terminal and the NO_COLOR environment variable is not
-// set to a truthy value (see https://no-colour.org/).
+// set to a truthy value (see https://no-colour.org/), at the depth reported
+// by [TermEnvEnabler].
 var DefaultEnabler ColourEnabler = ConjunctiveEnabler{
 	IsTTYFuncEnabler(term.IsTerminal),
 	InvertEnabler{
@@ -118,4 +193,88 @@ var DefaultEnabler ColourEnabler = ConjunctiveEnabler{
 			Variable: "NO_COLOR",
 		},
 	},
+	TermEnvEnabler{},
+}
+
+// LinkEnabler decides whether hyperlinks should be emitted to a particular
+// writer as OSC 8 escapes, rather than spelled out as text.
+type LinkEnabler interface {
+	EnableLinks(w io.Writer) bool
+}
+
+// EnableLinks implements [LinkEnabler].
+func (e FixedEnabler) EnableLinks(io.Writer) bool {
+	return bool(e)
 }
+
+var _ LinkEnabler = (*FixedEnabler)(nil)
+
+// TermLinkEnabler enables hyperlinks when the environment identifies a
+// terminal known to render OSC 8 escapes. FORCE_HYPERLINK, when set, overrides
+// the detection with its truthiness (see [strconv.ParseBool]). Terminal
+// multiplexers such as tmux and screen are assumed not to pass hyperlinks
+// through.
+type TermLinkEnabler struct{}
+
+// EnableLinks implements [LinkEnabler].
+func (TermLinkEnabler) EnableLinks(io.Writer) bool {
+	if val, ok := os.LookupEnv("FORCE_HYPERLINK"); ok {
+		enabled, _ := strconv.ParseBool(val)
+		return enabled
+	}
+	name := os.Getenv("TERM")
+	if os.Getenv("TMUX") != "" || strings.HasPrefix(name, "screen") || strings.HasPrefix(name, "tmux") {
+		return false
+	}
+	for _, variable := range []string{"WT_SESSION", "KONSOLE_VERSION", "DOMTERM"} {
+		if os.Getenv(variable) != "" {
+			return true
+		}
+	}
+	if vte, err := strconv.Atoi(os.Getenv("VTE_VERSION")); err == nil && vte >= 5000 {
+		return true
+	}
+	switch os.Getenv("TERM_PROGRAM") {
+	case "iTerm.app", "WezTerm", "vscode", "ghostty", "Hyper":
+		return true
+	}
+	switch name {
+	case "xterm-kitty", "xterm-ghostty", "alacritty", "wezterm", "foot", "foot-extra":
+		return true
+	}
+	return false
+}
+
+var _ LinkEnabler = (*TermLinkEnabler)(nil)
+
+// UnicodeEnabler decides whether characters beyond ASCII, such as the
+// box-drawing characters of a bordered panel, can be shown on a particular
+// writer.
+type UnicodeEnabler interface {
+	EnableUnicode(w io.Writer) bool
+}
+
+// EnableUnicode implements [UnicodeEnabler].
+func (e FixedEnabler) EnableUnicode(io.Writer) bool {
+	return bool(e)
+}
+
+var _ UnicodeEnabler = (*FixedEnabler)(nil)
+
+// LocaleUnicodeEnabler enables Unicode when the locale's character encoding is
+// UTF-8, as named by the first of the LC_ALL, LC_CTYPE, and LANG environment
+// variables that is set, such as "en_US.UTF-8". Without a locale, as in the
+// "C" locale, text is taken to be ASCII. On Windows, whose console is written
+// in UTF-16 whatever the locale, Unicode is always enabled.
+type LocaleUnicodeEnabler struct{}
+
+// EnableUnicode implements [UnicodeEnabler].
+func (LocaleUnicodeEnabler) EnableUnicode(io.Writer) bool {
+	if runtime.GOOS == "windows" {
+		return true
+	}
+	locale := strings.ToLower(cmp.Or(os.Getenv("LC_ALL"), os.Getenv("LC_CTYPE"), os.Getenv("LANG")))
+	return strings.Contains(locale, "utf-8") || strings.Contains(locale, "utf8")
+}
+
+var _ UnicodeEnabler = (*LocaleUnicodeEnabler)(nil)
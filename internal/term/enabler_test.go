@@ -3,6 +3,8 @@ package term_test
 import (
 	"bytes"
 	"io"
+	"os"
+	"runtime"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -10,30 +12,30 @@ import (
 	"github.com/bitwizeshift/go-cli/internal/term"
 )
 
-func TestIsTTYFuncEnabler_EnableColour(t *testing.T) {
+func TestIsTTYFuncEnabler_ColourDepth(t *testing.T) {
 	t.Parallel()
 
 	testCases := []struct {
 		name     string
 		hasFd    bool
 		fnResult bool
-		want     bool
+		want     term.ColourDepth
 	}{
 		{
 			name:     "FdWriterFuncReturnsTrue",
 			hasFd:    true,
 			fnResult: true,
-			want:     true,
+			want:     term.TrueColour,
 		}, {
 			name:     "FdWriterFuncReturnsFalse",
 			hasFd:    true,
 			fnResult: false,
-			want:     false,
+			want:     term.NoColour,
 		}, {
 			name:     "WriterWithoutFd",
 			hasFd:    false,
 			fnResult: true,
-			want:     false,
+			want:     term.NoColour,
 		},
 	}
 
@@ -51,32 +53,32 @@ func TestIsTTYFuncEnabler_EnableColour(t *testing.T) {
 			enabler := term.IsTTYFuncEnabler(func(int) bool { return tc.fnResult })
 
 			// Act
-			got := enabler.EnableColour(writer)
+			got := enabler.ColourDepth(writer)
 
 			// Assert
 			if got, want := got, tc.want; !cmp.Equal(got, want) {
-				t.Errorf("IsTTYFuncEnabler.EnableColour(...) got %v, want %v", got, want)
+				t.Errorf("IsTTYFuncEnabler.ColourDepth(...) got %v, want %v", got, want)
 			}
 		})
 	}
 }
 
-func TestFixedEnabler_EnableColour(t *testing.T) {
+func TestFixedEnabler_ColourDepth(t *testing.T) {
 	t.Parallel()
 
 	testCases := []struct {
 		name   string
 		result bool
-		want   bool
+		want   term.ColourDepth
 	}{
 		{
 			name:   "True",
 			result: true,
-			want:   true,
+			want:   term.TrueColour,
 		}, {
 			name:   "False",
 			result: false,
-			want:   false,
+			want:   term.NoColour,
 		},
 	}
 
@@ -88,60 +90,60 @@ func TestFixedEnabler_EnableColour(t *testing.T) {
 			sut := term.FixedEnabler(tc.result)
 
 			// Act
-			enabled := sut.EnableColour(&bytes.Buffer{})
+			depth := sut.ColourDepth(&bytes.Buffer{})
 
 			// Assert
-			if got, want := enabled, tc.want; !cmp.Equal(got, want) {
-				t.Errorf("FixedEnabler.EnableColour(...) got %v, want %v", got, want)
+			if got, want := depth, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("FixedEnabler.ColourDepth(...) got %v, want %v", got, want)
 			}
 		})
 	}
 }
 
-func TestEnvEnabler_EnableColour(t *testing.T) {
+func TestEnvEnabler_ColourDepth(t *testing.T) {
 	const varName = "ANSI_TEST_ENV_ENABLER_VAR"
 
 	testCases := []struct {
 		name  string
 		set   bool
 		value string
-		want  bool
+		want  term.ColourDepth
 	}{
 		{
 			name:  "NotSet",
 			set:   false,
 			value: "",
-			want:  false,
+			want:  term.NoColour,
 		}, {
 			name:  "SetTrue",
 			set:   true,
 			value: "true",
-			want:  true,
+			want:  term.TrueColour,
 		}, {
 			name:  "SetOne",
 			set:   true,
 			value: "1",
-			want:  true,
+			want:  term.TrueColour,
 		}, {
 			name:  "SetFalse",
 			set:   true,
 			value: "false",
-			want:  false,
+			want:  term.NoColour,
 		}, {
 			name:  "SetZero",
 			set:   true,
 			value: "0",
-			want:  false,
+			want:  term.NoColour,
 		}, {
 			name:  "SetEmpty",
 			set:   true,
 			value: "",
-			want:  false,
+			want:  term.NoColour,
 		}, {
 			name:  "SetGarbage",
 			set:   true,
 			value: "garbage",
-			want:  false,
+			want:  term.NoColour,
 		},
 	}
 
@@ -154,32 +156,32 @@ func TestEnvEnabler_EnableColour(t *testing.T) {
 			sut := term.EnvEnabler{Variable: varName}
 
 			// Act
-			enabled := sut.EnableColour(&bytes.Buffer{})
+			depth := sut.ColourDepth(&bytes.Buffer{})
 
 			// Assert
-			if got, want := enabled, tc.want; !cmp.Equal(got, want) {
-				t.Errorf("EnvEnabler.EnableColour(...) got %v, want %v", got, want)
+			if got, want := depth, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("EnvEnabler.ColourDepth(...) got %v, want %v", got, want)
 			}
 		})
 	}
 }
 
-func TestInvertEnabler_EnableColour(t *testing.T) {
+func TestInvertEnabler_ColourDepth(t *testing.T) {
 	t.Parallel()
 
 	testCases := []struct {
 		name     string
 		innerVal bool
-		want     bool
+		want     term.ColourDepth
 	}{
 		{
 			name:     "InnerTrue",
 			innerVal: true,
-			want:     false,
+			want:     term.NoColour,
 		}, {
 			name:     "InnerFalse",
 			innerVal: false,
-			want:     true,
+			want:     term.TrueColour,
 		},
 	}
 
@@ -193,36 +195,36 @@ func TestInvertEnabler_EnableColour(t *testing.T) {
 			}
 
 			// Act
-			enabled := sut.EnableColour(&bytes.Buffer{})
+			depth := sut.ColourDepth(&bytes.Buffer{})
 
 			// Assert
-			if got, want := enabled, tc.want; !cmp.Equal(got, want) {
-				t.Errorf("InvertEnabler.EnableColour(...) got %v, want %v", got, want)
+			if got, want := depth, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("InvertEnabler.ColourDepth(...) got %v, want %v", got, want)
 			}
 		})
 	}
 }
 
-func TestConjunctiveEnabler_EnableColour(t *testing.T) {
+func TestConjunctiveEnabler_ColourDepth(t *testing.T) {
 	t.Parallel()
 
 	testCases := []struct {
 		name     string
 		enablers []term.ColourEnabler
-		want     bool
+		want     term.ColourDepth
 	}{
 		{
 			name:     "Empty",
 			enablers: nil,
-			want:     false,
+			want:     term.NoColour,
 		}, {
 			name:     "SingleTrue",
 			enablers: []term.ColourEnabler{term.FixedEnabler(true)},
-			want:     true,
+			want:     term.TrueColour,
 		}, {
 			name:     "SingleFalse",
 			enablers: []term.ColourEnabler{term.FixedEnabler(false)},
-			want:     false,
+			want:     term.NoColour,
 		}, {
 			name: "MultipleAllTrue",
 			enablers: []term.ColourEnabler{
@@ -230,7 +232,7 @@ func TestConjunctiveEnabler_EnableColour(t *testing.T) {
 				term.FixedEnabler(true),
 				term.FixedEnabler(true),
 			},
-			want: true,
+			want: term.TrueColour,
 		}, {
 			name: "MultipleFirstFalse",
 			enablers: []term.ColourEnabler{
@@ -238,7 +240,7 @@ func TestConjunctiveEnabler_EnableColour(t *testing.T) {
 				term.FixedEnabler(true),
 				term.FixedEnabler(true),
 			},
-			want: false,
+			want: term.NoColour,
 		}, {
 			name: "MultipleLastFalse",
 			enablers: []term.ColourEnabler{
@@ -246,7 +248,15 @@ func TestConjunctiveEnabler_EnableColour(t *testing.T) {
 				term.FixedEnabler(true),
 				term.FixedEnabler(false),
 			},
-			want: false,
+			want: term.NoColour,
+		}, {
+			name: "LeastDepth",
+			enablers: []term.ColourEnabler{
+				term.FixedEnabler(true),
+				term.FixedDepth(term.Colour16),
+				term.FixedDepth(term.Colour256),
+			},
+			want: term.Colour16,
 		},
 	}
 
@@ -258,11 +268,237 @@ func TestConjunctiveEnabler_EnableColour(t *testing.T) {
 			sut := term.ConjunctiveEnabler(tc.enablers)
 
 			// Act
-			enabled := sut.EnableColour(&bytes.Buffer{})
+			depth := sut.ColourDepth(&bytes.Buffer{})
+
+			// Assert
+			if got, want := depth, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("ConjunctiveEnabler.ColourDepth(...) got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestFixedDepth_ColourDepth(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		depth term.ColourDepth
+		want  term.ColourDepth
+	}{
+		{
+			name:  "NoColour",
+			depth: term.NoColour,
+			want:  term.NoColour,
+		}, {
+			name:  "Colour256",
+			depth: term.Colour256,
+			want:  term.Colour256,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			sut := term.FixedDepth(tc.depth)
+
+			// Act
+			depth := sut.ColourDepth(&bytes.Buffer{})
+
+			// Assert
+			if got, want := depth, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("FixedDepth.ColourDepth(...) got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestTermEnvEnabler_ColourDepth(t *testing.T) {
+	testCases := []struct {
+		name      string
+		colorterm string
+		term      string
+		want      term.ColourDepth
+	}{
+		{
+			name:      "Unset",
+			colorterm: "",
+			term:      "",
+			want:      term.Colour16,
+		}, {
+			name:      "ColorTermTrueColor",
+			colorterm: "truecolor",
+			term:      "xterm-256color",
+			want:      term.TrueColour,
+		}, {
+			name:      "ColorTerm24Bit",
+			colorterm: "24bit",
+			term:      "screen",
+			want:      term.TrueColour,
+		}, {
+			name:      "DirectTerm",
+			colorterm: "",
+			term:      "xterm-direct",
+			want:      term.TrueColour,
+		}, {
+			name:      "TmuxWithoutTrueColor",
+			colorterm: "",
+			term:      "tmux-256color",
+			want:      term.Colour256,
+		}, {
+			name:      "BasicTerm",
+			colorterm: "",
+			term:      "xterm",
+			want:      term.Colour16,
+		}, {
+			name:      "DumbTerm",
+			colorterm: "",
+			term:      "dumb",
+			want:      term.NoColour,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			t.Setenv("COLORTERM", tc.colorterm)
+			t.Setenv("TERM", tc.term)
+			sut := term.TermEnvEnabler{}
+
+			// Act
+			depth := sut.ColourDepth(&bytes.Buffer{})
+
+			// Assert
+			if got, want := depth, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("TermEnvEnabler.ColourDepth(...) got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestTermLinkEnabler_EnableLinks(t *testing.T) {
+	testCases := []struct {
+		name string
+		env  map[string]string
+		want bool
+	}{
+		{
+			name: "UnknownTerminal",
+			env:  map[string]string{"TERM": "xterm"},
+			want: false,
+		}, {
+			name: "KnownTermProgram",
+			env:  map[string]string{"TERM_PROGRAM": "iTerm.app"},
+			want: true,
+		}, {
+			name: "KnownTerm",
+			env:  map[string]string{"TERM": "xterm-kitty"},
+			want: true,
+		}, {
+			name: "WindowsTerminal",
+			env:  map[string]string{"WT_SESSION": "1"},
+			want: true,
+		}, {
+			name: "RecentVTE",
+			env:  map[string]string{"VTE_VERSION": "6003"},
+			want: true,
+		}, {
+			name: "OldVTE",
+			env:  map[string]string{"VTE_VERSION": "4601"},
+			want: false,
+		}, {
+			name: "InsideTmux",
+			env:  map[string]string{"TERM_PROGRAM": "iTerm.app", "TMUX": "/tmp/tmux-0/default,1,0"},
+			want: false,
+		}, {
+			name: "ForcedOn",
+			env:  map[string]string{"TERM": "xterm", "FORCE_HYPERLINK": "1"},
+			want: true,
+		}, {
+			name: "ForcedOff",
+			env:  map[string]string{"TERM_PROGRAM": "WezTerm", "FORCE_HYPERLINK": "0"},
+			want: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			for _, variable := range []string{
+				"FORCE_HYPERLINK", "TERM", "TMUX", "WT_SESSION", "KONSOLE_VERSION",
+				"DOMTERM", "VTE_VERSION", "TERM_PROGRAM",
+			} {
+				t.Setenv(variable, tc.env[variable])
+			}
+			if _, ok := tc.env["FORCE_HYPERLINK"]; !ok {
+				os.Unsetenv("FORCE_HYPERLINK")
+			}
+			sut := term.TermLinkEnabler{}
+
+			// Act
+			enabled := sut.EnableLinks(&bytes.Buffer{})
+
+			// Assert
+			if got, want := enabled, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("TermLinkEnabler.EnableLinks(...) got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestLocaleUnicodeEnabler_EnableUnicode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the Windows console always shows Unicode")
+	}
+
+	testCases := []struct {
+		name string
+		env  map[string]string
+		want bool
+	}{
+		{
+			name: "NoLocale",
+			env:  map[string]string{},
+			want: false,
+		}, {
+			name: "CLocale",
+			env:  map[string]string{"LANG": "C"},
+			want: false,
+		}, {
+			name: "UTF8Lang",
+			env:  map[string]string{"LANG": "en_US.UTF-8"},
+			want: true,
+		}, {
+			name: "LowerCaseEncoding",
+			env:  map[string]string{"LANG": "de_DE.utf8"},
+			want: true,
+		}, {
+			name: "CTypeOverridesLang",
+			env:  map[string]string{"LANG": "en_US.UTF-8", "LC_CTYPE": "en_US.ISO-8859-1"},
+			want: false,
+		}, {
+			name: "AllOverridesCType",
+			env:  map[string]string{"LC_CTYPE": "C", "LC_ALL": "C.UTF-8"},
+			want: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			for _, variable := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+				t.Setenv(variable, tc.env[variable])
+			}
+			sut := term.LocaleUnicodeEnabler{}
+
+			// Act
+			enabled := sut.EnableUnicode(&bytes.Buffer{})
 
 			// Assert
 			if got, want := enabled, tc.want; !cmp.Equal(got, want) {
-				t.Errorf("ConjunctiveEnabler.EnableColour(...) got %v, want %v", got, want)
+				t.Errorf("LocaleUnicodeEnabler.EnableUnicode(...) got %v, want %v", got, want)
 			}
 		})
 	}
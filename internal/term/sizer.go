@@ -15,6 +15,22 @@ type Sizer interface {
 	Columns(w io.Writer) int
 }
 
+// RowSizer reports the number of rows available when rendering text for the
+// writer w. Like [Sizer], a return value of 0 means "unknown".
+type RowSizer interface {
+	Rows(w io.Writer) int
+}
+
+// Rows reports the number of rows available when rendering text for w, as
+// read by s when it is also a [RowSizer]. It returns 0 when s cannot report
+// rows.
+func Rows(s Sizer, w io.Writer) int {
+	if rs, ok := s.(RowSizer); ok {
+		return rs.Rows(w)
+	}
+	return 0
+}
+
 // EnvSizer reads the column width from a named environment variable. A
 // missing or non-numeric value yields 0.
 type EnvSizer struct {
@@ -57,7 +73,21 @@ func (f TTYFuncSizer) Columns(w io.Writer) int {
 	return 0
 }
 
-var _ Sizer = (*TTYFuncSizer)(nil)
+// Rows implements [RowSizer].
+func (f TTYFuncSizer) Rows(w io.Writer) int {
+	if tty, ok := w.(interface{ Fd() uintptr }); ok {
+		_, rows, err := f(int(tty.Fd()))
+		if err == nil {
+			return rows
+		}
+	}
+	return 0
+}
+
+var (
+	_ Sizer    = (*TTYFuncSizer)(nil)
+	_ RowSizer = (*TTYFuncSizer)(nil)
+)
 
 // SaturateSizer clamps a non-zero reading from its inner Sizer to the
 // inclusive range [Min, Max]. A zero reading is passed through unchanged so
@@ -81,6 +111,12 @@ func (s SaturateSizer) Columns(w io.Writer) int {
 	return 0
 }
 
+// Rows implements [RowSizer], passing the inner Sizer's reading through
+// unclamped: Min and Max bound columns only.
+func (s SaturateSizer) Rows(w io.Writer) int {
+	return Rows(s.Sizer, w)
+}
+
 // FallbackSizer returns the first non-zero reading from its members.
 type FallbackSizer []Sizer
 
@@ -94,6 +130,17 @@ func (f FallbackSizer) Columns(w io.Writer) int {
 	return 0
 }
 
+// Rows implements [RowSizer], returning the first non-zero row reading from
+// its members.
+func (f FallbackSizer) Rows(w io.Writer) int {
+	for _, sizer := range f {
+		if rows := Rows(sizer, w); rows > 0 {
+			return rows
+		}
+	}
+	return 0
+}
+
 // DefaultSizer is the standard policy: prefer the COLUMNS environment
 // variable, then the actual terminal width, then a final 80-column fallback,
 // clamping the result to the inclusive range [60, 100].
@@ -317,3 +317,61 @@ func TestFallbackSizer_Columns(t *testing.T) {
 		})
 	}
 }
+
+func TestRows(t *testing.T) {
+	t.Parallel()
+
+	tty := term.TTYFuncSizer(func(int) (int, int, error) {
+		return 120, 40, nil
+	})
+	testCases := []struct {
+		name   string
+		sizer  term.Sizer
+		writer io.Writer
+		want   int
+	}{
+		{
+			name:   "TTYFuncSizer",
+			sizer:  tty,
+			writer: &fdWriter{fd: 1},
+			want:   40,
+		}, {
+			name:   "TTYFuncSizerWithoutFd",
+			sizer:  tty,
+			writer: &bytes.Buffer{},
+			want:   0,
+		}, {
+			name:   "SizerWithoutRows",
+			sizer:  term.FixedSizer(80),
+			writer: &fdWriter{fd: 1},
+			want:   0,
+		}, {
+			name:   "SaturateSizerLeavesRowsUnclamped",
+			sizer:  term.SaturateSizer{Min: 60, Max: 100, Sizer: tty},
+			writer: &fdWriter{fd: 1},
+			want:   40,
+		}, {
+			name:   "FallbackSizerSkipsMembersWithoutRows",
+			sizer:  term.FallbackSizer{term.FixedSizer(80), tty},
+			writer: &fdWriter{fd: 1},
+			want:   40,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			sizer := tc.sizer
+
+			// Act
+			rows := term.Rows(sizer, tc.writer)
+
+			// Assert
+			if got, want := rows, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Rows(...) got %d, want %d", got, want)
+			}
+		})
+	}
+}
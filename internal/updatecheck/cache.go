@@ -63,6 +63,15 @@ func (c *CacheProvider) LatestVersion(ctx context.Context) (string, error) {
 	return version, nil
 }
 
+// ReleaseURL implements [ReleaseLinker] by delegating to the wrapped provider,
+// returning "" when it does not implement ReleaseLinker.
+func (c *CacheProvider) ReleaseURL() string {
+	if linker, ok := c.Provider.(ReleaseLinker); ok {
+		return linker.ReleaseURL()
+	}
+	return ""
+}
+
 // cached returns the cached version and whether a fresh entry was found. A
 // missing, unreadable, or expired entry reports false.
 func (c *CacheProvider) cached() (string, bool) {
@@ -92,4 +101,7 @@ func (c *CacheProvider) path() string {
 	return c.Source + ".json"
 }
 
-var _ Provider = (*CacheProvider)(nil)
+var (
+	_ Provider      = (*CacheProvider)(nil)
+	_ ReleaseLinker = (*CacheProvider)(nil)
+)
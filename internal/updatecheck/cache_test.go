@@ -174,3 +174,46 @@ func decodeRecord(t *testing.T, data []byte) cacheJSON {
 	}
 	return record
 }
+
+func TestCacheProvider_ReleaseURL(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		provider updatecheck.Provider
+		want     string
+	}{
+		{
+			name:     "LinkedProvider",
+			provider: updatetest.LinkedProvider("v1.0.0", "https://example.com"),
+			want:     "https://example.com",
+		}, {
+			name:     "UnlinkedProvider",
+			provider: updatetest.Provider("v1.0.0"),
+			want:     "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			sut := &updatecheck.CacheProvider{
+				Provider: tc.provider,
+				Source:   "github",
+				TTL:      time.Hour,
+				Cache:    fakeCache{},
+				Now:      func() time.Time { return testNow },
+			}
+
+			// Act
+			url := sut.ReleaseURL()
+
+			// Assert
+			if got, want := url, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("CacheProvider.ReleaseURL() = %q, want %q", got, want)
+			}
+		})
+	}
+}
@@ -14,6 +14,10 @@ type Result struct {
 	// Latest is the newest version reported by the source, as canonical
 	// v-prefixed semver. It is empty when no update information was available.
 	Latest string
+
+	// URL is the page describing the Latest release, when the source links to
+	// one and an update is Available.
+	URL string
 }
 
 // Checker decides whether the running build has a newer release available on the
@@ -48,9 +52,13 @@ func (c *Checker) Check(ctx context.Context) (Result, error) {
 	if canonical, err := CanonicalVersion(current); err == nil {
 		current = canonical
 	}
-	return Result{
+	result := Result{
 		Available: latest != "" && IsNewer(latest, current),
 		Current:   current,
 		Latest:    latest,
-	}, nil
+	}
+	if result.Available {
+		result.URL = c.registry.ReleaseURL(c.build.Source)
+	}
+	return result, nil
 }
@@ -32,6 +32,25 @@ func TestChecker_Check(t *testing.T) {
 				Current:   "v1.0.0",
 				Latest:    "v2.0.0",
 			},
+		}, {
+			name:     "UpdateAvailableWithReleasePage",
+			build:    updatecheck.BuildInfo{Version: "v1.0.0", Source: "github"},
+			registry: updatecheck.ProviderRegistry{"github": updatetest.LinkedProvider("v2.0.0", "https://example.com/v2")},
+			want: updatecheck.Result{
+				Available: true,
+				Current:   "v1.0.0",
+				Latest:    "v2.0.0",
+				URL:       "https://example.com/v2",
+			},
+		}, {
+			name:     "UpToDateOmitsReleasePage",
+			build:    updatecheck.BuildInfo{Version: "v2.0.0", Source: "github"},
+			registry: updatecheck.ProviderRegistry{"github": updatetest.LinkedProvider("v2.0.0", "https://example.com/v2")},
+			want: updatecheck.Result{
+				Available: false,
+				Current:   "v2.0.0",
+				Latest:    "v2.0.0",
+			},
 		}, {
 			name:     "NonCanonicalCurrent",
 			build:    updatecheck.BuildInfo{Version: "1.0", Source: "github"},
@@ -10,6 +10,14 @@ type Provider interface {
 	LatestVersion(ctx context.Context) (string, error)
 }
 
+// ReleaseLinker is implemented by a [Provider] that can direct users to a web
+// page describing the latest release on its channel.
+type ReleaseLinker interface {
+	// ReleaseURL returns the URL of the page describing the latest release, or
+	// "" when the channel has no such page.
+	ReleaseURL() string
+}
+
 // ProviderRegistry maps a distribution source name to the [Provider] that looks
 // up the latest version for that source.
 type ProviderRegistry map[string]Provider
@@ -24,3 +32,13 @@ func (pr ProviderRegistry) LatestVersion(ctx context.Context, source string) (st
 	}
 	return provider.LatestVersion(ctx)
 }
+
+// ReleaseURL returns the page describing the latest release of source, or ""
+// when no provider is registered for source or it does not implement
+// [ReleaseLinker].
+func (pr ProviderRegistry) ReleaseURL(source string) string {
+	if linker, ok := pr[source].(ReleaseLinker); ok {
+		return linker.ReleaseURL()
+	}
+	return ""
+}
@@ -63,3 +63,48 @@ func TestProviderRegistry_LatestVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestProviderRegistry_ReleaseURL(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		registry updatecheck.ProviderRegistry
+		source   string
+		want     string
+	}{
+		{
+			name:     "LinkedSource",
+			registry: updatecheck.ProviderRegistry{"github": updatetest.LinkedProvider("v1.0.0", "https://example.com")},
+			source:   "github",
+			want:     "https://example.com",
+		}, {
+			name:     "UnlinkedSource",
+			registry: updatecheck.ProviderRegistry{"github": updatetest.Provider("v1.0.0")},
+			source:   "github",
+			want:     "",
+		}, {
+			name:     "UnregisteredSource",
+			registry: updatecheck.ProviderRegistry{"github": updatetest.LinkedProvider("v1.0.0", "https://example.com")},
+			source:   "brew",
+			want:     "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			sut := tc.registry
+
+			// Act
+			url := sut.ReleaseURL(tc.source)
+
+			// Assert
+			if got, want := url, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("ProviderRegistry.ReleaseURL() = %q, want %q", got, want)
+			}
+		})
+	}
+}
@@ -23,8 +23,11 @@ type config struct {
 	builders   map[string]spec.Builder
 	theme      *richtext.Theme
 	colour     spec.ColourMode
+	background richtext.Background
 	sizer      term.Sizer
 	classifier exit.Classifier
+	shell      bool
+	prompt     bool
 
 	buildVersion    string
 	buildSource     string
@@ -123,6 +126,30 @@ func ForceColour() Option {
 	})
 }
 
+// LightBackground styles the CLI's output for a light terminal background,
+// rather than detecting the background. It selects the light variant of an
+// adaptive theme such as [richtext.DefaultTheme].
+//
+// It is mutually exclusive with [DarkBackground]: setting a background more
+// than once, or setting both, panics.
+func LightBackground() Option {
+	return option(func(c *config) {
+		setBackground(c, richtext.LightBackground)
+	})
+}
+
+// DarkBackground styles the CLI's output for a dark terminal background,
+// rather than detecting the background. It selects the dark variant of an
+// adaptive theme such as [richtext.DefaultTheme].
+//
+// It is mutually exclusive with [LightBackground]: setting a background more
+// than once, or setting both, panics.
+func DarkBackground() Option {
+	return option(func(c *config) {
+		setBackground(c, richtext.DarkBackground)
+	})
+}
+
 // TerminalWidth sets a fixed width for the terminal width, instead of being
 // dynamic based on the terminal size. Setting columns less than 60 will panic.
 func TerminalWidth(columns int) Option {
@@ -191,6 +218,29 @@ func UpdateProvider(name string, provider update.Provider) Option {
 	})
 }
 
+// EnableShell adds a "shell" command to the root of the CLI, which runs the
+// commands of the specification interactively, one line at a time. The shell
+// offers line editing, tab completion, and a history kept in the application's
+// data storage. Every command run from one shell shares a [Session], so a
+// builder can keep a connection open for the commands that follow it.
+func EnableShell() Option {
+	return option(func(c *config) {
+		c.shell = true
+	})
+}
+
+// PromptIfMissing asks for every required flag and positional argument that
+// is left without a value, as though each had been registered with
+// [arg.PromptIfMissing]. Prompts are not shown when input was disabled with
+// --no-input, when running under continuous integration, or when standard
+// input or the error stream prompts are written to is not a terminal; a
+// missing argument then fails the command as usual.
+func PromptIfMissing() Option {
+	return option(func(c *config) {
+		c.prompt = true
+	})
+}
+
 // setColour transitions the config's colour mode, panicking on any transition
 // away from the default: a mode may be selected at most once.
 func setColour(c *config, mode spec.ColourMode) {
@@ -199,3 +249,12 @@ func setColour(c *config, mode spec.ColourMode) {
 	}
 	c.colour = mode
 }
+
+// setBackground transitions the config's background, panicking on any
+// transition away from detection: a background may be selected at most once.
+func setBackground(c *config, bg richtext.Background) {
+	if c.background != richtext.UnknownBackground {
+		panic("cli: background already set")
+	}
+	c.background = bg
+}
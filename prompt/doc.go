@@ -1,8 +1,22 @@
 // Package prompt reads answers to interactive prompts from the terminal.
 //
-// It offers plain, confirmation, masked-secret, and typed reads, each taking a
-// context so a prompt can be cancelled. A [Prompter] binds the streams to read
-// and write; the package-level functions use [DefaultPrompter] over the process
-// standard streams. Secret reads require an interactive terminal and error
-// otherwise.
+// It offers plain, confirmation, masked-secret, and typed reads, and menus
+// choosing one or several of a list of options, each taking a context so a
+// prompt can be cancelled. A [Prompter] binds the streams to read and write;
+// the package-level functions use the one returned by [From], which is
+// [DefaultPrompter] over the process standard streams unless a test has
+// scripted the conversation. Secret reads require an interactive terminal and
+// error otherwise; menus fall back to a numbered list.
+//
+// On a terminal, lines are edited as they are typed, with cursor movement,
+// shell-style deletion, tab completion through [Complete], and recall of
+// earlier replies through [History]; elsewhere whole lines are read as they
+// arrive. Longer text, such as a commit message, is written in the user's own
+// text editor through [Editor].
+//
+// Prompts honour the interaction policy of the running command. When the
+// command may not interact with the user -- under --no-input, under continuous
+// integration, or without a terminal -- every prompt fails immediately with
+// [ErrNonInteractive], and under --yes [Prompter.Confirm] accepts without
+// asking.
 package prompt
@@ -0,0 +1,157 @@
+package prompt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/bitwizeshift/go-cli/internal/clictx"
+	"github.com/bitwizeshift/go-cli/internal/shellwords"
+)
+
+// ErrEditAborted is returned by [Prompter.Editor] when the edited text is
+// saved unchanged, or is left with nothing but comments and blank lines.
+var ErrEditAborted = errors.New("prompt: edit aborted")
+
+// defaultEditor is the editor launched when neither $VISUAL nor $EDITOR names
+// one.
+const defaultEditor = "vi"
+
+// Editor opens template in the user's text editor and returns the text saved,
+// in the manner of a commit message: lines beginning with '#' are removed, as
+// are blank lines at its start and end. ext is the file extension, such as
+// ".md", that tells the editor how to highlight the text.
+//
+// The editor is named by $VISUAL, then $EDITOR, falling back to vi. It is run
+// by the shell, as git runs it, writing to the stream beneath Out until it
+// exits. The file is created in the application's runtime storage, and removed
+// once it has been read. It returns [ErrEditAborted] when the text is saved
+// unchanged or left empty.
+func (p *Prompter) Editor(ctx context.Context, template, ext string) (string, error) {
+	if err := interactive(ctx); err != nil {
+		return "", err
+	}
+	edit := clictx.Editor(ctx)
+	if edit == nil {
+		edit = p.launchEditor
+	}
+	saved, err := edit(ctx, template, ext)
+	if err != nil {
+		return "", err
+	}
+	text := stripComments(saved)
+	if saved == template || strings.TrimSpace(text) == "" {
+		return "", ErrEditAborted
+	}
+	return text, nil
+}
+
+// launchEditor writes content to a new file with extension ext, runs the
+// user's editor on it, and returns the content it was saved with.
+func (p *Prompter) launchEditor(ctx context.Context, content, ext string) (_ string, err error) {
+	file, err := os.CreateTemp(editDir(ctx), "edit-*"+ext)
+	if err != nil {
+		return "", err
+	}
+	path := file.Name()
+	defer func() {
+		err = errors.Join(err, os.Remove(path))
+	}()
+	_, err = io.WriteString(file, content)
+	if err = errors.Join(err, file.Close()); err != nil {
+		return "", err
+	}
+
+	// Whatever was prompted so far must reach the terminal before the editor
+	// takes it over.
+	if w, ok := p.Out.(interface{ Flush() error }); ok {
+		if err := w.Flush(); err != nil {
+			return "", err
+		}
+	}
+	editor := editorCommand()
+	cmd, err := editorProcess(ctx, editor, path)
+	if err != nil {
+		return "", fmt.Errorf("running editor %q: %w", editor, err)
+	}
+	out := underlying(p.Out)
+	cmd.Stdin = fileOr(p.In, os.Stdin)
+	cmd.Stdout, cmd.Stderr = out, out
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("running editor %q: %w", editor, err)
+	}
+	saved, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(saved), nil
+}
+
+// editorCommand returns the command line of the user's text editor.
+func editorCommand() string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(name)); editor != "" {
+			return editor
+		}
+	}
+	return defaultEditor
+}
+
+// editorProcess returns the command that runs editor, the command line of the
+// user's text editor, on path. As git does, it is run by the shell, so it may
+// name the editor by a quoted path holding spaces and give it arguments of its
+// own. Windows has no such shell, so there it is split into words instead.
+func editorProcess(ctx context.Context, editor, path string) (*exec.Cmd, error) {
+	if runtime.GOOS != "windows" {
+		return exec.CommandContext(ctx, "sh", "-c", editor+` "$@"`, editor, path), nil
+	}
+	words, err := shellwords.Split(editor)
+	if err != nil {
+		return nil, err
+	}
+	return exec.CommandContext(ctx, words[0], append(words[1:], path)...), nil
+}
+
+// editDir returns the directory the file edited by [Prompter.Editor] is
+// created in: the application's runtime storage when ctx carries storage on
+// the host filesystem, and the system temporary directory otherwise.
+func editDir(ctx context.Context) string {
+	if app := clictx.Storage(ctx); app != nil {
+		if dir, err := app.Runtime.Path("."); err == nil && os.MkdirAll(dir, 0o700) == nil {
+			return dir
+		}
+	}
+	return ""
+}
+
+// fileOr returns v when it is an [os.File], so the editor reads from the same
+// terminal, and fallback otherwise.
+func fileOr(v any, fallback *os.File) *os.File {
+	if f, ok := v.(*os.File); ok {
+		return f
+	}
+	return fallback
+}
+
+// stripComments removes the lines of text beginning with '#', and the blank
+// lines at its start and end.
+func stripComments(text string) string {
+	var lines []string
+	for line := range strings.SplitSeq(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
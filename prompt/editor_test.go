@@ -0,0 +1,52 @@
+package prompt_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/bitwizeshift/go-cli/prompt"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+// editorScript is a text editor that announces itself on standard output and
+// saves its first argument as the text of the file named by its second.
+const editorScript = "#!/bin/sh\necho editing\nprintf '%s\\n' \"$1\" > \"$2\"\n"
+
+func TestPrompter_Editor_RunsEditorCommandLine(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the editor is a shell script")
+	}
+
+	// Arrange
+	dir := filepath.Join(t.TempDir(), "Sublime Text.app")
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		t.Fatalf("os.MkdirAll(...) = %v, want nil", err)
+	}
+	script := filepath.Join(dir, "my editor")
+	if err := os.WriteFile(script, []byte(editorScript), 0o700); err != nil {
+		t.Fatalf("os.WriteFile(...) = %v, want nil", err)
+	}
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", `"`+script+`" -w`)
+	var out strings.Builder
+	sut := &prompt.Prompter{Out: &out, In: strings.NewReader("")}
+
+	// Act
+	text, err := sut.Editor(context.Background(), "# template\n", ".txt")
+
+	// Assert
+	if got, want := err, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Fatalf("Editor(...) = %v, want %v", got, want)
+	}
+	if got, want := text, "-w"; got != want {
+		t.Errorf("Editor(...) = %q, want %q", got, want)
+	}
+	if got, want := out.String(), "editing\n"; got != want {
+		t.Errorf("Out = %q, want %q", got, want)
+	}
+}
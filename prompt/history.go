@@ -0,0 +1,47 @@
+package prompt
+
+import (
+	"bytes"
+	"context"
+	"path"
+
+	"github.com/bitwizeshift/go-cli/internal/clictx"
+	"github.com/bitwizeshift/go-cli/internal/lineedit"
+	"github.com/bitwizeshift/go-cli/prompt/internal/ask"
+)
+
+const (
+	// historyDir names the directory, within the application's data storage,
+	// holding the histories kept by [History].
+	historyDir = "prompt_history"
+
+	// historyLimit is the number of replies each history retains.
+	historyLimit = 100
+)
+
+// withHistory calls read with the rules of s. When s names a history, it is
+// loaded from the application's data storage on ctx beforehand, and saved once
+// read accepts a reply. A history that cannot be loaded or saved is not kept,
+// rather than failing the prompt.
+func withHistory(ctx context.Context, s settings, read func(ask.Rules) error) error {
+	if s.history == "" {
+		return read(s.Rules)
+	}
+	s.History = lineedit.NewHistory(historyLimit)
+	app := clictx.Storage(ctx)
+	file := path.Join(historyDir, s.history)
+	if app != nil {
+		if data, err := app.Data.ReadFile(file); err == nil {
+			_ = s.History.Load(bytes.NewReader(data))
+		}
+	}
+	if err := read(s.Rules); err != nil {
+		return err
+	}
+	if app != nil {
+		var buf bytes.Buffer
+		_ = s.History.Save(&buf)
+		_ = app.Data.WriteFile(file, buf.Bytes())
+	}
+	return nil
+}
@@ -4,80 +4,123 @@ import (
 	"bufio"
 	"context"
 	"errors"
+	"fmt"
 	"io"
+	"reflect"
 	"strings"
 
+	"github.com/bitwizeshift/go-cli/internal/lineedit"
 	"github.com/bitwizeshift/go-cli/internal/term"
+	"github.com/bitwizeshift/go-cli/richtext"
 )
 
-// ErrInterrupted is returned by [Asker.Secret] when the user aborts entry with
-// Ctrl-C or Ctrl-D.
-var ErrInterrupted = errors.New("input interrupted")
+// ErrInterrupted is returned when the user aborts entry with Ctrl-C, or with
+// Ctrl-D at a secret or a menu.
+var ErrInterrupted = lineedit.ErrInterrupted
 
 // Asker reads answers to prompts from In, writing the prompts and any echoed
 // characters to Out. Secret answers are masked with HiddenChar after EchoDisabler
 // suppresses the terminal's own echo.
+//
+// Selection menus are styled with Theme, resolved against Background when it
+// adapts to the terminal's background, and show as many options at once as
+// Sizer reports rows for Out.
 type Asker struct {
 	Out io.Writer
 	In  io.Reader
 
 	EchoDisabler term.EchoDisabler
+	Sizer        term.Sizer
+	Theme        *richtext.Theme
+	Background   richtext.Background
 
 	HiddenChar rune
 }
 
 // DefaultAsker returns an [Asker] writing to out, reading from in, masking secret
-// input with hidden, and using the real terminal for echo control.
+// input with hidden, and using the real terminal for echo control and sizing.
 func DefaultAsker(out io.Writer, in io.Reader, hidden rune) *Asker {
 	return &Asker{
 		Out:          out,
 		In:           in,
 		HiddenChar:   hidden,
 		EchoDisabler: term.DefaultConsole,
+		Sizer:        term.DefaultSizer,
+		Theme:        richtext.DefaultTheme,
 	}
 }
 
 // Line writes prompt to Out and returns the next line of input with its trailing
-// newline removed. It returns ctx.Err() if ctx is cancelled before a line is
+// newline removed, accepted under rules. On a terminal the line is edited as it
+// is typed, recalling entries from the rules' history and completing words
+// with its completer. It returns ctx.Err() if ctx is cancelled before a line is
 // read, or io.EOF if input ends without one.
-func (a *Asker) Line(ctx context.Context, prompt string) (string, error) {
-	if _, err := io.WriteString(a.Out, prompt); err != nil {
+func (a *Asker) Line(ctx context.Context, prompt string, rules Rules) (string, error) {
+	answer, err := a.ask(ctx, hinted(prompt, rules.hints()), rules, func(line string) (any, error) {
+		return line, nil
+	})
+	if err != nil {
 		return "", err
 	}
-	return a.readLine(ctx, bufio.NewReader(a.In))
+	return answer.(string), nil
 }
 
 // Confirm writes prompt followed by a "[y/n]" hint and returns the parsed
 // answer, treating "y"/"yes" as true and "n"/"no" as false (case-insensitive).
-// It re-prompts on any other input, and returns ctx.Err() on cancellation.
-func (a *Asker) Confirm(ctx context.Context, prompt string) (bool, error) {
-	reader := bufio.NewReader(a.In)
-	for {
-		if _, err := io.WriteString(a.Out, prompt+" [y/n]: "); err != nil {
-			return false, err
-		}
-		line, err := a.readLine(ctx, reader)
-		if err != nil {
-			return false, err
-		}
+// A bool default capitalises the answer it stands for, as in "[Y/n]". Any other
+// reply is rejected under rules, and ctx.Err() is returned on cancellation.
+func (a *Asker) Confirm(ctx context.Context, prompt string, rules Rules) (bool, error) {
+	choices := "[y/n]"
+	switch rules.Default {
+	case nil:
+	case true:
+		choices = "[Y/n]"
+	case false:
+		choices = "[y/N]"
+	default:
+		panic(fmt.Sprintf("ask: Confirm with non-bool default %T", rules.Default))
+	}
+	prompt = strings.TrimRight(hinted(prompt, rules.withHelp([]string{choices})), " ") + ": "
+	answer, err := a.ask(ctx, prompt, rules, func(line string) (any, error) {
 		switch strings.ToLower(strings.TrimSpace(line)) {
 		case "y", "yes":
 			return true, nil
 		case "n", "no":
 			return false, nil
 		}
+		return nil, errNotYesNo
+	})
+	if err != nil {
+		return false, err
 	}
+	return answer.(bool), nil
 }
 
-// Value writes prompt, reads one line, and unmarshals it into v via [Unmarshal].
-// It returns ctx.Err() on cancellation or a parse error for invalid input, and
-// panics if v is not a supported target type.
-func (a *Asker) Value(ctx context.Context, prompt string, v any) error {
-	line, err := a.Line(ctx, prompt)
+// Value writes prompt, reads one line, and unmarshals it into v via [Unmarshal],
+// accepting it under rules. v is left unchanged until an answer is accepted. It
+// returns ctx.Err() on cancellation, and panics if v is not a pointer to a
+// supported target type or rules holds a default v cannot be set to.
+func (a *Asker) Value(ctx context.Context, prompt string, v any, rules Rules) error {
+	target := reflect.ValueOf(v)
+	if target.Kind() != reflect.Pointer || target.IsNil() {
+		panic(fmt.Sprintf("ask: Value into non-pointer %T", v))
+	}
+	elem := target.Type().Elem()
+	if rules.Default != nil && !reflect.TypeOf(rules.Default).AssignableTo(elem) {
+		panic(fmt.Sprintf("ask: Value default %T is not assignable to %v", rules.Default, elem))
+	}
+	answer, err := a.ask(ctx, hinted(prompt, rules.hints()), rules, func(line string) (any, error) {
+		decoded := reflect.New(elem)
+		if err := Unmarshal(line, decoded.Interface()); err != nil {
+			return nil, err
+		}
+		return decoded.Elem().Interface(), nil
+	})
 	if err != nil {
 		return err
 	}
-	return Unmarshal(line, v)
+	target.Elem().Set(reflect.ValueOf(answer))
+	return nil
 }
 
 // Secret writes prompt, disables the terminal's echo, and reads one line while
@@ -100,32 +143,6 @@ func (a *Asker) Secret(ctx context.Context, prompt string) (_ string, err error)
 	return a.readSecret(ctx)
 }
 
-// readLine returns the next line from r with its trailing newline removed,
-// honouring ctx while the blocking read runs in a goroutine.
-func (a *Asker) readLine(ctx context.Context, r *bufio.Reader) (string, error) {
-	type result struct {
-		line string
-		err  error
-	}
-	ch := make(chan result, 1)
-	go func() {
-		line, err := r.ReadString('\n')
-		ch <- result{line: line, err: err}
-	}()
-
-	select {
-	case <-ctx.Done():
-		// No newline was echoed, so move off the prompt line before returning.
-		_, _ = io.WriteString(a.Out, "\n")
-		return "", ctx.Err()
-	case res := <-ch:
-		if res.err != nil && !(errors.Is(res.err, io.EOF) && res.line != "") {
-			return "", res.err
-		}
-		return strings.TrimRight(res.line, "\r\n"), nil
-	}
-}
-
 // readSecret reads runes until Enter, masking each with HiddenChar. It honours
 // ctx, handles backspace, and returns [ErrInterrupted] on Ctrl-C/Ctrl-D.
 func (a *Asker) readSecret(ctx context.Context) (string, error) {
@@ -184,3 +201,10 @@ func (a *Asker) readSecret(ctx context.Context) (string, error) {
 		}
 	}
 }
+
+// writer returns a [richtext.Writer] rendering to Out in the Asker's theme.
+func (a *Asker) writer() *richtext.Writer {
+	w := richtext.NewWriter(a.Out, a.Theme)
+	w.SetBackground(a.Background)
+	return w
+}
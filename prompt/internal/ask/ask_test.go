@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/bitwizeshift/go-cli/internal/lineedit"
 	"github.com/bitwizeshift/go-cli/internal/term"
 	"github.com/bitwizeshift/go-cli/internal/term/termtest"
 	"github.com/bitwizeshift/go-cli/prompt/internal/ask"
@@ -71,7 +72,7 @@ func TestAsker_Line(t *testing.T) {
 			ctx := context.Background()
 
 			// Act
-			line, err := sut.Line(ctx, tc.prompt)
+			line, err := sut.Line(ctx, tc.prompt, ask.Rules{})
 
 			// Assert
 			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
@@ -87,6 +88,79 @@ func TestAsker_Line(t *testing.T) {
 	}
 }
 
+func TestAsker_Line_Raw(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		input       string
+		history     []string
+		candidates  []string
+		want        string
+		wantHistory []string
+	}{
+		{
+			name:        "EditsLine",
+			input:       "helo\x1b[Dl\r",
+			want:        "hello",
+			wantHistory: []string{"hello"},
+		}, {
+			name:        "KillsWord",
+			input:       "hello big\x17world\r",
+			want:        "hello world",
+			wantHistory: []string{"hello world"},
+		}, {
+			name:        "RecallsHistory",
+			input:       "\x1b[A\x1b[A\r",
+			history:     []string{"first", "second"},
+			want:        "first",
+			wantHistory: []string{"first", "second", "first"},
+		}, {
+			name:        "CompletesWord",
+			input:       "us-w\t\r",
+			candidates:  []string{"us-west"},
+			want:        "us-west ",
+			wantHistory: []string{"us-west "},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			history := lineedit.NewHistory(0)
+			for _, entry := range tc.history {
+				history.Add(entry)
+			}
+			sut := &ask.Asker{
+				Out:          io.Discard,
+				In:           strings.NewReader(tc.input),
+				EchoDisabler: termtest.NoOpEchoDisabler(),
+			}
+			rules := ask.Rules{
+				History:  history,
+				Complete: func(string) []string { return tc.candidates },
+			}
+			ctx := context.Background()
+
+			// Act
+			line, err := sut.Line(ctx, "Region: ", rules)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Asker.Line(...) error got %v, want nil", err)
+			}
+			if got, want := line, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Asker.Line(...) got %q, want %q", got, want)
+			}
+			if got, want := history.Entries(), tc.wantHistory; !cmp.Equal(got, want) {
+				t.Errorf("History.Entries() got %q, want %q", got, want)
+			}
+		})
+	}
+}
+
 func TestAsker_Value(t *testing.T) {
 	t.Parallel()
 
@@ -126,7 +200,7 @@ func TestAsker_Value(t *testing.T) {
 			ctx := context.Background()
 
 			// Act
-			err := sut.Value(ctx, "> ", tc.target)
+			err := sut.Value(ctx, "> ", tc.target, ask.Rules{})
 			value := reflect.ValueOf(tc.target).Elem().Interface()
 
 			// Assert
@@ -192,7 +266,7 @@ func TestAsker_Confirm(t *testing.T) {
 			ctx := context.Background()
 
 			// Act
-			answer, err := sut.Confirm(ctx, "Continue?")
+			answer, err := sut.Confirm(ctx, "Continue?", ask.Rules{})
 			prompts := strings.Count(out.String(), "Continue?")
 
 			// Assert
@@ -300,7 +374,7 @@ func TestAsker_Line_Cancelled(t *testing.T) {
 	cancel()
 
 	// Act
-	line, err := sut.Line(ctx, "Name: ")
+	line, err := sut.Line(ctx, "Name: ", ask.Rules{})
 
 	// Assert
 	if got, want := err, context.Canceled; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
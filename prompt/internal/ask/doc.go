@@ -1,7 +1,9 @@
 // Package ask reads answers to interactive prompts from an input stream.
 //
 // It is the engine behind the public prompt package: an [Asker] writes a prompt,
-// reads a line or a masked secret while honouring a cancellation context, and can
-// decode a typed answer. Secret entry requires a terminal, surfacing the echo
-// controller's error when one is not present.
+// reads a line, edited in place on a terminal, or a masked secret while
+// honouring a cancellation context, can decode a typed answer, and runs
+// selection menus. Secret entry requires a terminal, surfacing the echo
+// controller's error when one is not present; lines are read whole and menus
+// fall back to a numbered list instead.
 package ask
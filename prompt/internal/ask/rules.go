@@ -0,0 +1,134 @@
+package ask
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/bitwizeshift/go-cli/internal/lineedit"
+	"github.com/bitwizeshift/go-cli/internal/template/tag"
+)
+
+var (
+	// ErrTooManyAttempts is returned when more replies are rejected than
+	// [Rules.MaxAttempts] allows.
+	ErrTooManyAttempts = errors.New("too many invalid answers")
+
+	// errNotYesNo rejects a reply to [Asker.Confirm] that is neither yes nor no.
+	errNotYesNo = errors.New(`answer "y" or "n"`)
+)
+
+// helpReply is the reply that shows a prompt's help instead of answering it.
+const helpReply = "?"
+
+// Rules controls which replies to [Asker.Line], [Asker.Value], and
+// [Asker.Confirm] are accepted. A rejected reply is reported and the prompt is
+// written again. The zero value accepts the first reply that decodes, and
+// re-prompts indefinitely otherwise.
+type Rules struct {
+	// Default is the answer taken for an empty reply, and is shown in brackets
+	// after the prompt. A nil Default leaves an empty reply to be decoded like
+	// any other.
+	Default any
+
+	// Validate checks a decoded answer, rejecting it with an error.
+	Validate func(answer any) error
+
+	// MaxAttempts is the number of replies read before giving up with
+	// [ErrTooManyAttempts]. Zero or less reads until a reply is accepted.
+	MaxAttempts int
+
+	// Help is shown when the reply is "?". When it is empty, "?" is answered
+	// like any other reply.
+	Help string
+
+	// History records replies and supplies them for recall while a reply is
+	// edited on a terminal. It may be nil, in which case no history is kept.
+	History *lineedit.History
+
+	// Complete supplies tab-completion candidates while a reply is edited on a
+	// terminal. It may be nil, in which case Tab is ignored.
+	Complete lineedit.CompleteFunc
+}
+
+// hints returns the bracketed hints written after a prompt read under r.
+func (r Rules) hints() []string {
+	var hints []string
+	if r.Default != nil {
+		hints = append(hints, fmt.Sprintf("[%v]", r.Default))
+	}
+	return r.withHelp(hints)
+}
+
+// withHelp appends a hint that "?" shows help to hints, when r has help.
+func (r Rules) withHelp(hints []string) []string {
+	if r.Help != "" {
+		hints = append(hints, "[? for help]")
+	}
+	return hints
+}
+
+// hinted returns prompt followed by hints. prompt is returned unchanged when
+// there are no hints.
+func hinted(prompt string, hints []string) string {
+	if len(hints) == 0 {
+		return prompt
+	}
+	return strings.TrimRight(prompt, " ") + " " + strings.Join(hints, " ") + " "
+}
+
+// ask writes prompt and reads replies until decode and rules accept one,
+// returning the decoded answer. An empty reply is answered with the rules'
+// default when there is one. A rejected reply is reported on Out before the
+// prompt is written again.
+//
+// If input ends after a reply was rejected, the rejection is returned rather
+// than io.EOF, since it explains why no answer was given.
+func (a *Asker) ask(ctx context.Context, prompt string, rules Rules, decode func(line string) (any, error)) (any, error) {
+	editor := &lineedit.Editor{
+		Out:      a.Out,
+		In:       a.In,
+		Console:  a.EchoDisabler,
+		History:  rules.History,
+		Complete: rules.Complete,
+	}
+	var rejected error
+	for attempt := 1; ; {
+		line, err := editor.ReadLineContext(ctx, prompt)
+		if err != nil {
+			if errors.Is(err, io.EOF) && rejected != nil {
+				return nil, rejected
+			}
+			return nil, err
+		}
+		if rules.Help != "" && strings.TrimSpace(line) == helpReply {
+			a.note("info", rules.Help)
+			continue
+		}
+		answer, err := rules.Default, error(nil)
+		if line != "" || answer == nil {
+			answer, err = decode(line)
+		}
+		if err == nil && rules.Validate != nil {
+			err = rules.Validate(answer)
+		}
+		if err == nil {
+			return answer, nil
+		}
+		rejected = err
+		a.note("error", err.Error())
+		if rules.MaxAttempts > 0 && attempt >= rules.MaxAttempts {
+			return nil, fmt.Errorf("%w: %w", ErrTooManyAttempts, err)
+		}
+		attempt++
+	}
+}
+
+// note writes text to Out on a line of its own, styled with the theme's role.
+func (a *Asker) note(role, text string) {
+	w := a.writer()
+	_, _ = io.WriteString(w, tag.Themed(role, tag.Raw(text))+"\n")
+	_ = w.Flush()
+}
@@ -0,0 +1,265 @@
+package ask_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/bitwizeshift/go-cli/prompt/internal/ask"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+var errTooShort = errors.New("too short")
+
+// minLength returns a validator rejecting strings shorter than n.
+func minLength(n int) func(any) error {
+	return func(answer any) error {
+		if len(answer.(string)) < n {
+			return errTooShort
+		}
+		return nil
+	}
+}
+
+func TestAsker_Line_Rules(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		input      string
+		rules      ask.Rules
+		want       string
+		wantErr    error
+		wantOutput string
+	}{
+		{
+			name:       "DefaultShownAndTakenForEmptyReply",
+			input:      "\n",
+			rules:      ask.Rules{Default: "alice"},
+			want:       "alice",
+			wantOutput: "Name: [alice] ",
+		}, {
+			name:       "ReplyOverridesDefault",
+			input:      "bob\n",
+			rules:      ask.Rules{Default: "alice"},
+			want:       "bob",
+			wantOutput: "Name: [alice] ",
+		}, {
+			name:       "InvalidReplyReprompts",
+			input:      "al\nalice\n",
+			rules:      ask.Rules{Validate: minLength(3)},
+			want:       "alice",
+			wantOutput: "Name: too short\nName: ",
+		}, {
+			name:       "MaxAttemptsGivesUp",
+			input:      "a\nb\nalice\n",
+			rules:      ask.Rules{Validate: minLength(3), MaxAttempts: 2},
+			wantErr:    ask.ErrTooManyAttempts,
+			wantOutput: "Name: too short\nName: too short\n",
+		}, {
+			name:       "EndOfInputAfterRejectionReportsRejection",
+			input:      "a\n",
+			rules:      ask.Rules{Validate: minLength(3)},
+			wantErr:    errTooShort,
+			wantOutput: "Name: too short\nName: ",
+		}, {
+			name:       "QuestionMarkShowsHelp",
+			input:      "?\nalice\n",
+			rules:      ask.Rules{Help: "Your given name.", MaxAttempts: 1},
+			want:       "alice",
+			wantOutput: "Name: [? for help] Your given name.\nName: [? for help] ",
+		}, {
+			name:       "QuestionMarkWithoutHelpIsAnAnswer",
+			input:      "?\n",
+			want:       "?",
+			wantOutput: "Name: ",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			out := &bytes.Buffer{}
+			sut := &ask.Asker{Out: out, In: strings.NewReader(tc.input)}
+
+			// Act
+			got, err := sut.Line(context.Background(), "Name: ", tc.rules)
+
+			// Assert
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("Asker.Line(...) error got %v, want %v", got, want)
+			}
+			if want := tc.want; got != want {
+				t.Errorf("Asker.Line(...) got %q, want %q", got, want)
+			}
+			if got, want := out.String(), tc.wantOutput; got != want {
+				t.Errorf("Asker.Line(...) output got %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestAsker_Value_Rules(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		input       string
+		rules       ask.Rules
+		want        int
+		wantErr     error
+		wantPrompts int
+	}{
+		{
+			name:        "DefaultForEmptyReply",
+			input:       "\n",
+			rules:       ask.Rules{Default: 8080},
+			want:        8080,
+			wantPrompts: 1,
+		}, {
+			name:        "UndecodableReplyReprompts",
+			input:       "http\n443\n",
+			want:        443,
+			wantPrompts: 2,
+		}, {
+			name:  "ValidatesDecodedValue",
+			input: "70000\n443\n",
+			rules: ask.Rules{Validate: func(answer any) error {
+				if answer.(int) > 65535 {
+					return errors.New("port out of range")
+				}
+				return nil
+			}},
+			want:        443,
+			wantPrompts: 2,
+		}, {
+			name:        "FailureLeavesValueUnchanged",
+			input:       "http\n",
+			rules:       ask.Rules{MaxAttempts: 1},
+			want:        -1,
+			wantErr:     strconv.ErrSyntax,
+			wantPrompts: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			out := &bytes.Buffer{}
+			sut := &ask.Asker{Out: out, In: strings.NewReader(tc.input)}
+			port := -1
+
+			// Act
+			err := sut.Value(context.Background(), "Port: ", &port, tc.rules)
+
+			// Assert
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("Asker.Value(...) error got %v, want %v", got, want)
+			}
+			if got, want := port, tc.want; got != want {
+				t.Errorf("Asker.Value(...) value got %d, want %d", got, want)
+			}
+			if got, want := strings.Count(out.String(), "Port: "), tc.wantPrompts; got != want {
+				t.Errorf("Asker.Value(...) prompt count got %d, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestAsker_Value_UnassignableDefault_Panics(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	sut := &ask.Asker{Out: io.Discard, In: strings.NewReader("\n")}
+	var port int
+
+	// Act
+	panicked := func() (panicked bool) {
+		defer func() { panicked = recover() != nil }()
+		_ = sut.Value(context.Background(), "Port: ", &port, ask.Rules{Default: "8080"})
+		return false
+	}()
+
+	// Assert
+	if got, want := panicked, true; got != want {
+		t.Errorf("Asker.Value(...) panicked = %t, want %t", got, want)
+	}
+}
+
+func TestAsker_Confirm_Rules(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		input      string
+		rules      ask.Rules
+		want       bool
+		wantErr    error
+		wantPrompt string
+	}{
+		{
+			name:       "DefaultYes",
+			input:      "\n",
+			rules:      ask.Rules{Default: true},
+			want:       true,
+			wantPrompt: "Continue? [Y/n]: ",
+		}, {
+			name:       "DefaultNo",
+			input:      "\n",
+			rules:      ask.Rules{Default: false},
+			want:       false,
+			wantPrompt: "Continue? [y/N]: ",
+		}, {
+			name:       "ReplyOverridesDefault",
+			input:      "y\n",
+			rules:      ask.Rules{Default: false},
+			want:       true,
+			wantPrompt: "Continue? [y/N]: ",
+		}, {
+			name:       "EmptyWithoutDefaultIsRejected",
+			input:      "\n\n",
+			rules:      ask.Rules{MaxAttempts: 2},
+			wantErr:    ask.ErrTooManyAttempts,
+			wantPrompt: "Continue? [y/n]: ",
+		}, {
+			name:       "HelpHint",
+			input:      "n\n",
+			rules:      ask.Rules{Help: "Deploys to production."},
+			want:       false,
+			wantPrompt: "Continue? [y/n] [? for help]: ",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			out := &bytes.Buffer{}
+			sut := &ask.Asker{Out: out, In: strings.NewReader(tc.input)}
+
+			// Act
+			got, err := sut.Confirm(context.Background(), "Continue?", tc.rules)
+
+			// Assert
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("Asker.Confirm(...) error got %v, want %v", got, want)
+			}
+			if want := tc.want; got != want {
+				t.Errorf("Asker.Confirm(...) got %t, want %t", got, want)
+			}
+			if got, want := out.String(), tc.wantPrompt; !strings.HasPrefix(got, want) {
+				t.Errorf("Asker.Confirm(...) output got %q, want prefix %q", got, want)
+			}
+		})
+	}
+}
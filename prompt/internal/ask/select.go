@@ -0,0 +1,402 @@
+package ask
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/bitwizeshift/go-cli/internal/lineedit"
+	"github.com/bitwizeshift/go-cli/internal/template/tag"
+	"github.com/bitwizeshift/go-cli/internal/term"
+	"github.com/bitwizeshift/go-cli/internal/term/cursor"
+)
+
+// ErrNoOptions is returned by the selection prompts when given no options to
+// choose from.
+var ErrNoOptions = errors.New("no options to choose from")
+
+// defaultPageSize is the number of options a menu shows at once when the
+// terminal's height is unknown.
+const defaultPageSize = 10
+
+// Select writes prompt and a menu of options, and returns the index of the
+// option chosen.
+//
+// On an interactive terminal the menu is navigated with the arrow, Page Up,
+// and Page Down keys, and typing filters it to the options containing the text
+// typed. Otherwise the options are listed by number and the reply is read as a
+// number, re-prompting until it names an option. It returns [ErrNoOptions] when
+// options is empty, [ErrInterrupted] on Ctrl-C/Ctrl-D, or ctx.Err().
+func (a *Asker) Select(ctx context.Context, prompt string, options []string) (int, error) {
+	chosen, err := a.choose(ctx, &menu{prompt: prompt, options: options, match: containsMatch})
+	if err != nil {
+		return -1, err
+	}
+	return chosen[0], nil
+}
+
+// MultiSelect writes prompt and a menu of options, and returns the indices of
+// the options chosen, in ascending order.
+//
+// It behaves as [Asker.Select], except that Space toggles the option under the
+// cursor and Enter accepts every option toggled on. Without an interactive
+// terminal, the reply is read as a list of numbers separated by commas or
+// spaces; an empty reply chooses nothing.
+func (a *Asker) MultiSelect(ctx context.Context, prompt string, options []string) ([]int, error) {
+	return a.choose(ctx, &menu{prompt: prompt, options: options, match: containsMatch, multi: true})
+}
+
+// FuzzySelect behaves as [Asker.Select], except that typing filters the menu
+// to the options containing the text typed as a subsequence, ranked by how
+// closely they match and with the matching characters highlighted.
+func (a *Asker) FuzzySelect(ctx context.Context, prompt string, options []string) (int, error) {
+	chosen, err := a.choose(ctx, &menu{prompt: prompt, options: options, match: fuzzyMatch})
+	if err != nil {
+		return -1, err
+	}
+	return chosen[0], nil
+}
+
+// choose runs m interactively when the terminal allows it, and as a numbered
+// list otherwise.
+func (a *Asker) choose(ctx context.Context, m *menu) (_ []int, err error) {
+	if len(m.options) == 0 {
+		return nil, ErrNoOptions
+	}
+	restore, rawErr := a.EchoDisabler.DisableEcho(a.Out)
+	if rawErr != nil {
+		return a.chooseNumbered(ctx, m)
+	}
+	defer func() {
+		if rerr := restore(); rerr != nil && err == nil {
+			err = rerr
+		}
+	}()
+	m.chosen = make([]bool, len(m.options))
+	m.page = defaultPageSize
+	if rows := term.Rows(a.Sizer, a.Out); rows > 0 {
+		// Leave room for the prompt and hint lines around the options.
+		m.page = max(rows-2, 1)
+	}
+	m.refilter()
+	return a.runMenu(ctx, m)
+}
+
+// runMenu draws m and applies keystrokes to it until a choice is made.
+func (a *Asker) runMenu(ctx context.Context, m *menu) ([]int, error) {
+	// Keys are read one at a time, as the menu asks for them, so the input
+	// after the Enter that closes the menu is left for whatever reads next.
+	reader := bufio.NewReader(a.In)
+	w := a.writer()
+	drawn := 0
+	draw := func(lines []string) {
+		_, _ = io.WriteString(w, cursor.CursorUp(drawn-1)+"\r"+cursor.ClearDown+strings.Join(lines, "\r\n"))
+		_ = w.Flush()
+		drawn = len(lines)
+	}
+	for {
+		draw(m.render())
+		k, r, err := lineedit.ReadKeyContext(ctx, reader)
+		if err != nil {
+			draw(nil)
+			return nil, err
+		}
+		switch k {
+		case lineedit.KeyInterrupt, lineedit.KeyEOF:
+			draw(nil)
+			return nil, ErrInterrupted
+		case lineedit.KeyEnter:
+			if chosen, ok := m.result(); ok {
+				draw([]string{m.summary(chosen)})
+				_, _ = io.WriteString(a.Out, "\r\n")
+				return chosen, nil
+			}
+		default:
+			m.apply(k, r)
+		}
+	}
+}
+
+// chooseNumbered lists the options of m by number and reads the chosen
+// numbers, re-prompting until the reply is valid.
+func (a *Asker) chooseNumbered(ctx context.Context, m *menu) ([]int, error) {
+	var sb strings.Builder
+	sb.WriteString(m.prompt + "\n")
+	for i, option := range m.options {
+		fmt.Fprintf(&sb, "  %d) %s\n", i+1, option)
+	}
+	if _, err := io.WriteString(a.Out, sb.String()); err != nil {
+		return nil, err
+	}
+	ask := fmt.Sprintf("Choose an option [1-%d]: ", len(m.options))
+	if m.multi {
+		ask = fmt.Sprintf("Choose options [1-%d], separated by commas: ", len(m.options))
+	}
+	// The terminal could not enter raw mode, so replies are read whole.
+	editor := &lineedit.Editor{Out: a.Out, In: a.In}
+	for {
+		line, err := editor.ReadLineContext(ctx, ask)
+		if err != nil {
+			return nil, err
+		}
+		if chosen, ok := parseNumbers(line, len(m.options)); ok && (m.multi || len(chosen) == 1) {
+			return chosen, nil
+		}
+	}
+}
+
+// parseNumbers parses line as 1-based option numbers separated by commas or
+// whitespace, returning the 0-based indices they name in ascending order. It
+// reports false if any number does not name one of n options.
+func parseNumbers(line string, n int) ([]int, bool) {
+	fields := strings.FieldsFunc(line, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+	chosen := []int{}
+	for _, field := range fields {
+		number, err := strconv.Atoi(field)
+		if err != nil || number < 1 || number > n {
+			return nil, false
+		}
+		chosen = append(chosen, number-1)
+	}
+	slices.Sort(chosen)
+	return slices.Compact(chosen), true
+}
+
+// matchFunc reports whether option matches filter, the positions of the runes
+// of option that matched, and a score ranking the match against others: the
+// higher the score, the closer the match.
+type matchFunc func(option, filter string) (positions []int, score int, ok bool)
+
+// choice is an option shown by a menu, with the positions of its runes that
+// matched the filter.
+type choice struct {
+	index     int
+	positions []int
+	score     int
+}
+
+// menu is the state of an interactive selection: the options, the filter
+// typed so far, and the cursor within the options that match it.
+type menu struct {
+	prompt  string
+	options []string
+	match   matchFunc
+	multi   bool
+	page    int
+
+	filter  []rune
+	visible []choice
+	cursor  int
+	top     int
+	chosen  []bool
+}
+
+// apply applies the keystroke k, with r the rune typed for
+// [lineedit.KeyRune].
+func (m *menu) apply(k lineedit.Key, r rune) {
+	switch k {
+	case lineedit.KeyUp:
+		m.move(-1, true)
+	case lineedit.KeyDown:
+		m.move(1, true)
+	case lineedit.KeyPageUp:
+		m.move(-m.page, false)
+	case lineedit.KeyPageDown:
+		m.move(m.page, false)
+	case lineedit.KeyHome:
+		m.move(-len(m.visible), false)
+	case lineedit.KeyEnd:
+		m.move(len(m.visible), false)
+	case lineedit.KeyRune:
+		if m.multi && r == ' ' {
+			if len(m.visible) > 0 {
+				index := m.visible[m.cursor].index
+				m.chosen[index] = !m.chosen[index]
+			}
+			return
+		}
+		m.filter = append(m.filter, r)
+		m.refilter()
+	case lineedit.KeyBackspace:
+		if len(m.filter) > 0 {
+			m.filter = m.filter[:len(m.filter)-1]
+			m.refilter()
+		}
+	case lineedit.KeyKillToStart, lineedit.KeyKillWord:
+		m.filter = nil
+		m.refilter()
+	}
+}
+
+// move moves the cursor by delta options, wrapping past either end when wrap
+// is set and stopping at it otherwise, and scrolls to keep the cursor shown.
+func (m *menu) move(delta int, wrap bool) {
+	n := len(m.visible)
+	if n == 0 {
+		return
+	}
+	if wrap {
+		m.cursor = ((m.cursor+delta)%n + n) % n
+	} else {
+		m.cursor = min(max(m.cursor+delta, 0), n-1)
+	}
+	if m.cursor < m.top {
+		m.top = m.cursor
+	} else if m.cursor >= m.top+m.page {
+		m.top = m.cursor - m.page + 1
+	}
+}
+
+// refilter recomputes the options matching the filter, ranked by score, and
+// returns the cursor to the first of them.
+func (m *menu) refilter() {
+	filter := string(m.filter)
+	m.visible = m.visible[:0]
+	for i, option := range m.options {
+		if positions, score, ok := m.match(option, filter); ok {
+			m.visible = append(m.visible, choice{index: i, positions: positions, score: score})
+		}
+	}
+	slices.SortStableFunc(m.visible, func(a, b choice) int {
+		return cmp.Compare(b.score, a.score)
+	})
+	m.cursor, m.top = 0, 0
+}
+
+// result returns the indices chosen when Enter is pressed, and reports false
+// when there is nothing to choose.
+func (m *menu) result() ([]int, bool) {
+	if !m.multi {
+		if len(m.visible) == 0 {
+			return nil, false
+		}
+		return []int{m.visible[m.cursor].index}, true
+	}
+	chosen := []int{}
+	for i, on := range m.chosen {
+		if on {
+			chosen = append(chosen, i)
+		}
+	}
+	return chosen, true
+}
+
+// render returns the lines of richtext markup that draw the menu.
+func (m *menu) render() []string {
+	lines := []string{tag.Themed("label", tag.Raw(m.prompt)) + " " + tag.Raw(string(m.filter))}
+	end := min(m.top+m.page, len(m.visible))
+	for i, c := range m.visible[m.top:end] {
+		line := "  "
+		if m.multi && m.chosen[c.index] {
+			line += "[x] "
+		} else if m.multi {
+			line += "[ ] "
+		}
+		line += highlight(m.options[c.index], c.positions)
+		if m.top+i == m.cursor {
+			line = tag.Themed("selected", ">"+line[1:])
+		}
+		lines = append(lines, line)
+	}
+	if len(m.visible) == 0 {
+		lines = append(lines, tag.Themed("quote", "  no matching options"))
+	}
+	hint := "↑/↓ to move, enter to choose, type to filter"
+	if m.multi {
+		hint = "↑/↓ to move, space to toggle, enter to accept, type to filter"
+	}
+	if len(m.visible) > m.page {
+		hint = fmt.Sprintf("%d-%d of %d; %s", m.top+1, end, len(m.visible), hint)
+	}
+	return append(lines, tag.Themed("quote", hint))
+}
+
+// summary returns the markup left in place of the menu once chosen is
+// accepted.
+func (m *menu) summary(chosen []int) string {
+	names := make([]string, len(chosen))
+	for i, index := range chosen {
+		names[i] = m.options[index]
+	}
+	return tag.Themed("label", tag.Raw(m.prompt)) + " " + tag.Themed("value", tag.Raw(strings.Join(names, ", ")))
+}
+
+// highlight returns the markup for option, with the runes at positions
+// styled as matches.
+func highlight(option string, positions []int) string {
+	if len(positions) == 0 {
+		return tag.Raw(option)
+	}
+	var sb strings.Builder
+	runes := []rune(option)
+	start := 0
+	for _, p := range positions {
+		sb.WriteString(tag.Raw(string(runes[start:p])))
+		sb.WriteString(tag.Themed("match", tag.Raw(string(runes[p]))))
+		start = p + 1
+	}
+	sb.WriteString(tag.Raw(string(runes[start:])))
+	return sb.String()
+}
+
+// containsMatch matches options containing filter, ignoring case. Every match
+// scores the same, so options keep their order.
+func containsMatch(option, filter string) ([]int, int, bool) {
+	if filter == "" {
+		return nil, 0, true
+	}
+	runes := []rune(strings.ToLower(option))
+	needle := []rune(strings.ToLower(filter))
+	for i := 0; i+len(needle) <= len(runes); i++ {
+		if slices.Equal(runes[i:i+len(needle)], needle) {
+			positions := make([]int, len(needle))
+			for j := range positions {
+				positions[j] = i + j
+			}
+			return positions, 0, true
+		}
+	}
+	return nil, 0, false
+}
+
+// fuzzyMatch matches options containing the runes of filter in order,
+// ignoring case. A match scores higher for runes that are adjacent to the
+// previous match or begin a word, and lower for each rune skipped.
+func fuzzyMatch(option, filter string) ([]int, int, bool) {
+	if filter == "" {
+		return nil, 0, true
+	}
+	runes := []rune(strings.ToLower(option))
+	needle := []rune(strings.ToLower(filter))
+	positions := make([]int, 0, len(needle))
+	score := 0
+	for i, r := range runes {
+		if len(positions) == len(needle) {
+			break
+		}
+		if r != needle[len(positions)] {
+			score--
+			continue
+		}
+		switch {
+		case len(positions) > 0 && positions[len(positions)-1] == i-1:
+			score += 8
+		case i == 0 || !unicode.IsLetter(runes[i-1]) && !unicode.IsDigit(runes[i-1]):
+			score += 6
+		}
+		positions = append(positions, i)
+	}
+	if len(positions) < len(needle) {
+		return nil, 0, false
+	}
+	return positions, score, true
+}
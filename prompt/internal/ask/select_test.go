@@ -0,0 +1,390 @@
+package ask_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/bitwizeshift/go-cli/internal/term"
+	"github.com/bitwizeshift/go-cli/internal/term/termtest"
+	"github.com/bitwizeshift/go-cli/prompt/internal/ask"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+// rowSizer is a [term.Sizer] reporting a fixed number of rows.
+type rowSizer int
+
+func (rowSizer) Columns(io.Writer) int { return 80 }
+func (s rowSizer) Rows(io.Writer) int  { return int(s) }
+
+var _ term.RowSizer = rowSizer(0)
+
+var greek = []string{"alpha", "beta", "gamma", "delta", "epsilon"}
+
+func TestAsker_Select_Raw(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		input   string
+		rows    int
+		options []string
+		want    int
+		wantErr error
+	}{
+		{
+			name:    "EnterChoosesFirst",
+			input:   "\r",
+			options: greek,
+			want:    0,
+		}, {
+			name:    "DownMovesCursor",
+			input:   "\x1b[B\x1b[B\r",
+			options: greek,
+			want:    2,
+		}, {
+			name:    "UpWrapsToLast",
+			input:   "\x1b[A\r",
+			options: greek,
+			want:    4,
+		}, {
+			name:    "TypingFilters",
+			input:   "ta\x1b[B\r",
+			options: greek,
+			want:    3,
+		}, {
+			name:    "FilterIgnoresCase",
+			input:   "GAM\r",
+			options: greek,
+			want:    2,
+		}, {
+			name:    "EnterWithoutMatchesIsIgnored",
+			input:   "zz\r\x7f\x7f\r",
+			options: greek,
+			want:    0,
+		}, {
+			name:    "PageDownMovesByTerminalHeight",
+			input:   "\x1b[6~\r",
+			rows:    4,
+			options: greek,
+			want:    2,
+		}, {
+			name:    "EndMovesToLast",
+			input:   "\x1b[F\r",
+			options: greek,
+			want:    4,
+		}, {
+			name:    "Interrupt",
+			input:   "\x03",
+			options: greek,
+			want:    -1,
+			wantErr: ask.ErrInterrupted,
+		}, {
+			name:    "NoOptions",
+			input:   "\r",
+			want:    -1,
+			wantErr: ask.ErrNoOptions,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			sut := &ask.Asker{
+				Out:          io.Discard,
+				In:           strings.NewReader(tc.input),
+				EchoDisabler: termtest.NoOpEchoDisabler(),
+				Sizer:        rowSizer(tc.rows),
+			}
+
+			// Act
+			got, err := sut.Select(context.Background(), "Pick:", tc.options)
+
+			// Assert
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("Asker.Select(...) error got %v, want %v", got, want)
+			}
+			if want := tc.want; got != want {
+				t.Errorf("Asker.Select(...) got %d, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestAsker_Select_Raw_ShowsChoice(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	out := &bytes.Buffer{}
+	sut := &ask.Asker{
+		Out:          out,
+		In:           strings.NewReader("\x1b[B\r"),
+		EchoDisabler: termtest.NoOpEchoDisabler(),
+	}
+
+	// Act
+	_, err := sut.Select(context.Background(), "Pick:", greek)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Asker.Select(...) error got %v, want nil", err)
+	}
+	lines := strings.Split(out.String(), "\x1b[0J")
+	if got, want := lines[len(lines)-1], "Pick: beta\r\n"; got != want {
+		t.Errorf("Asker.Select(...) final render got %q, want %q", got, want)
+	}
+}
+
+func TestAsker_Select_Raw_LeavesInputForNextPrompt(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	sut := &ask.Asker{
+		Out:          &bytes.Buffer{},
+		In:           bufio.NewReader(strings.NewReader("\x1b[B\rhello\r")),
+		EchoDisabler: termtest.NoOpEchoDisabler(),
+	}
+
+	// Act
+	chosen, selectErr := sut.Select(t.Context(), "Pick:", greek)
+	line, lineErr := sut.Line(t.Context(), "Name: ", ask.Rules{})
+
+	// Assert
+	if got, want := selectErr, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Fatalf("Asker.Select(...) error got %v, want %v", got, want)
+	}
+	if got, want := lineErr, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Fatalf("Asker.Line(...) error got %v, want %v", got, want)
+	}
+	if got, want := chosen, 1; got != want {
+		t.Errorf("Asker.Select(...) got %d, want %d", got, want)
+	}
+	if got, want := line, "hello"; got != want {
+		t.Errorf("Asker.Line(...) got %q, want %q", got, want)
+	}
+}
+
+func TestAsker_MultiSelect_Raw(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		input string
+		want  []int
+	}{
+		{
+			name:  "SpaceToggles",
+			input: " \x1b[B\x1b[B \r",
+			want:  []int{0, 2},
+		}, {
+			name:  "ToggleTwiceClears",
+			input: "  \x1b[B \r",
+			want:  []int{1},
+		}, {
+			name:  "ToggledOptionsSurviveFilter",
+			input: " eps \r",
+			want:  []int{0, 4},
+		}, {
+			name:  "NothingToggled",
+			input: "\r",
+			want:  []int{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			sut := &ask.Asker{
+				Out:          io.Discard,
+				In:           strings.NewReader(tc.input),
+				EchoDisabler: termtest.NoOpEchoDisabler(),
+			}
+
+			// Act
+			got, err := sut.MultiSelect(context.Background(), "Pick:", greek)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Asker.MultiSelect(...) error got %v, want nil", err)
+			}
+			if want := tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Asker.MultiSelect(...) got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestAsker_FuzzySelect_Raw(t *testing.T) {
+	t.Parallel()
+
+	options := []string{"config-set", "cs-fix", "cancel", "checksum"}
+	testCases := []struct {
+		name  string
+		input string
+		want  int
+	}{
+		{
+			name:  "RanksClosestMatchFirst",
+			input: "cs\r",
+			want:  1,
+		}, {
+			name:  "MatchesSubsequence",
+			input: "cnl\r",
+			want:  2,
+		}, {
+			name:  "NavigatesRankedMatches",
+			input: "cs\x1b[B\x1b[B\r",
+			want:  3,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			sut := &ask.Asker{
+				Out:          io.Discard,
+				In:           strings.NewReader(tc.input),
+				EchoDisabler: termtest.NoOpEchoDisabler(),
+			}
+
+			// Act
+			got, err := sut.FuzzySelect(context.Background(), "Pick:", options)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Asker.FuzzySelect(...) error got %v, want nil", err)
+			}
+			if want := tc.want; got != want {
+				t.Errorf("Asker.FuzzySelect(...) got %d (%q), want %d (%q)", got, options[got], want, options[want])
+			}
+		})
+	}
+}
+
+func TestAsker_Select_NotTerminal(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		input       string
+		want        int
+		wantErr     error
+		wantPrompts int
+	}{
+		{
+			name:        "ReadsNumber",
+			input:       "2\n",
+			want:        1,
+			wantPrompts: 1,
+		}, {
+			name:        "RepromptsOnInvalid",
+			input:       "beta\n9\n\n3\n",
+			want:        2,
+			wantPrompts: 4,
+		}, {
+			name:        "RepromptsOnSeveral",
+			input:       "1,2\n1\n",
+			want:        0,
+			wantPrompts: 2,
+		}, {
+			name:        "EndOfInput",
+			input:       "",
+			want:        -1,
+			wantErr:     io.EOF,
+			wantPrompts: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			out := &bytes.Buffer{}
+			sut := &ask.Asker{
+				Out:          out,
+				In:           strings.NewReader(tc.input),
+				EchoDisabler: termtest.ErrEchoDisabler(term.ErrNotDescriptor),
+			}
+
+			// Act
+			got, err := sut.Select(context.Background(), "Pick:", greek)
+
+			// Assert
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("Asker.Select(...) error got %v, want %v", got, want)
+			}
+			if want := tc.want; got != want {
+				t.Errorf("Asker.Select(...) got %d, want %d", got, want)
+			}
+			if got, want := strings.Count(out.String(), "Choose an option [1-5]: "), tc.wantPrompts; got != want {
+				t.Errorf("Asker.Select(...) prompt count got %d, want %d", got, want)
+			}
+			if got, want := strings.Count(out.String(), "  2) beta\n"), 1; got != want {
+				t.Errorf("Asker.Select(...) listed option %d times, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestAsker_MultiSelect_NotTerminal(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		input string
+		want  []int
+	}{
+		{
+			name:  "CommaSeparated",
+			input: "3,1\n",
+			want:  []int{0, 2},
+		}, {
+			name:  "SpaceSeparatedWithRepeats",
+			input: "2 4 2\n",
+			want:  []int{1, 3},
+		}, {
+			name:  "EmptyChoosesNothing",
+			input: "\n",
+			want:  []int{},
+		}, {
+			name:  "RepromptsOnInvalid",
+			input: "1,x\n5\n",
+			want:  []int{4},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			sut := &ask.Asker{
+				Out:          io.Discard,
+				In:           strings.NewReader(tc.input),
+				EchoDisabler: termtest.ErrEchoDisabler(term.ErrNotDescriptor),
+			}
+
+			// Act
+			got, err := sut.MultiSelect(context.Background(), "Pick:", greek)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Asker.MultiSelect(...) error got %v, want nil", err)
+			}
+			if want := tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Asker.MultiSelect(...) got %v, want %v", got, want)
+			}
+		})
+	}
+}
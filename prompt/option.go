@@ -0,0 +1,118 @@
+package prompt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bitwizeshift/go-cli/prompt/internal/ask"
+)
+
+// ErrTooManyAttempts is returned when more replies to a prompt are rejected
+// than [MaxAttempts] allows.
+var ErrTooManyAttempts = ask.ErrTooManyAttempts
+
+// Option configures which replies to [Prompter.Line], [Prompter.Value], and
+// [Prompter.Confirm] are accepted, and how they are edited. A rejected reply is
+// reported beneath the prompt, and the prompt is asked again.
+type Option interface {
+	apply(*settings)
+}
+
+type option func(*settings)
+
+func (o option) apply(s *settings) { o(s) }
+
+// settings holds the options a prompt is asked under: the rules its reply is
+// accepted under, and the name its history is kept under.
+type settings struct {
+	ask.Rules
+
+	history string
+}
+
+// Default sets the answer taken when the reply is empty, shown in brackets
+// after the prompt as "[v]". For [Prompter.Confirm], v must be a bool, and is
+// shown by capitalising the answer it stands for, as in "[Y/n]". For
+// [Prompter.Value], v must be assignable to the value being prompted for.
+func Default(v any) Option {
+	return option(func(r *settings) {
+		r.Default = v
+	})
+}
+
+// Validate checks each decoded answer with fn, rejecting it when fn returns an
+// error. The error's message is shown before the prompt is asked again. T must
+// be the type of the answer: string for [Prompter.Line], bool for
+// [Prompter.Confirm], or the pointed-to type for [Prompter.Value]; any other
+// type panics when an answer is checked.
+func Validate[T any](fn func(T) error) Option {
+	return option(func(r *settings) {
+		r.Validate = func(answer any) error {
+			v, ok := answer.(T)
+			if !ok {
+				var want T
+				panic(fmt.Sprintf("prompt: Validate for %T given a %T answer", want, answer))
+			}
+			return fn(v)
+		}
+	})
+}
+
+// MaxAttempts limits the replies read to n, after which the prompt fails with
+// [ErrTooManyAttempts]. Without it, the prompt is asked until it is answered
+// or input ends. It panics if n is less than 1.
+func MaxAttempts(n int) Option {
+	if n < 1 {
+		panic(fmt.Sprintf("prompt: MaxAttempts(%d) must be at least 1", n))
+	}
+	return option(func(r *settings) {
+		r.MaxAttempts = n
+	})
+}
+
+// Help sets text shown when the reply is "?", after which the prompt is asked
+// again. The prompt is followed by a "[? for help]" hint.
+func Help(text string) Option {
+	return option(func(r *settings) {
+		r.Help = text
+	})
+}
+
+// History recalls earlier replies to prompts sharing name with the Up and Down
+// keys while a reply is edited on a terminal, and records the accepted reply
+// for the next. Histories are kept in the application's data storage, so they
+// persist between runs; outside a running command, where there is no storage,
+// replies are recalled only within the prompt.
+//
+// name names the file the history is kept in, so it panics if name is empty,
+// is "." or "..", or holds a "/" or "\" that would place the file outside
+// the directory of histories.
+func History(name string) Option {
+	if name == "" {
+		panic("prompt: History name must not be empty")
+	}
+	if name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		panic(fmt.Sprintf("prompt: History name %q must be a plain file name", name))
+	}
+	return option(func(r *settings) {
+		r.history = name
+	})
+}
+
+// Complete completes the word before the cursor with Tab while a reply is
+// edited on a terminal. fn is given the text before the cursor, and returns
+// candidates that each replace its last word in full.
+func Complete(fn func(head string) []string) Option {
+	return option(func(r *settings) {
+		r.Complete = fn
+	})
+}
+
+// newSettings resolves options into the settings a prompt is asked under.
+func newSettings(options []Option) settings {
+	var s settings
+	for _, opt := range options {
+		opt.apply(&s)
+	}
+	return s
+}
@@ -0,0 +1,62 @@
+package prompt_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bitwizeshift/go-cli/prompt"
+)
+
+func TestHistory_InvalidName_Panics(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		history string
+		substr  string
+	}{
+		{name: "Empty", history: "", substr: "must not be empty"},
+		{name: "Dot", history: ".", substr: "plain file name"},
+		{name: "DotDot", history: "..", substr: "plain file name"},
+		{name: "Slash", history: "../config", substr: "plain file name"},
+		{name: "Backslash", history: `..\config`, substr: "plain file name"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			recovered := recoverPanic(func() {
+				prompt.History(tc.history)
+			})
+
+			// Assert
+			message, _ := recovered.(string)
+			if got, want := strings.Contains(message, tc.substr), true; got != want {
+				t.Fatalf("recovered panic = %q, want to contain %q", message, tc.substr)
+			}
+		})
+	}
+}
+
+func TestHistory_PlainName_DoesNotPanic(t *testing.T) {
+	t.Parallel()
+
+	// Act
+	recovered := recoverPanic(func() {
+		prompt.History("deploy.target")
+	})
+
+	// Assert
+	if recovered != nil {
+		t.Fatalf("recovered panic = %v, want nil", recovered)
+	}
+}
+
+// recoverPanic runs fn and returns the value it panicked with, or nil.
+func recoverPanic(fn func()) (recovered any) {
+	defer func() { recovered = recover() }()
+	fn()
+	return nil
+}
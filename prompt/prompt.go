@@ -4,44 +4,235 @@ import (
 	"context"
 	"io"
 	"os"
+	"strings"
 
+	"github.com/bitwizeshift/go-cli/internal/clictx"
+	"github.com/bitwizeshift/go-cli/internal/interact"
+	"github.com/bitwizeshift/go-cli/internal/term"
 	"github.com/bitwizeshift/go-cli/prompt/internal/ask"
+	"github.com/bitwizeshift/go-cli/richtext"
 )
 
+// ErrNonInteractive is returned by every prompt made while the running command
+// may not interact with the user: when input was disabled with --no-input, when
+// running under continuous integration, or when standard input or the error
+// stream prompts are written to is not a terminal. It is returned immediately,
+// rather than waiting on input that will never come.
+var ErrNonInteractive = interact.ErrNonInteractive
+
 // Prompter reads answers to interactive prompts, writing prompts and echoed
 // characters to Out and reading replies from In. Secret answers are masked with
 // HiddenChar.
+//
+// Prompt text may hold richtext markup, which is rendered, like selection
+// menus, with Theme. When Theme is nil, the theme of Out is used if it is a
+// [richtext.Writer], and [richtext.DefaultTheme] otherwise.
 type Prompter struct {
 	Out io.Writer
 	In  io.Reader
 
 	HiddenChar rune
+	Theme      *richtext.Theme
+
+	// scripted reports that In and Out are a scripted conversation rather than
+	// a terminal, so replies are read as whole lines.
+	scripted bool
 }
 
-// Line writes prompt to Out and returns the entered line.
-func (p *Prompter) Line(ctx context.Context, prompt string) (string, error) {
-	return p.asker().Line(ctx, prompt)
+// From returns the [Prompter] for the command running under ctx. It writes to
+// the command's error stream and reads from its input stream, so prompts are
+// styled like the rest of the command's output and can be captured by tests.
+// When ctx carries a scripted conversation, as installed by the prompttest
+// package, the Prompter converses through it instead.
+func From(ctx context.Context) *Prompter {
+	if conv := clictx.Conversation(ctx); conv != nil {
+		return &Prompter{Out: conv, In: conv, HiddenChar: DefaultPrompter.HiddenChar, scripted: true}
+	}
+	_, stderr := clictx.Writers(ctx)
+	return &Prompter{Out: stderr, In: clictx.Input(ctx), HiddenChar: DefaultPrompter.HiddenChar}
+}
+
+// Line writes prompt to Out and returns the entered line, accepted under
+// options. When In and Out are a terminal, the line is edited as it is typed:
+// the arrow keys, Home and End move the cursor, Ctrl-A, Ctrl-E, Ctrl-K, Ctrl-U
+// and Ctrl-W move and delete as in a shell, and Up and Down recall replies
+// kept by [History]. Otherwise whole lines are read as they arrive.
+func (p *Prompter) Line(ctx context.Context, prompt string, options ...Option) (string, error) {
+	if err := interactive(ctx); err != nil {
+		return "", err
+	}
+	var line string
+	err := withHistory(ctx, newSettings(options), func(rules ask.Rules) (err error) {
+		line, err = p.asker().Line(ctx, p.render(prompt), rules)
+		return err
+	})
+	return line, err
 }
 
 // Confirm writes prompt and returns the parsed yes/no answer, re-prompting until
-// the reply is recognised.
-func (p *Prompter) Confirm(ctx context.Context, prompt string) (bool, error) {
-	return p.asker().Confirm(ctx, prompt)
+// the reply is recognised. An empty reply takes the answer set by [Default],
+// when there is one. When the running command was given --yes, Confirm
+// returns true without prompting.
+func (p *Prompter) Confirm(ctx context.Context, prompt string, options ...Option) (bool, error) {
+	if clictx.InteractionPolicy(ctx).AssumeYes {
+		return true, nil
+	}
+	if err := interactive(ctx); err != nil {
+		return false, err
+	}
+	return p.asker().Confirm(ctx, p.render(prompt), newSettings(options).Rules)
 }
 
 // Secret writes prompt and reads a reply without revealing it, masking each rune
 // with HiddenChar. It errors when In/Out is not an interactive terminal.
 func (p *Prompter) Secret(ctx context.Context, prompt string) (string, error) {
-	return p.asker().Secret(ctx, prompt)
+	if err := interactive(ctx); err != nil {
+		return "", err
+	}
+	if p.scripted {
+		return p.asker().Line(ctx, p.render(prompt), ask.Rules{})
+	}
+	return p.asker().Secret(ctx, p.render(prompt))
 }
 
-// Value writes prompt, reads one line, and unmarshals it into v.
-func (p *Prompter) Value(ctx context.Context, prompt string, v any) error {
-	return p.asker().Value(ctx, prompt, v)
+// Value writes prompt, reads a line, and unmarshals it into v, re-prompting
+// while the reply cannot be unmarshalled or is rejected under options. v is
+// left unchanged until a reply is accepted. The line is edited as for
+// [Prompter.Line].
+func (p *Prompter) Value(ctx context.Context, prompt string, v any, options ...Option) error {
+	if err := interactive(ctx); err != nil {
+		return err
+	}
+	return withHistory(ctx, newSettings(options), func(rules ask.Rules) error {
+		return p.asker().Value(ctx, p.render(prompt), v, rules)
+	})
 }
 
+// Select writes prompt and a menu of options, and returns the index of the
+// option chosen. On a terminal the menu is navigated with the arrow keys and
+// filtered by typing; otherwise the options are listed by number and the reply
+// is read as a number.
+func (p *Prompter) Select(ctx context.Context, prompt string, options []string) (int, error) {
+	if err := interactive(ctx); err != nil {
+		return -1, err
+	}
+	return p.asker().Select(ctx, p.render(prompt), options)
+}
+
+// MultiSelect writes prompt and a menu of options, and returns the indices of
+// the options chosen, in ascending order. On a terminal Space toggles an
+// option and Enter accepts; otherwise the reply is read as a comma-separated
+// list of numbers.
+func (p *Prompter) MultiSelect(ctx context.Context, prompt string, options []string) ([]int, error) {
+	if err := interactive(ctx); err != nil {
+		return nil, err
+	}
+	return p.asker().MultiSelect(ctx, p.render(prompt), options)
+}
+
+// FuzzySelect behaves as [Prompter.Select], except that typing filters the
+// menu by fuzzy match, ranking the closest matches first.
+func (p *Prompter) FuzzySelect(ctx context.Context, prompt string, options []string) (int, error) {
+	if err := interactive(ctx); err != nil {
+		return -1, err
+	}
+	return p.asker().FuzzySelect(ctx, p.render(prompt), options)
+}
+
+// interactive returns [ErrNonInteractive] when the interaction policy of the
+// command running under ctx forbids prompting, and nil otherwise.
+func interactive(ctx context.Context) error {
+	if !clictx.InteractionPolicy(ctx).Interactive {
+		return ErrNonInteractive
+	}
+	return nil
+}
+
+// asker returns the [ask.Asker] that prompts for p. When Out is a
+// [richtext.Writer], whatever it has buffered is flushed, and the Asker writes
+// to the stream beneath it, so the escapes that redraw an edited line are not
+// taken for markup.
 func (p *Prompter) asker() *ask.Asker {
-	return ask.DefaultAsker(p.Out, p.In, p.HiddenChar)
+	if w, ok := p.Out.(*richtext.Writer); ok {
+		_ = w.Flush()
+	}
+	a := ask.DefaultAsker(underlying(p.Out), p.In, p.HiddenChar)
+	a.Theme = p.theme()
+	a.Background = p.background()
+	if p.scripted {
+		a.EchoDisabler = notTerminal{}
+	}
+	return a
+}
+
+// render returns text with its markup rendered as Out would render it: in
+// [Prompter.theme], at the colour depth and against the background of Out.
+func (p *Prompter) render(text string) string {
+	var sb strings.Builder
+	w := richtext.NewWriter(&sb, p.theme())
+	w.SetColourDepth(p.colourDepth())
+	w.SetBackground(p.background())
+	if _, err := io.WriteString(w, text); err != nil {
+		return text
+	}
+	_ = w.Flush()
+	return sb.String()
+}
+
+// colourDepth returns the colour depth of Out: the depth of a
+// [richtext.Writer], or otherwise of the stream itself.
+func (p *Prompter) colourDepth() richtext.ColourDepth {
+	if w, ok := p.Out.(*richtext.Writer); ok {
+		return w.ColourDepth()
+	}
+	return term.DefaultEnabler.ColourDepth(underlying(p.Out))
+}
+
+// background returns the background of Out that an adaptive theme is resolved
+// against: the background of a [richtext.Writer], or otherwise of the terminal
+// behind the stream. It is only detected while Out emits colour, since the
+// theme is otherwise never seen; it is resolved before a prompt reads from the
+// terminal, so the terminal's answer is not read as input.
+func (p *Prompter) background() richtext.Background {
+	if p.colourDepth() == richtext.NoColour {
+		return richtext.UnknownBackground
+	}
+	if w, ok := p.Out.(*richtext.Writer); ok {
+		return w.Background()
+	}
+	return term.DefaultBackgroundDetector.Background(underlying(p.Out))
+}
+
+// theme returns the theme prompts are styled with: Theme, the theme of Out
+// when it is a [richtext.Writer], or [richtext.DefaultTheme].
+func (p *Prompter) theme() *richtext.Theme {
+	if p.Theme != nil {
+		return p.Theme
+	}
+	if w, ok := p.Out.(*richtext.Writer); ok && w.Theme() != nil {
+		return w.Theme()
+	}
+	return richtext.DefaultTheme
+}
+
+// underlying returns the writer beneath w, following any writer that exposes a
+// Writer() io.Writer method, so that prompts reach the terminal itself.
+func underlying(w io.Writer) io.Writer {
+	for {
+		next, ok := w.(interface{ Writer() io.Writer })
+		if !ok {
+			return w
+		}
+		w = next.Writer()
+	}
+}
+
+// notTerminal is the [term.EchoDisabler] of a scripted conversation, which
+// cannot enter raw mode.
+type notTerminal struct{}
+
+func (notTerminal) DisableEcho(io.Writer) (term.RestoreFunc, error) {
+	return nil, term.ErrNotDescriptor
 }
 
 // DefaultPrompter reads from standard input and writes to standard output,
@@ -52,22 +243,47 @@ var DefaultPrompter = Prompter{
 	HiddenChar: '*',
 }
 
-// Line prompts on standard output and reads a line from standard input.
-func Line(ctx context.Context, prompt string) (string, error) {
-	return DefaultPrompter.Line(ctx, prompt)
+// Line reads a line through the [Prompter] returned by [From].
+func Line(ctx context.Context, prompt string, options ...Option) (string, error) {
+	return From(ctx).Line(ctx, prompt, options...)
 }
 
-// Confirm prompts for and reads a yes/no answer from standard input.
-func Confirm(ctx context.Context, prompt string) (bool, error) {
-	return DefaultPrompter.Confirm(ctx, prompt)
+// Confirm reads a yes/no answer through the [Prompter] returned by [From].
+func Confirm(ctx context.Context, prompt string, options ...Option) (bool, error) {
+	return From(ctx).Confirm(ctx, prompt, options...)
 }
 
-// Secret prompts for and reads a masked answer from the standard input terminal.
+// Secret reads a masked answer through the [Prompter] returned by [From].
 func Secret(ctx context.Context, prompt string) (string, error) {
-	return DefaultPrompter.Secret(ctx, prompt)
+	return From(ctx).Secret(ctx, prompt)
+}
+
+// Value reads a line through the [Prompter] returned by [From] and unmarshals
+// it into v.
+func Value(ctx context.Context, prompt string, v any, options ...Option) error {
+	return From(ctx).Value(ctx, prompt, v, options...)
+}
+
+// Editor opens template in the user's text editor through the [Prompter]
+// returned by [From].
+func Editor(ctx context.Context, template, ext string) (string, error) {
+	return From(ctx).Editor(ctx, template, ext)
+}
+
+// Select reads a choice among options through the [Prompter] returned by
+// [From].
+func Select(ctx context.Context, prompt string, options []string) (int, error) {
+	return From(ctx).Select(ctx, prompt, options)
+}
+
+// MultiSelect reads any number of choices among options through the
+// [Prompter] returned by [From].
+func MultiSelect(ctx context.Context, prompt string, options []string) ([]int, error) {
+	return From(ctx).MultiSelect(ctx, prompt, options)
 }
 
-// Value prompts for a line on standard input and unmarshals it into v.
-func Value(ctx context.Context, prompt string, v any) error {
-	return DefaultPrompter.Value(ctx, prompt, v)
+// FuzzySelect reads a choice among options, filtered by fuzzy match, through
+// the [Prompter] returned by [From].
+func FuzzySelect(ctx context.Context, prompt string, options []string) (int, error) {
+	return From(ctx).FuzzySelect(ctx, prompt, options)
 }
@@ -0,0 +1,169 @@
+package prompttest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/bitwizeshift/go-cli/internal/clictx"
+)
+
+// ErrUnexpectedPrompt is returned to a prompt that a [Conversation] does not
+// expect: one asked out of order, or after every expected prompt was asked.
+var ErrUnexpectedPrompt = errors.New("prompttest: unexpected prompt")
+
+// Conversation is a script of the prompts a test expects to be asked, in
+// order, and the reply given to each. It is built with [Expect]:
+//
+//	conv := prompttest.Expect("Name: ").Answer("bob").
+//		Expect("Continue?").Answer("y")
+//
+// Prompts converse with it as they would with a terminal that is not
+// interactive, so replies are typed as whole lines: a menu is answered with
+// the number of the option chosen, and a secret is answered in the clear.
+//
+// A Conversation is installed on a context with [WithConversation], and is
+// safe for concurrent use.
+type Conversation struct {
+	mu      sync.Mutex
+	steps   []step
+	next    int
+	asked   []string
+	shown   strings.Builder
+	output  strings.Builder
+	pending string
+}
+
+// step is one expected prompt and the reply given to it.
+type step struct {
+	prompt   string
+	reply    string
+	answered bool
+}
+
+// Expect returns a [Conversation] that expects prompt to be asked first.
+func Expect(prompt string) *Conversation {
+	return (&Conversation{}).Expect(prompt)
+}
+
+// Expect adds prompt as the next prompt c expects, and returns c. A prompt is
+// matched when the text written since the previous reply contains it, ignoring
+// surrounding whitespace, so it need not include the hints, such as "[y/n]",
+// written after it.
+//
+// A prompt that is rejected and asked again must be expected again.
+func (c *Conversation) Expect(prompt string) *Conversation {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.steps = append(c.steps, step{prompt: prompt})
+	return c
+}
+
+// Answer sets reply as the answer to the prompt last expected, and returns c.
+// A prompt left without an answer is answered with the end of input. It panics
+// if no prompt awaits an answer.
+func (c *Conversation) Answer(reply string) *Conversation {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	last := len(c.steps) - 1
+	if last < 0 || c.steps[last].answered {
+		panic(fmt.Sprintf("prompttest: Answer(%q) without a prompt to answer", reply))
+	}
+	c.steps[last].reply = reply
+	c.steps[last].answered = true
+	return c
+}
+
+// Write records p as shown to the user.
+func (c *Conversation) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.shown.Write(p)
+	c.output.Write(p)
+	return len(p), nil
+}
+
+// Read supplies the reply to the prompt shown since the previous reply. It
+// returns [ErrUnexpectedPrompt] when that prompt is not the one expected next,
+// and [io.EOF] when the expected prompt has no answer.
+func (c *Conversation) Read(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.pending == "" {
+		if err := c.advance(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, c.pending)
+	c.pending = c.pending[n:]
+	return n, nil
+}
+
+// advance matches the prompt shown since the previous reply to the next
+// expected prompt, and makes its reply the one read.
+func (c *Conversation) advance() error {
+	shown := strings.TrimSpace(c.shown.String())
+	c.shown.Reset()
+	if c.next == len(c.steps) {
+		c.asked = append(c.asked, shown)
+		return fmt.Errorf("%w: %q", ErrUnexpectedPrompt, shown)
+	}
+	step := c.steps[c.next]
+	c.next++
+	if !strings.Contains(shown, strings.TrimSpace(step.prompt)) {
+		c.asked = append(c.asked, shown)
+		return fmt.Errorf("%w: %q, want %q", ErrUnexpectedPrompt, shown, step.prompt)
+	}
+	c.asked = append(c.asked, step.prompt)
+	if !step.answered {
+		return io.EOF
+	}
+	c.pending = step.reply + "\n"
+	return nil
+}
+
+// Output returns everything written to c, including the prompts themselves
+// and any message shown for a rejected reply.
+func (c *Conversation) Output() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.output.String()
+}
+
+// Diff returns a report of how the prompts asked differ from those c expects,
+// as the "-want +got" text of [cmp.Diff], or "" when every expected prompt was
+// asked in order and no other was. An expected prompt that was never asked,
+// leaving its answer unread, appears only as "-"; an unexpected prompt appears
+// as "+", with everything shown for it.
+func (c *Conversation) Diff() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	want := make([]string, 0, len(c.steps))
+	for _, step := range c.steps {
+		want = append(want, step.prompt)
+	}
+	return cmp.Diff(want, c.asked, cmpopts.EquateEmpty())
+}
+
+// WithConversation returns a copy of ctx on which the prompt package's
+// functions, and the Prompter returned by prompt.From, converse through c in
+// place of the terminal. When the test ends, it fails t with the report of
+// [Conversation.Diff] unless the conversation went as scripted.
+func WithConversation(ctx context.Context, t testing.TB, c *Conversation) context.Context {
+	t.Helper()
+	t.Cleanup(func() {
+		if diff := c.Diff(); diff != "" {
+			t.Errorf("prompt conversation mismatch (-want +got):\n%s", diff)
+		}
+	})
+	return clictx.WithConversation(ctx, c)
+}
+
+var _ io.ReadWriter = (*Conversation)(nil)
@@ -0,0 +1,208 @@
+package prompttest_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/bitwizeshift/go-cli/prompt"
+	"github.com/bitwizeshift/go-cli/prompt/prompttest"
+)
+
+// reporter is a [testing.TB] that records the failures reported to it, rather
+// than failing the test, until its cleanups are run by finish.
+type reporter struct {
+	testing.TB
+	failures []string
+	cleanups []func()
+}
+
+func (r *reporter) Helper() {}
+
+func (r *reporter) Cleanup(fn func()) {
+	r.cleanups = append(r.cleanups, fn)
+}
+
+func (r *reporter) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+// finish runs the registered cleanups, as the end of a test would.
+func (r *reporter) finish() {
+	for i := len(r.cleanups) - 1; i >= 0; i-- {
+		r.cleanups[i]()
+	}
+}
+
+// signup is a runner-like sequence of prompts, recording each answer.
+type signup struct {
+	Name  string
+	Token string
+	Plan  int
+	Agree bool
+}
+
+func (s *signup) run(ctx context.Context) error {
+	var err error
+	if s.Name, err = prompt.Line(ctx, "Name: "); err != nil {
+		return err
+	}
+	if s.Token, err = prompt.Secret(ctx, "Token: "); err != nil {
+		return err
+	}
+	if s.Plan, err = prompt.From(ctx).Select(ctx, "Plan:", []string{"free", "pro"}); err != nil {
+		return err
+	}
+	s.Agree, err = prompt.Confirm(ctx, "Continue?")
+	return err
+}
+
+func TestConversation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		conv     *prompttest.Conversation
+		want     signup
+		wantErr  error
+		wantFail bool
+	}{
+		{
+			name: "AsScripted",
+			conv: prompttest.Expect("Name: ").Answer("bob").
+				Expect("Token: ").Answer("s3cr3t").
+				Expect("Choose an option").Answer("2").
+				Expect("Continue?").Answer("y"),
+			want: signup{Name: "bob", Token: "s3cr3t", Plan: 1, Agree: true},
+		}, {
+			name: "RejectedReplyIsAskedAgain",
+			conv: prompttest.Expect("Name: ").Answer("bob").
+				Expect("Token: ").Answer("s3cr3t").
+				Expect("Choose an option").Answer("2").
+				Expect("Continue?").Answer("maybe").
+				Expect("Continue?").Answer("n"),
+			want: signup{Name: "bob", Token: "s3cr3t", Plan: 1},
+		}, {
+			name:    "UnansweredPromptEndsInput",
+			conv:    prompttest.Expect("Name: "),
+			wantErr: io.EOF,
+		}, {
+			name: "UnexpectedPrompt",
+			conv: prompttest.Expect("Email: ").Answer("bob@example.com").
+				Expect("Token: ").Answer("s3cr3t"),
+			wantErr:  prompttest.ErrUnexpectedPrompt,
+			wantFail: true,
+		}, {
+			name: "PromptAfterScriptEnds",
+			conv: prompttest.Expect("Name: ").Answer("bob").
+				Expect("Token: ").Answer("s3cr3t"),
+			want:     signup{Name: "bob", Token: "s3cr3t", Plan: -1},
+			wantErr:  prompttest.ErrUnexpectedPrompt,
+			wantFail: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			tb := &reporter{TB: t}
+			ctx := prompttest.WithConversation(context.Background(), tb, tc.conv)
+			var got signup
+
+			// Act
+			err := got.run(ctx)
+			tb.finish()
+
+			// Assert
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("run(...) = %v, want %v", got, want)
+			}
+			if want := tc.want; !cmp.Equal(got, want) {
+				t.Errorf("run(...) answers = %+v, want %+v", got, want)
+			}
+			if got, want := len(tb.failures) > 0, tc.wantFail; got != want {
+				t.Errorf("WithConversation(...) failed the test = %t, want %t: %q", got, want, tb.failures)
+			}
+		})
+	}
+}
+
+func TestConversation_Diff(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	conv := prompttest.Expect("Name: ").Answer("bob").
+		Expect("Email: ").Answer("bob@example.com").
+		Expect("Continue?").Answer("y")
+	ctx := prompttest.WithConversation(context.Background(), &reporter{TB: t}, conv)
+
+	// Act
+	_, _ = prompt.Line(ctx, "Name: ")
+	_, err := prompt.Line(ctx, "Phone: ")
+
+	// Assert
+	if got, want := err, prompttest.ErrUnexpectedPrompt; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Fatalf("Line(...) = %v, want %v", got, want)
+	}
+	var removed, added []string
+	for line := range strings.Lines(conv.Diff()) {
+		switch {
+		case strings.HasPrefix(line, "-"):
+			removed = append(removed, strings.Trim(line[1:], " \u00a0\t\n,"))
+		case strings.HasPrefix(line, "+"):
+			added = append(added, strings.Trim(line[1:], " \u00a0\t\n,"))
+		}
+	}
+	if got, want := removed, []string{`"Email: "`, `"Continue?"`}; !cmp.Equal(got, want) {
+		t.Errorf("Diff() removed %q, want %q", got, want)
+	}
+	if got, want := added, []string{`"Phone:"`}; !cmp.Equal(got, want) {
+		t.Errorf("Diff() added %q, want %q", got, want)
+	}
+}
+
+func TestConversation_Answer_WithoutPromptPanics(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	conv := prompttest.Expect("Name: ").Answer("bob")
+	defer func() {
+		// Assert
+		if r := recover(); r == nil {
+			t.Errorf("Answer(...) did not panic")
+		}
+	}()
+
+	// Act
+	conv.Answer("again")
+}
+
+func TestConversation_Output(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	conv := prompttest.Expect("Age: ").Answer("old").Expect("Age: ").Answer("42")
+	ctx := prompttest.WithConversation(context.Background(), t, conv)
+	var age int
+
+	// Act
+	err := prompt.Value(ctx, "Age: ", &age)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Value(...) = %v, want nil", err)
+	}
+	if got, want := age, 42; got != want {
+		t.Errorf("Value(...) = %d, want %d", got, want)
+	}
+	if got := conv.Output(); !strings.Contains(got, "Age: ") || strings.Count(got, "Age: ") != 2 {
+		t.Errorf("Output() = %q, want the prompt written twice", got)
+	}
+}
@@ -0,0 +1,15 @@
+// Package prompttest provides fakes for testing runners that prompt the user.
+//
+// A [Conversation] scripts the prompts a runner is expected to ask and the
+// replies given to them, and [WithEditor] stands in for the user's text
+// editor. Both are carried on a context, so a runner under test reaches them
+// through the same prompt functions it calls in production, without a
+// terminal:
+//
+//	conv := prompttest.Expect("Name: ").Answer("bob").
+//		Expect("Continue?").Answer("y")
+//	ctx := prompttest.WithConversation(context.Background(), t, conv)
+//
+// A conversation that strays from its script fails the test, reporting the
+// prompts that were asked against those expected.
+package prompttest
@@ -0,0 +1,35 @@
+package prompttest
+
+import (
+	"context"
+
+	"github.com/bitwizeshift/go-cli/internal/clictx"
+)
+
+// EditFunc edits text as a person would in their text editor, given the text
+// the editor opened with, and returns the text they saved.
+type EditFunc = func(template string) (string, error)
+
+// WithEditor returns a copy of ctx on which prompt.Editor edits through edit
+// rather than launching the user's editor. The saved text is cleaned and
+// checked exactly as it is for a real editor.
+func WithEditor(ctx context.Context, edit EditFunc) context.Context {
+	return clictx.WithEditor(ctx, func(_ context.Context, content, _ string) (string, error) {
+		return edit(content)
+	})
+}
+
+// Save returns an [EditFunc] that replaces the template with text and saves it.
+func Save(text string) EditFunc {
+	return func(string) (string, error) {
+		return text, nil
+	}
+}
+
+// Quit returns an [EditFunc] that exits without saving, leaving the template
+// unchanged.
+func Quit() EditFunc {
+	return func(template string) (string, error) {
+		return template, nil
+	}
+}
@@ -0,0 +1,93 @@
+package prompttest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/bitwizeshift/go-cli/prompt"
+	"github.com/bitwizeshift/go-cli/prompt/prompttest"
+)
+
+func TestWithEditor(t *testing.T) {
+	t.Parallel()
+
+	errCrashed := errors.New("editor crashed")
+	const template = "\n# Describe the change.\n# Lines starting with '#' are ignored.\n"
+
+	testCases := []struct {
+		name    string
+		edit    prompttest.EditFunc
+		want    string
+		wantErr error
+	}{
+		{
+			name: "ReturnsSavedText",
+			edit: prompttest.Save("Fix the parser\n\nIt no longer panics.\n"),
+			want: "Fix the parser\n\nIt no longer panics.",
+		}, {
+			name: "StripsComments",
+			edit: func(template string) (string, error) {
+				return "Add a flag" + template, nil
+			},
+			want: "Add a flag",
+		}, {
+			name:    "UnchangedIsAborted",
+			edit:    prompttest.Quit(),
+			wantErr: prompt.ErrEditAborted,
+		}, {
+			name:    "OnlyCommentsIsAborted",
+			edit:    prompttest.Save("# nothing to say\n\n"),
+			wantErr: prompt.ErrEditAborted,
+		}, {
+			name:    "EditorError",
+			edit:    func(string) (string, error) { return "", errCrashed },
+			wantErr: errCrashed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			ctx := prompttest.WithEditor(context.Background(), tc.edit)
+
+			// Act
+			got, err := prompt.Editor(ctx, template, ".txt")
+
+			// Assert
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("Editor(...) error = %v, want %v", got, want)
+			}
+			if want := tc.want; got != want {
+				t.Errorf("Editor(...) = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestWithEditor_ReceivesTemplate(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	var opened string
+	ctx := prompttest.WithEditor(context.Background(), func(template string) (string, error) {
+		opened = template
+		return "done", nil
+	})
+
+	// Act
+	_, err := prompt.Editor(ctx, "# template\n", ".md")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Editor(...) error = %v, want nil", err)
+	}
+	if got, want := opened, "# template\n"; got != want {
+		t.Errorf("editor opened with %q, want %q", got, want)
+	}
+}
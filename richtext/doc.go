@@ -1,4 +1,10 @@
 // Package richtext renders bracketed tag markup into ANSI-styled terminal
 // output, gated by the terminal's colour capability. A [Writer] performs the
-// rendering as an [io.Writer].
+// rendering as an [io.Writer]. The same markup can also be rendered as HTML, or
+// as an SVG image of a terminal window, to turn any output into a
+// documentation artifact.
+//
+// Text from outside the program should be passed through [Escape], or
+// formatted with [Sprintf] or [Fprintf], so that brackets in it are shown as
+// written rather than parsed as tags.
 package richtext
@@ -12,6 +12,10 @@ var (
 
 	// ErrUnclosedTag reports that input ended while tags were still open.
 	ErrUnclosedTag = errors.New("unclosed tag")
+
+	// ErrInvalidThemeName reports a theme name that could never be referenced
+	// by a [theme:name] tag.
+	ErrInvalidThemeName = errors.New("invalid theme name")
 )
 
 // TagError describes a problem with a specific tag encountered while writing.
@@ -0,0 +1,163 @@
+package richtext
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/bitwizeshift/go-cli/richtext/internal/token"
+)
+
+// The tags that open and close a passthrough region.
+const (
+	rawOpen  = "[" + token.RawNamespace + ":off]"
+	rawClose = "[/" + token.RawNamespace + "]"
+)
+
+// Escape returns s as markup that a [Writer] shows verbatim, so text from
+// outside the program, such as a file name containing "[fg:red]", cannot be
+// mistaken for tags. Text with no brackets is returned unchanged; any other is
+// wrapped in a "[richtext:off]" region, split wherever s itself contains the
+// region's closing tag. [Strip] and [Width] of the result are those of s.
+func Escape(s string) string {
+	if !strings.ContainsAny(s, "[]") {
+		return s
+	}
+	var b strings.Builder
+	for {
+		i := strings.Index(s, rawClose)
+		if i < 0 {
+			break
+		}
+		// Ending the region after the '[' leaves only "/richtext]" for the
+		// next one, which is no longer the closing tag.
+		b.WriteString(rawOpen + s[:i+1] + rawClose)
+		s = s[i+1:]
+	}
+	b.WriteString(rawOpen + s + rawClose)
+	return b.String()
+}
+
+// Sprintf formats according to a format specifier, as [fmt.Sprintf] does, and
+// returns the resulting markup. The tags in format take effect, but the text of
+// each argument is escaped as [Escape] does, so the formatted values are shown
+// as written:
+//
+//	richtext.Sprintf("[theme:error]cannot open %s[/theme]", name)
+//
+// Arguments that format uses only as a '*' width or precision, or prints only
+// by type or address with %T or %p, are passed to [fmt] unchanged.
+func Sprintf(format string, a ...any) string {
+	return fmt.Sprintf(format, escapeArgs(format, a)...)
+}
+
+// Fprintf formats according to a format specifier and writes the resulting
+// markup to w, escaping the text of each argument as [Sprintf] does. It
+// returns the number of bytes written and any write error encountered.
+func Fprintf(w io.Writer, format string, a ...any) (n int, err error) {
+	return fmt.Fprintf(w, format, escapeArgs(format, a)...)
+}
+
+// escaped formats a value as fmt would, and escapes the result.
+type escaped struct {
+	value any
+}
+
+func (e escaped) Format(f fmt.State, verb rune) {
+	_, _ = io.WriteString(f, Escape(fmt.Sprintf(fmt.FormatString(f, verb), e.value)))
+}
+
+// escapeArgs returns a copy of args in which each argument that format prints
+// as text is wrapped to be escaped.
+func escapeArgs(format string, args []any) []any {
+	verbatim := verbatimArgs(format, len(args))
+	wrapped := make([]any, len(args))
+	for i, arg := range args {
+		if verbatim[i] {
+			wrapped[i] = arg
+		} else {
+			wrapped[i] = escaped{arg}
+		}
+	}
+	return wrapped
+}
+
+// verbatimArgs reports which of n arguments to format must reach fmt as they
+// are: those taken as a '*' width or precision, which fmt requires to be ints,
+// and those printed by %T or %p, which describe the argument itself. An
+// argument that format also prints as text is escaped regardless. It follows
+// the argument numbering of [fmt], including explicit "[n]" indexes.
+func verbatimArgs(format string, n int) []bool {
+	verbatim, text := make([]bool, n), make([]bool, n)
+	mark := func(uses []bool, i int) {
+		if i >= 0 && i < n {
+			uses[i] = true
+		}
+	}
+	arg := 0
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		i++
+		for i < len(format) && strings.IndexByte("+-# 0", format[i]) >= 0 {
+			i++
+		}
+		// A width, then a precision, each optionally preceded by an index.
+		for part := 0; part < 2 && i < len(format); part++ {
+			arg, i = argIndex(format, i, arg)
+			if i < len(format) && format[i] == '*' {
+				mark(verbatim, arg)
+				arg++
+				i++
+			} else {
+				for i < len(format) && '0' <= format[i] && format[i] <= '9' {
+					i++
+				}
+			}
+			if part == 0 && i < len(format) && format[i] == '.' {
+				i++
+			} else {
+				break
+			}
+		}
+		arg, i = argIndex(format, i, arg)
+		if i >= len(format) {
+			break
+		}
+		switch format[i] {
+		case '%':
+			continue
+		case 'T', 'p':
+			mark(verbatim, arg)
+		default:
+			mark(text, arg)
+		}
+		arg++
+	}
+	for i := range verbatim {
+		verbatim[i] = verbatim[i] && !text[i]
+	}
+	return verbatim
+}
+
+// argIndex parses an explicit "[n]" argument index at format[i], returning the
+// zero-based argument it selects and the index just past it. Without one, it
+// returns arg and i unchanged.
+func argIndex(format string, i, arg int) (int, int) {
+	if i >= len(format) || format[i] != '[' {
+		return arg, i
+	}
+	end := strings.IndexByte(format[i:], ']')
+	if end < 0 {
+		return arg, i
+	}
+	n := 0
+	for _, c := range format[i+1 : i+end] {
+		if c < '0' || c > '9' {
+			return arg, i + end + 1
+		}
+		n = n*10 + int(c-'0')
+	}
+	return n - 1, i + end + 1
+}
@@ -0,0 +1,222 @@
+package richtext_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bitwizeshift/go-cli/richtext"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestEscape(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "NoBracketsUnchanged",
+			input: "report.txt",
+			want:  "report.txt",
+		},
+		{
+			name:  "TagsWrapped",
+			input: "[fg:red]x[/fg]",
+			want:  "[richtext:off][fg:red]x[/fg][/richtext]",
+		},
+		{
+			name:  "LoneCloseBracketWrapped",
+			input: "a]",
+			want:  "[richtext:off]a][/richtext]",
+		},
+		{
+			name:  "EmbeddedRegionCloseSplit",
+			input: "a[/richtext]b",
+			want:  "[richtext:off]a[[/richtext][richtext:off]/richtext]b[/richtext]",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			escaped := richtext.Escape(tc.input)
+
+			// Assert
+			if got, want := escaped, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Escape(%q) = %q, want %q", tc.input, got, want)
+			}
+		})
+	}
+}
+
+func TestEscape_WriterShowsVerbatim(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "Tags",
+			input: "[fg:red]x[/fg]",
+		},
+		{
+			name:  "RegionClose",
+			input: "[/richtext][fg:red]x[/richtext][/richtext]",
+		},
+		{
+			name:  "UnterminatedTag",
+			input: "file[fg",
+		},
+		{
+			name:  "Link",
+			input: "[link:https://example.com]click[/link]",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			var buf strings.Builder
+			sut := richtext.NewWriter(&buf, nil)
+			sut.ForceColour()
+
+			// Act
+			_, err := sut.Write([]byte("[fg:red]" + richtext.Escape(tc.input) + "[/fg]"))
+
+			// Assert
+			if got, want := err, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("Write() = %v, want nil", got)
+			}
+			if got, want := buf.String(), reset+red+tc.input+reset; !cmp.Equal(got, want) {
+				t.Errorf("Write() output = %q, want %q", got, want)
+			}
+			if got, want := richtext.Width(richtext.Escape(tc.input)), len(tc.input); !cmp.Equal(got, want) {
+				t.Errorf("Width(Escape(%q)) = %d, want %d", tc.input, got, want)
+			}
+		})
+	}
+}
+
+type stringer string
+
+func (s stringer) String() string {
+	return string(s)
+}
+
+func TestSprintf(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		format string
+		args   []any
+		want   string
+	}{
+		{
+			name:   "FormatMarkupKept",
+			format: "[theme:error]%s[/theme]",
+			args:   []any{"plain"},
+			want:   "[theme:error]plain[/theme]",
+		},
+		{
+			name:   "StringArgumentEscaped",
+			format: "cannot open %s",
+			args:   []any{"[fg:red].txt"},
+			want:   "cannot open [richtext:off][fg:red].txt[/richtext]",
+		},
+		{
+			name:   "VerbsAndFlagsApplied",
+			format: "%-6s|%q",
+			args:   []any{"a[b]", "[x]"},
+			want:   "[richtext:off]a[b]  [/richtext]|[richtext:off]\"[x]\"[/richtext]",
+		},
+		{
+			name:   "ValueArgumentEscaped",
+			format: "%v %v",
+			args:   []any{[]string{"a", "b"}, stringer("[bg:red]")},
+			want:   "[richtext:off][a b][/richtext] [richtext:off][bg:red][/richtext]",
+		},
+		{
+			name:   "ErrorArgumentEscaped",
+			format: "failed: %v",
+			args:   []any{errors.New("bad [attr:bold]")},
+			want:   "failed: [richtext:off]bad [attr:bold][/richtext]",
+		},
+		{
+			name:   "NumbersUnchanged",
+			format: "%d/%.2f",
+			args:   []any{3, 0.5},
+			want:   "3/0.50",
+		},
+		{
+			name:   "StarWidthAndPrecisionPassedThrough",
+			format: "%*.*s|",
+			args:   []any{4, 2, "abc"},
+			want:   "  ab|",
+		},
+		{
+			name:   "TypeVerbPassedThrough",
+			format: "%T %s",
+			args:   []any{"x", "%"},
+			want:   "string %",
+		},
+		{
+			name:   "ExplicitIndexes",
+			format: "%[2]*[1]s|%[2]T",
+			args:   []any{"[x]", 5},
+			want:   "[richtext:off]  [x][/richtext]|int",
+		},
+		{
+			name:   "PercentConsumesNoArgument",
+			format: "100%% %T",
+			args:   []any{1},
+			want:   "100% int",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			formatted := richtext.Sprintf(tc.format, tc.args...)
+
+			// Assert
+			if got, want := formatted, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Sprintf(%q) = %q, want %q", tc.format, got, want)
+			}
+		})
+	}
+}
+
+func TestFprintf(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	var buf strings.Builder
+	sut := richtext.NewWriter(&buf, nil)
+	sut.ForceColour()
+
+	// Act
+	n, err := richtext.Fprintf(sut, "[fg:red]%s[/fg]", "[bg:blue]x")
+
+	// Assert
+	if got, want := err, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Fatalf("Fprintf() = %v, want nil", got)
+	}
+	if got, want := n, len("[fg:red][richtext:off][bg:blue]x[/richtext][/fg]"); !cmp.Equal(got, want) {
+		t.Errorf("Fprintf() = %d, want %d", got, want)
+	}
+	if got, want := buf.String(), reset+red+"[bg:blue]x"+reset; !cmp.Equal(got, want) {
+		t.Errorf("Fprintf() output = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,146 @@
+package richtext
+
+import (
+	"html"
+	"io"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/bitwizeshift/go-cli/richtext/style"
+)
+
+// NewHTMLWriter returns a Writer that renders markup as an HTML fragment
+// rather than as ANSI escapes, resolving [theme:name] tags against theme as
+// [NewWriter] does. Styled text becomes a <span> with an inline style, links
+// to http, https, mailto, and file URLs become <a> elements, and all text is
+// escaped, so the fragment is safe to embed as the contents of a <pre>
+// element. A link to any other URL is written as its text alone.
+//
+// Colour is always emitted, in true colour, with the named colours taken from
+// a palette that suits the background; see [Writer.SetBackground], which
+// defaults to a [DarkBackground]. Call [Writer.Close] once the markup is
+// written to close the last element.
+func NewHTMLWriter(dst io.Writer, theme *Theme) *Writer {
+	w := NewWriter(dst, theme)
+	w.SetFormat(HTML)
+	return w
+}
+
+// htmlOutput renders as HTML. It opens a <span> or <a> only once there is text
+// to put in it, and closes it as soon as the style or link it shows changes,
+// so the elements it writes are always balanced.
+type htmlOutput struct {
+	dst        io.Writer
+	background func() Background
+
+	// style and url are what the next text is shown in.
+	style style.Style
+	url   string
+
+	// span and link report whether a <span> with the inline style spanCSS, or
+	// an <a> to url, is open.
+	span    bool
+	spanCSS string
+	link    bool
+}
+
+func (o *htmlOutput) restyle(s style.Style, url string) error {
+	var b strings.Builder
+	if url != o.url {
+		o.closeSpan(&b)
+		o.closeLink(&b)
+	} else if o.span && o.css(s) != o.spanCSS {
+		o.closeSpan(&b)
+	}
+	o.style, o.url = s, url
+	return o.write(b.String())
+}
+
+func (o *htmlOutput) text(s string) error {
+	if s == "" {
+		return nil
+	}
+	var b strings.Builder
+	if !o.link && linkable(o.url) {
+		b.WriteString(`<a href="` + html.EscapeString(o.url) + `">`)
+		o.link = true
+	}
+	if !o.span {
+		if css := o.css(o.style); css != "" {
+			b.WriteString(`<span style="` + html.EscapeString(css) + `">`)
+			o.span, o.spanCSS = true, css
+		}
+	}
+	b.WriteString(html.EscapeString(s))
+	return o.write(b.String())
+}
+
+func (o *htmlOutput) close() error {
+	var b strings.Builder
+	o.closeSpan(&b)
+	o.closeLink(&b)
+	return o.write(b.String())
+}
+
+// linkSchemes are the URL schemes an <a> is written for. A link to any other
+// scheme, such as javascript: or data:, could run script in the page the HTML
+// is embedded in, so its text is written without the <a>.
+var linkSchemes = []string{"http", "https", "mailto", "file"}
+
+// linkable reports whether an <a> may be written to rawURL: it parses, and has
+// one of the linkSchemes.
+func linkable(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	return err == nil && slices.Contains(linkSchemes, strings.ToLower(u.Scheme))
+}
+
+// closeSpan closes the open <span>, if any, onto b.
+func (o *htmlOutput) closeSpan(b *strings.Builder) {
+	if o.span {
+		b.WriteString("</span>")
+		o.span = false
+	}
+}
+
+// closeLink closes the open <a>, if any, onto b.
+func (o *htmlOutput) closeLink(b *strings.Builder) {
+	if o.link {
+		b.WriteString("</a>")
+		o.link = false
+	}
+}
+
+// css returns the inline CSS declarations that show s, or the empty string
+// when s is unstyled.
+func (o *htmlOutput) css(s style.Style) string {
+	fg, bg := schemeFor(o.background()).colours(s)
+	var decls []string
+	if fg != "" {
+		decls = append(decls, "color:"+fg)
+	}
+	if bg != "" {
+		decls = append(decls, "background-color:"+bg)
+	}
+	if s.Attributes&style.Bold != 0 {
+		decls = append(decls, "font-weight:bold")
+	}
+	if s.Attributes&style.Faint != 0 {
+		decls = append(decls, "opacity:0.6")
+	}
+	if s.Attributes&style.Italic != 0 {
+		decls = append(decls, "font-style:italic")
+	}
+	if lines := decorations(s); lines != "" {
+		decls = append(decls, "text-decoration:"+lines)
+	}
+	return strings.Join(decls, ";")
+}
+
+func (o *htmlOutput) write(s string) error {
+	if s == "" {
+		return nil
+	}
+	_, err := io.WriteString(o.dst, s)
+	return err
+}
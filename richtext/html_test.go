@@ -0,0 +1,170 @@
+package richtext_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bitwizeshift/go-cli/richtext"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestHTMLWriter_Write(t *testing.T) {
+	t.Parallel()
+
+	theme := newTheme(t)
+
+	testCases := []struct {
+		name       string
+		background richtext.Background
+		input      string
+		want       string
+	}{
+		{
+			name:  "PlainTextEscaped",
+			input: `<b> & "q"`,
+			want:  "&lt;b&gt; &amp; &#34;q&#34;",
+		},
+		{
+			name:  "NamedColourFromDarkPalette",
+			input: "[fg:red]x[/fg]",
+			want:  `<span style="color:#cd3131">x</span>`,
+		},
+		{
+			name:       "NamedColourFromLightPalette",
+			background: richtext.LightBackground,
+			input:      "[fg:green]x[/fg]",
+			want:       `<span style="color:#00bc00">x</span>`,
+		},
+		{
+			name:  "TrueColourAndBackground",
+			input: "[fg:rgb(1,2,3)][bg:208]x[/bg][/fg]",
+			want:  `<span style="color:#010203;background-color:#ff8700">x</span>`,
+		},
+		{
+			name:  "AttributesAccumulate",
+			input: "[attr:bold]a[attr:italic]b[attr:underline][attr:strike]c[/attr][/attr][/attr][/attr]",
+			want: `<span style="font-weight:bold">a</span>` +
+				`<span style="font-weight:bold;font-style:italic">b</span>` +
+				`<span style="font-weight:bold;font-style:italic;text-decoration:underline line-through">c</span>`,
+		},
+		{
+			name:  "ReverseSwapsWithDefaults",
+			input: "[attr:reverse][fg:red]x[/fg][/attr]",
+			want:  `<span style="color:#1e1e1e;background-color:#cd3131">x</span>`,
+		},
+		{
+			name:  "Theme",
+			input: "[theme:title]x[/theme]",
+			want:  `<span style="color:#11a8cd;font-weight:bold">x</span>`,
+		},
+		{
+			name:  "EmptyRegionsWriteNothing",
+			input: "a[fg:red][/fg]b",
+			want:  "ab",
+		},
+		{
+			name:  "SameStyleSharesSpan",
+			input: "[fg:red]a[attr:blink]b[/attr]c[/fg]",
+			want:  `<span style="color:#cd3131">abc</span>`,
+		},
+		{
+			name:  "LinkEnclosesStyledText",
+			input: "[link:https://example.com/?a=1&b=2]go [fg:red]here[/fg][/link]!",
+			want:  `<a href="https://example.com/?a=1&amp;b=2">go <span style="color:#cd3131">here</span></a>!`,
+		},
+		{
+			name:  "StyleSpansLinkBoundary",
+			input: "[fg:red]a[link:https://example.com]b[/link]c[/fg]",
+			want: `<span style="color:#cd3131">a</span>` +
+				`<a href="https://example.com"><span style="color:#cd3131">b</span></a>` +
+				`<span style="color:#cd3131">c</span>`,
+		},
+		{
+			name:  "MailtoLink",
+			input: "[link:mailto:team@example.com]mail[/link]",
+			want:  `<a href="mailto:team@example.com">mail</a>`,
+		},
+		{
+			name:  "ScriptLinkWrittenAsText",
+			input: "[link:javascript:alert(1)]click [fg:red]me[/fg][/link]",
+			want:  `click <span style="color:#cd3131">me</span>`,
+		},
+		{
+			name:  "ScriptLinkInMixedCase",
+			input: "[link:JavaScript:alert(1)]click[/link]",
+			want:  `click`,
+		},
+		{
+			name:  "DataLinkWrittenAsText",
+			input: "[link:data:text/html,x]click[/link]",
+			want:  `click`,
+		},
+		{
+			name:  "RawRegionEscaped",
+			input: "[fg:red][richtext:off]<[/fg]>[/richtext][/fg]",
+			want:  `<span style="color:#cd3131">&lt;[/fg]&gt;</span>`,
+		},
+		{
+			name:  "UnclosedTagClosedOnClose",
+			input: "[link:https://example.com][fg:red]x",
+			want:  `<a href="https://example.com"><span style="color:#cd3131">x</span></a>`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			var buf strings.Builder
+			sut := richtext.NewHTMLWriter(&buf, theme)
+			if tc.background != richtext.UnknownBackground {
+				sut.SetBackground(tc.background)
+			}
+
+			// Act
+			_, err := sut.Write([]byte(tc.input))
+			_ = sut.Close()
+
+			// Assert
+			if got, want := err, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("Write() = %v, want %v", got, want)
+			}
+			if got, want := buf.String(), tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Write() output = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestHTMLWriter_Background(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	sut := richtext.NewHTMLWriter(&strings.Builder{}, richtext.DefaultTheme)
+
+	// Act
+	bg := sut.Background()
+
+	// Assert
+	if got, want := bg, richtext.DarkBackground; !cmp.Equal(got, want) {
+		t.Errorf("Background() = %v, want %v", got, want)
+	}
+}
+
+func TestHTMLWriter_Close_UnclosedTag_ReturnsError(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	sut := richtext.NewHTMLWriter(&strings.Builder{}, nil)
+	_, _ = sut.Write([]byte("[fg:red]x"))
+
+	// Act
+	err := sut.Close()
+
+	// Assert
+	if got, want := err, richtext.ErrUnclosedTag; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Errorf("Close() = %v, want %v", got, want)
+	}
+}
@@ -23,6 +23,17 @@ func TrueColour(bg bool, r, g, b uint8) []int {
 	return []int{lead, 2, int(r), int(g), int(b)}
 }
 
+// Indexed returns the SGR parameters selecting entry n of the 256-colour
+// palette. When bg is true the parameters target the background layer,
+// otherwise the foreground.
+func Indexed(bg bool, n uint8) []int {
+	lead := 38
+	if bg {
+		lead = 48
+	}
+	return []int{lead, 5, int(n)}
+}
+
 // Background shifts a foreground colour parameter to its background equivalent.
 func Background(foreground int) int {
 	return foreground + backgroundOffset
@@ -94,6 +94,44 @@ func TestTrueColour(t *testing.T) {
 	}
 }
 
+func TestIndexed(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		background bool
+		n          uint8
+		want       []int
+	}{
+		{
+			name:       "Foreground",
+			background: false,
+			n:          208,
+			want:       []int{38, 5, 208},
+		},
+		{
+			name:       "Background",
+			background: true,
+			n:          17,
+			want:       []int{48, 5, 17},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			params := sgr.Indexed(tc.background, tc.n)
+
+			// Assert
+			if got, want := params, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Indexed() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
 func TestBackground(t *testing.T) {
 	t.Parallel()
 
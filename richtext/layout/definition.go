@@ -0,0 +1,89 @@
+package layout
+
+import (
+	"cmp"
+	"strings"
+
+	"github.com/bitwizeshift/go-cli/internal/template/tag"
+	"github.com/bitwizeshift/go-cli/richtext"
+)
+
+// The spacing of a definition list.
+const (
+	// definitionGap separates the column of terms from that of descriptions.
+	definitionGap = 2
+
+	// definitionIndent indents a description beneath its term, when there is
+	// too little room beside it.
+	definitionIndent = 4
+
+	// minDescription is the narrowest the column of descriptions is allowed to
+	// be before each description is moved beneath its term instead.
+	minDescription = 20
+)
+
+// Definition is a term of a [DefinitionList] and the description of it, both
+// markup.
+type Definition struct {
+	Term        string
+	Description string
+}
+
+// DefinitionList is a list of terms, such as the keys of a configuration, each
+// followed by its description. The descriptions are aligned in a column beside
+// the widest term and wrapped to fit it, or set beneath their terms when the
+// column would be too narrow.
+type DefinitionList struct {
+	Items []Definition
+
+	// TermRole is the theme role of the terms, "label" when empty.
+	TermRole string
+}
+
+// Render implements [Component].
+func (l DefinitionList) Render(width int, _ *Charset) string {
+	role := cmp.Or(l.TermRole, termRole)
+	column := 0
+	for _, item := range l.Items {
+		column = max(column, richtext.Width(item.Term))
+	}
+	stacked := width > 0 && width-column-definitionGap < minDescription
+
+	var lines []string
+	for _, item := range l.Items {
+		term := tag.Themed(role, item.Term)
+		if stacked {
+			lines = append(lines, wrapLines(term, width)...)
+			lines = append(lines, hang(wrapLines(item.Description, max(width-definitionIndent, 1)), definitionIndent)...)
+			continue
+		}
+		description := wrapLines(item.Description, max(width-column-definitionGap, 0))
+		if len(description) == 0 {
+			lines = append(lines, term)
+			continue
+		}
+		pad := strings.Repeat(" ", column-richtext.Width(item.Term)+definitionGap)
+		lines = append(lines, term+pad+description[0])
+		lines = append(lines, hang(description[1:], column+definitionGap)...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// wrapLines returns the lines of the markup s wrapped to width, or no lines
+// when s is empty.
+func wrapLines(s string, width int) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(richtext.Wrap(s, width), "\n")
+}
+
+// hang indents each of lines by n spaces.
+func hang(lines []string, n int) []string {
+	for i, line := range lines {
+		lines[i] = strings.Repeat(" ", n) + line
+	}
+	return lines
+}
+
+var _ Component = DefinitionList{}
@@ -0,0 +1,108 @@
+package layout_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bitwizeshift/go-cli/richtext/layout"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDefinitionList_Render(t *testing.T) {
+	t.Parallel()
+
+	items := []layout.Definition{
+		{Term: "name", Description: "the name the package is published under"},
+		{Term: "version", Description: "v1.2.0"},
+		{Term: "private"},
+	}
+
+	testCases := []struct {
+		name  string
+		list  layout.DefinitionList
+		width int
+		want  []string
+	}{
+		{
+			name:  "Aligned",
+			list:  layout.DefinitionList{Items: items},
+			width: 80,
+			want: []string{
+				"name     the name the package is published under",
+				"version  v1.2.0",
+				"private",
+			},
+		},
+		{
+			name:  "DescriptionsWrapped",
+			list:  layout.DefinitionList{Items: items},
+			width: 32,
+			want: []string{
+				"name     the name the package is",
+				"         published under",
+				"version  v1.2.0",
+				"private",
+			},
+		},
+		{
+			name:  "StackedWhenNarrow",
+			list:  layout.DefinitionList{Items: items[:2]},
+			width: 24,
+			want: []string{
+				"name",
+				"    the name the package",
+				"    is published under",
+				"version",
+				"    v1.2.0",
+			},
+		},
+		{
+			name:  "Unlimited",
+			list:  layout.DefinitionList{Items: items[:2]},
+			width: 0,
+			want: []string{
+				"name     the name the package is published under",
+				"version  v1.2.0",
+			},
+		},
+		{
+			name:  "Empty",
+			list:  layout.DefinitionList{},
+			width: 80,
+			want:  []string{""},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			rendered := render(t, tc.list, tc.width, layout.Unicode)
+
+			// Assert
+			if got, want := rendered, strings.Join(tc.want, "\n"); !cmp.Equal(got, want) {
+				t.Errorf("DefinitionList.Render() = \n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestDefinitionList_Render_Roles(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	sut := layout.DefinitionList{
+		Items:    []layout.Definition{{Term: "key", Description: "[theme:value]v[/theme]"}},
+		TermRole: "code",
+	}
+
+	// Act
+	rendered := sut.Render(80, layout.Unicode)
+
+	// Assert
+	want := "[theme:code]key[/theme]  [theme:value]v[/theme]"
+	if got := rendered; !cmp.Equal(got, want) {
+		t.Errorf("DefinitionList.Render() = %q, want %q", got, want)
+	}
+}
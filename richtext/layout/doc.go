@@ -0,0 +1,12 @@
+// Package layout draws blocks of richtext markup laid out to a width: bordered
+// panels, horizontal rules, definition lists, and trees, such as a dependency
+// graph. Each is a [Component], whose markup is written to a
+// [github.com/bitwizeshift/go-cli/richtext.Writer] like any other, and sized by
+// the caller, usually to the terminal's width as reported by
+// [github.com/bitwizeshift/go-cli.StreamColumns].
+//
+// Components are drawn with box-drawing characters, or with ASCII alone where
+// the terminal or locale cannot show them; see [CharsetFor]. Their borders,
+// titles, and labels are styled through the theme roles "gutter", "title", and
+// "label" unless given others.
+package layout
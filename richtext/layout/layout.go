@@ -0,0 +1,95 @@
+package layout
+
+import (
+	"io"
+
+	"github.com/bitwizeshift/go-cli/internal/term"
+)
+
+// The theme roles components are styled with unless given others.
+const (
+	lineRole  = "gutter"
+	titleRole = "title"
+	termRole  = "label"
+)
+
+// Component is a block of markup laid out to a width.
+type Component interface {
+	// Render returns the markup of the component laid out to fit within width
+	// columns, drawn with the characters of cs. Every line closes the tags it
+	// opens. A non-positive width lays the component out without a limit.
+	Render(width int, cs *Charset) string
+}
+
+// Charset is the set of characters components are drawn with. Each character
+// of a border or guide occupies a single column.
+type Charset struct {
+	// Horizontal and Vertical draw the sides of a panel, rules, and the guides
+	// of a tree.
+	Horizontal, Vertical string
+
+	// TopLeft, TopRight, BottomLeft, and BottomRight are the corners of a panel.
+	TopLeft, TopRight, BottomLeft, BottomRight string
+
+	// Branch joins a node of a tree to the guide of its parent when a later
+	// sibling follows it, and LastBranch when none does.
+	Branch, LastBranch string
+
+	// Ellipsis ends text shortened to fit.
+	Ellipsis string
+
+	// Arrow leads from one thing to another, such as from a version to the one
+	// that replaces it.
+	Arrow string
+}
+
+// The charsets components are drawn with.
+var (
+	// Unicode draws with box-drawing characters and rounded corners.
+	Unicode = &Charset{
+		Horizontal: "─", Vertical: "│",
+		TopLeft: "╭", TopRight: "╮", BottomLeft: "╰", BottomRight: "╯",
+		Branch: "├", LastBranch: "└",
+		Ellipsis: "…",
+		Arrow:    "→",
+	}
+
+	// ASCII draws with ASCII alone, for terminals and locales that cannot show
+	// Unicode.
+	ASCII = &Charset{
+		Horizontal: "-", Vertical: "|",
+		TopLeft: "+", TopRight: "+", BottomLeft: "+", BottomRight: "+",
+		Branch: "|", LastBranch: "`",
+		Ellipsis: "...",
+		Arrow:    "->",
+	}
+)
+
+// CharsetFor returns [Unicode] when w shows characters beyond ASCII, and
+// [ASCII] otherwise. A [github.com/bitwizeshift/go-cli/richtext.Writer], as
+// every stream of a running command is, reports this itself; any other writer
+// is judged by the locale.
+func CharsetFor(w io.Writer) *Charset {
+	var enabled bool
+	if u, ok := w.(interface{ Unicode() bool }); ok {
+		enabled = u.Unicode()
+	} else {
+		enabled = term.LocaleUnicodeEnabler{}.EnableUnicode(w)
+	}
+	if enabled {
+		return Unicode
+	}
+	return ASCII
+}
+
+// Fprint writes c to w followed by a newline, laid out to fit within width
+// columns and drawn with the charset [CharsetFor] returns for w. A runner sizes
+// components to its terminal with [github.com/bitwizeshift/go-cli.StreamColumns]:
+//
+//	w := cli.OutStream(ctx)
+//	rule := layout.Rule{Title: "Summary"}
+//	err := layout.Fprint(w, cli.StreamColumns(ctx, w), rule)
+func Fprint(w io.Writer, width int, c Component) error {
+	_, err := io.WriteString(w, c.Render(width, CharsetFor(w))+"\n")
+	return err
+}
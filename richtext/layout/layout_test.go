@@ -0,0 +1,86 @@
+package layout_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bitwizeshift/go-cli/internal/template/plain"
+	"github.com/bitwizeshift/go-cli/richtext"
+	"github.com/bitwizeshift/go-cli/richtext/layout"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+// render returns c laid out to width with cs, with its styling tags removed.
+func render(t *testing.T, c layout.Component, width int, cs *layout.Charset) string {
+	t.Helper()
+
+	rendered, err := plain.Render(c.Render(width, cs))
+	if err != nil {
+		t.Fatalf("plain.Render() = %v, want nil", err)
+	}
+	return rendered
+}
+
+func TestCharsetFor(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		unicode bool
+		want    *layout.Charset
+	}{
+		{
+			name:    "Unicode",
+			unicode: true,
+			want:    layout.Unicode,
+		},
+		{
+			name:    "ASCII",
+			unicode: false,
+			want:    layout.ASCII,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			w := richtext.NewWriter(&strings.Builder{}, nil)
+			w.EnableUnicode(false)
+			if tc.unicode {
+				w.ForceUnicode()
+			}
+
+			// Act
+			cs := layout.CharsetFor(w)
+
+			// Assert
+			if got, want := cs, tc.want; got != want {
+				t.Errorf("CharsetFor() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestFprint(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	var buf strings.Builder
+	w := richtext.NewWriter(&buf, nil)
+	w.EnableColour(false)
+	w.EnableUnicode(false)
+
+	// Act
+	err := layout.Fprint(w, 20, layout.Panel{Body: "[fg:red]ok[/fg]"})
+
+	// Assert
+	if got, want := err, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Fatalf("Fprint() = %v, want nil", got)
+	}
+	if got, want := buf.String(), "+----+\n| ok |\n+----+\n"; !cmp.Equal(got, want) {
+		t.Errorf("Fprint() output = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,85 @@
+package layout
+
+import (
+	"cmp"
+	"strings"
+
+	"github.com/bitwizeshift/go-cli/internal/template/tag"
+	"github.com/bitwizeshift/go-cli/richtext"
+)
+
+// Panel is a body of markup inside a border, with an optional title set into
+// the top of the border.
+//
+// A writer that cannot show hyperlinks spells a link's URL out after its text,
+// which the panel cannot allow for when it measures the body. A link in a panel
+// should therefore be given its URL as its text, which is never spelled out
+// again.
+type Panel struct {
+	// Title is the markup shown in the top border, shortened to fit.
+	Title string
+
+	// Body is the markup inside the border, wrapped to fit as [richtext.Wrap]
+	// wraps it. An empty body draws only the border.
+	Body string
+
+	// Expand widens the panel to the full width it is rendered at. Otherwise it
+	// is only as wide as its title and body need.
+	Expand bool
+
+	// BorderRole and TitleRole are the theme roles of the border and the title,
+	// "gutter" and "title" when empty.
+	BorderRole, TitleRole string
+}
+
+// Render implements [Component]. The body is set one space in from each side
+// of the border.
+func (p Panel) Render(width int, cs *Charset) string {
+	border := func(s string) string {
+		return tag.Themed(cmp.Or(p.BorderRole, lineRole), s)
+	}
+	// limit is the most columns the body may take, or 0 for no limit.
+	limit := 0
+	if width > 0 {
+		limit = max(width-4, 1)
+	}
+	var body []string
+	inner := 0
+	if p.Body != "" {
+		body = strings.Split(richtext.Wrap(p.Body, limit), "\n")
+		for _, line := range body {
+			inner = max(inner, richtext.Width(line))
+		}
+	}
+	title := ""
+	if p.Title != "" {
+		if limit > 0 {
+			title = richtext.Truncate(p.Title, max(limit-2, 1), cs.Ellipsis)
+		} else {
+			title = p.Title
+		}
+		inner = max(inner, richtext.Width(title)+2)
+	}
+	if p.Expand && limit > 0 {
+		inner = limit
+	}
+
+	lines := make([]string, 0, len(body)+2)
+	top := cs.TopLeft + cs.Horizontal
+	if title != "" {
+		fill := inner - richtext.Width(title) - 1
+		top = border(top+" ") + tag.Themed(cmp.Or(p.TitleRole, titleRole), title) +
+			border(" "+strings.Repeat(cs.Horizontal, fill)+cs.TopRight)
+	} else {
+		top = border(top + strings.Repeat(cs.Horizontal, inner+1) + cs.TopRight)
+	}
+	lines = append(lines, top)
+	for _, line := range body {
+		pad := strings.Repeat(" ", inner-richtext.Width(line))
+		lines = append(lines, border(cs.Vertical)+" "+line+pad+" "+border(cs.Vertical))
+	}
+	lines = append(lines, border(cs.BottomLeft+strings.Repeat(cs.Horizontal, inner+2)+cs.BottomRight))
+	return strings.Join(lines, "\n")
+}
+
+var _ Component = Panel{}
@@ -0,0 +1,153 @@
+package layout_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bitwizeshift/go-cli/richtext/layout"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPanel_Render(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		panel   layout.Panel
+		width   int
+		charset *layout.Charset
+		want    []string
+	}{
+		{
+			name:    "FitsBody",
+			panel:   layout.Panel{Body: "hello"},
+			width:   40,
+			charset: layout.Unicode,
+			want: []string{
+				"╭───────╮",
+				"│ hello │",
+				"╰───────╯",
+			},
+		},
+		{
+			name:    "Title",
+			panel:   layout.Panel{Title: "Notice", Body: "hi"},
+			width:   40,
+			charset: layout.Unicode,
+			want: []string{
+				"╭─ Notice ─╮",
+				"│ hi       │",
+				"╰──────────╯",
+			},
+		},
+		{
+			name:    "BodyWrapped",
+			panel:   layout.Panel{Body: "the quick brown fox"},
+			width:   14,
+			charset: layout.Unicode,
+			want: []string{
+				"╭───────────╮",
+				"│ the quick │",
+				"│ brown fox │",
+				"╰───────────╯",
+			},
+		},
+		{
+			name:    "TitleShortened",
+			panel:   layout.Panel{Title: "a long title", Body: "x"},
+			width:   12,
+			charset: layout.Unicode,
+			want: []string{
+				"╭─ a lon… ─╮",
+				"│ x        │",
+				"╰──────────╯",
+			},
+		},
+		{
+			name:    "Expand",
+			panel:   layout.Panel{Body: "x", Expand: true},
+			width:   10,
+			charset: layout.Unicode,
+			want: []string{
+				"╭────────╮",
+				"│ x      │",
+				"╰────────╯",
+			},
+		},
+		{
+			name:    "ASCII",
+			panel:   layout.Panel{Title: "T", Body: "日本"},
+			width:   40,
+			charset: layout.ASCII,
+			want: []string{
+				"+- T --+",
+				"| 日本 |",
+				"+------+",
+			},
+		},
+		{
+			name:    "Unlimited",
+			panel:   layout.Panel{Body: "one two three"},
+			width:   0,
+			charset: layout.ASCII,
+			want: []string{
+				"+---------------+",
+				"| one two three |",
+				"+---------------+",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			rendered := render(t, tc.panel, tc.width, tc.charset)
+
+			// Assert
+			if got, want := rendered, strings.Join(tc.want, "\n"); !cmp.Equal(got, want) {
+				t.Errorf("Panel.Render() = \n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestPanel_Render_Roles(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		panel layout.Panel
+		want  string
+	}{
+		{
+			name:  "Default",
+			panel: layout.Panel{Title: "T", Body: "[fg:red]a b[/fg]"},
+			want: "[theme:gutter]+- [/theme][theme:title]T[/theme][theme:gutter] -+[/theme]\n" +
+				"[theme:gutter]|[/theme] [fg:red]a[/fg]   [theme:gutter]|[/theme]\n" +
+				"[theme:gutter]|[/theme] [fg:red]b[/fg]   [theme:gutter]|[/theme]\n" +
+				"[theme:gutter]+-----+[/theme]",
+		},
+		{
+			name:  "Custom",
+			panel: layout.Panel{Title: "T", BorderRole: "warning", TitleRole: "emphasis"},
+			want: "[theme:warning]+- [/theme][theme:emphasis]T[/theme][theme:warning] -+[/theme]\n" +
+				"[theme:warning]+-----+[/theme]",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			rendered := tc.panel.Render(5, layout.ASCII)
+
+			// Assert
+			if got, want := rendered, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Panel.Render() = %q, want %q", got, want)
+			}
+		})
+	}
+}
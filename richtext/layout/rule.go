@@ -0,0 +1,39 @@
+package layout
+
+import (
+	"cmp"
+	"strings"
+
+	"github.com/bitwizeshift/go-cli/internal/template/tag"
+	"github.com/bitwizeshift/go-cli/richtext"
+)
+
+// Rule is a horizontal line across the width it is rendered at, with an
+// optional title set into it near its start.
+type Rule struct {
+	// Title is the markup set into the line, shortened to fit.
+	Title string
+
+	// LineRole and TitleRole are the theme roles of the line and the title,
+	// "gutter" and "title" when empty.
+	LineRole, TitleRole string
+}
+
+// Render implements [Component]. Without a limit on its width, a rule is only
+// as long as its title needs.
+func (r Rule) Render(width int, cs *Charset) string {
+	line := func(n int) string {
+		return tag.Themed(cmp.Or(r.LineRole, lineRole), strings.Repeat(cs.Horizontal, n))
+	}
+	if r.Title == "" {
+		return line(max(width, 4))
+	}
+	title := r.Title
+	if width > 0 {
+		title = richtext.Truncate(title, max(width-6, 1), cs.Ellipsis)
+	}
+	fill := max(width-richtext.Width(title)-4, 2)
+	return line(2) + " " + tag.Themed(cmp.Or(r.TitleRole, titleRole), title) + " " + line(fill)
+}
+
+var _ Component = Rule{}
@@ -0,0 +1,79 @@
+package layout_test
+
+import (
+	"testing"
+
+	"github.com/bitwizeshift/go-cli/richtext/layout"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRule_Render(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		rule    layout.Rule
+		width   int
+		charset *layout.Charset
+		want    string
+	}{
+		{
+			name:    "Untitled",
+			rule:    layout.Rule{},
+			width:   8,
+			charset: layout.Unicode,
+			want:    "────────",
+		},
+		{
+			name:    "Title",
+			rule:    layout.Rule{Title: "Summary"},
+			width:   20,
+			charset: layout.Unicode,
+			want:    "── Summary ─────────",
+		},
+		{
+			name:    "TitleShortened",
+			rule:    layout.Rule{Title: "Summary"},
+			width:   10,
+			charset: layout.ASCII,
+			want:    "-- S... --",
+		},
+		{
+			name:    "Unlimited",
+			rule:    layout.Rule{Title: "Summary"},
+			width:   0,
+			charset: layout.ASCII,
+			want:    "-- Summary --",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			rendered := render(t, tc.rule, tc.width, tc.charset)
+
+			// Assert
+			if got, want := rendered, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Rule.Render() = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestRule_Render_Roles(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	sut := layout.Rule{Title: "T", LineRole: "quote"}
+
+	// Act
+	rendered := sut.Render(7, layout.ASCII)
+
+	// Assert
+	want := "[theme:quote]--[/theme] [theme:title]T[/theme] [theme:quote]--[/theme]"
+	if got := rendered; !cmp.Equal(got, want) {
+		t.Errorf("Rule.Render() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,87 @@
+package layout
+
+import (
+	"cmp"
+	"strings"
+
+	"github.com/bitwizeshift/go-cli/internal/template/tag"
+)
+
+// Node is an entry of a [Tree]: a label of markup, and the nodes beneath it.
+type Node struct {
+	Label    string
+	Children []Node
+}
+
+// Tree is a hierarchy, such as a dependency graph, drawn as an outline in
+// which each node is joined to its parent by guides. A label too wide for the
+// width is wrapped, its further lines set beneath its first.
+type Tree struct {
+	// Root is the node at the top of the tree. A root without a label is not
+	// drawn, so that each of its children is drawn as the root of a tree of its
+	// own.
+	Root Node
+
+	// GuideRole is the theme role of the guides, "gutter" when empty.
+	GuideRole string
+}
+
+// Render implements [Component].
+func (t Tree) Render(width int, cs *Charset) string {
+	d := treeDrawer{width: width, cs: cs, role: cmp.Or(t.GuideRole, lineRole)}
+	if t.Root.Label == "" {
+		for _, child := range t.Root.Children {
+			d.node(child, "", 0, "", "")
+		}
+	} else {
+		d.node(t.Root, "", 0, "", "")
+	}
+	return strings.Join(d.lines, "\n")
+}
+
+// branchWidth is the number of columns a branch, or the guide that continues
+// past it, occupies.
+const branchWidth = 4
+
+// treeDrawer accumulates the lines of a [Tree].
+type treeDrawer struct {
+	width int
+	cs    *Charset
+	role  string
+	lines []string
+}
+
+// node draws n and the nodes beneath it. prefix is the markup of the guides of
+// n's ancestors, prefixWidth its width, branch the markup joining n to its
+// parent, and guide the markup that continues the parent's guide past n.
+func (d *treeDrawer) node(n Node, prefix string, prefixWidth int, branch, guide string) {
+	indent := prefixWidth
+	if branch != "" {
+		indent += branchWidth
+	}
+	limit := 0
+	if d.width > 0 {
+		limit = max(d.width-indent, 1)
+	}
+	for i, line := range wrapLines(n.Label, limit) {
+		lead := branch
+		if i > 0 {
+			lead = guide
+		}
+		d.lines = append(d.lines, prefix+lead+line)
+	}
+	if branch != "" {
+		prefix, prefixWidth = prefix+guide, indent
+	}
+	for i, child := range n.Children {
+		last := i == len(n.Children)-1
+		joint, past := d.cs.Branch, tag.Themed(d.role, d.cs.Vertical)+"   "
+		if last {
+			joint, past = d.cs.LastBranch, "    "
+		}
+		branch := tag.Themed(d.role, joint+strings.Repeat(d.cs.Horizontal, 2)) + " "
+		d.node(child, prefix, prefixWidth, branch, past)
+	}
+}
+
+var _ Component = Tree{}
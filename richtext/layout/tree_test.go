@@ -0,0 +1,124 @@
+package layout_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bitwizeshift/go-cli/richtext/layout"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTree_Render(t *testing.T) {
+	t.Parallel()
+
+	deps := layout.Node{
+		Label: "example-cli",
+		Children: []layout.Node{
+			{Label: "github.com/spf13/cobra", Children: []layout.Node{
+				{Label: "github.com/spf13/pflag"},
+				{Label: "github.com/inconshreveable/mousetrap"},
+			}},
+			{Label: "github.com/rivo/uniseg"},
+		},
+	}
+
+	testCases := []struct {
+		name    string
+		tree    layout.Tree
+		width   int
+		charset *layout.Charset
+		want    []string
+	}{
+		{
+			name:    "Unicode",
+			tree:    layout.Tree{Root: deps},
+			width:   80,
+			charset: layout.Unicode,
+			want: []string{
+				"example-cli",
+				"├── github.com/spf13/cobra",
+				"│   ├── github.com/spf13/pflag",
+				"│   └── github.com/inconshreveable/mousetrap",
+				"└── github.com/rivo/uniseg",
+			},
+		},
+		{
+			name:    "ASCII",
+			tree:    layout.Tree{Root: deps},
+			width:   80,
+			charset: layout.ASCII,
+			want: []string{
+				"example-cli",
+				"|-- github.com/spf13/cobra",
+				"|   |-- github.com/spf13/pflag",
+				"|   `-- github.com/inconshreveable/mousetrap",
+				"`-- github.com/rivo/uniseg",
+			},
+		},
+		{
+			name: "LabelsWrapped",
+			tree: layout.Tree{Root: layout.Node{Label: "root", Children: []layout.Node{
+				{Label: "first child label", Children: []layout.Node{{Label: "grandchild label"}}},
+				{Label: "last child label"},
+			}}},
+			width:   18,
+			charset: layout.Unicode,
+			want: []string{
+				"root",
+				"├── first child",
+				"│   label",
+				"│   └── grandchild",
+				"│       label",
+				"└── last child",
+				"    label",
+			},
+		},
+		{
+			name: "UnlabelledRoot",
+			tree: layout.Tree{Root: layout.Node{Children: []layout.Node{
+				{Label: "a", Children: []layout.Node{{Label: "b"}}},
+				{Label: "c"},
+			}}},
+			width:   80,
+			charset: layout.Unicode,
+			want: []string{
+				"a",
+				"└── b",
+				"c",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			rendered := render(t, tc.tree, tc.width, tc.charset)
+
+			// Assert
+			if got, want := rendered, strings.Join(tc.want, "\n"); !cmp.Equal(got, want) {
+				t.Errorf("Tree.Render() = \n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestTree_Render_Roles(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	sut := layout.Tree{
+		Root:      layout.Node{Label: "a", Children: []layout.Node{{Label: "b"}}},
+		GuideRole: "quote",
+	}
+
+	// Act
+	rendered := sut.Render(80, layout.ASCII)
+
+	// Assert
+	want := "a\n[theme:quote]`--[/theme] b"
+	if got := rendered; !cmp.Equal(got, want) {
+		t.Errorf("Tree.Render() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,321 @@
+package markdown
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	fenceLine   = regexp.MustCompile("^( {0,3})(`{3,}|~{3,})(.*)$")
+	headingLine = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ ]+(.*?))?(?:[ ]+#+)?[ ]*$`)
+	quoteLine   = regexp.MustCompile(`^ {0,3}> ?(.*)$`)
+	itemLine    = regexp.MustCompile(`^( {0,3})([-*+]|[0-9]{1,9}[.)])(?:( +)(.*))?$`)
+)
+
+// codeIndent indents the lines of a code block from the surrounding prose.
+const codeIndent = "  "
+
+// quoteGutter marks each line of a block quote.
+const quoteGutter = "│"
+
+// block is one block-level element of a Markdown document.
+type block interface {
+	// render returns the lines of markup for the block, wrapped to fit within
+	// width where the block wraps at all.
+	render(width int) []string
+}
+
+// paragraph is a run of prose, wrapped as a whole.
+type paragraph struct {
+	text string
+}
+
+func (p paragraph) render(width int) []string {
+	return wrapSpans(parseInline(p.text, nil), width)
+}
+
+// heading is an ATX heading: a level 1 heading is styled as a title, and any
+// deeper heading as a heading.
+type heading struct {
+	level int
+	text  string
+}
+
+func (h heading) render(width int) []string {
+	role := "heading"
+	if h.level == 1 {
+		role = "title"
+	}
+	return wrapSpans(parseInline(h.text, []tagPair{themed(role)}), width)
+}
+
+// codeBlock is a fenced code block, whose lines are shown verbatim.
+type codeBlock struct {
+	lines []string
+}
+
+func (c codeBlock) render(int) []string {
+	lines := make([]string, len(c.lines))
+	for i, line := range c.lines {
+		if line != "" {
+			lines[i] = codeIndent + renderLine([]word{{pieces: []span{{text: line, tags: []tagPair{codeTag}}}}})
+		}
+	}
+	return lines
+}
+
+// quote is a block quote, holding the blocks quoted within it.
+type quote struct {
+	children []block
+}
+
+func (q quote) render(width int) []string {
+	lines := renderBlocks(q.children, narrow(width, len(quoteGutter)+1), true)
+	for i, line := range prefixLines(lines, quoteGutter+" ", quoteGutter+" ") {
+		lines[i] = quoteTag.open + line + quoteTag.close
+	}
+	return lines
+}
+
+// list is a bulleted or numbered list. A loose list, one whose items are
+// separated by blank lines, keeps them separated.
+type list struct {
+	ordered bool
+	bullet  string
+	start   int
+	items   [][]block
+	loose   bool
+}
+
+func (l list) render(width int) []string {
+	markers := make([]string, len(l.items))
+	size := 0
+	for i := range l.items {
+		markers[i] = l.bullet
+		if l.ordered {
+			markers[i] = strconv.Itoa(l.start+i) + l.bullet
+		}
+		size = max(size, len(markers[i]))
+	}
+	var lines []string
+	for i, item := range l.items {
+		if i > 0 && l.loose {
+			lines = append(lines, "")
+		}
+		marker := markers[i] + strings.Repeat(" ", size-len(markers[i])+1)
+		content := renderBlocks(item, narrow(width, len(marker)), l.loose)
+		if len(content) == 0 {
+			content = []string{""}
+		}
+		lines = append(lines, prefixLines(content, marker, strings.Repeat(" ", len(marker)))...)
+	}
+	return lines
+}
+
+// listMarker is the marker that opens a list item.
+type listMarker struct {
+	bullet  string // the bullet character, or the delimiter after a number
+	ordered bool
+	number  int
+	indent  int    // the column the item's content begins at
+	content string // the content on the marker's own line
+}
+
+// sameList reports whether an item opened by m continues the list opened by
+// first, which requires the same bullet or number delimiter.
+func (m listMarker) sameList(first listMarker) bool {
+	return m.ordered == first.ordered && m.bullet == first.bullet
+}
+
+// matchItem reports whether line opens a list item, and its marker.
+func matchItem(line string) (listMarker, bool) {
+	match := itemLine.FindStringSubmatch(line)
+	if match == nil {
+		return listMarker{}, false
+	}
+	indent, marker, spaces, content := match[1], match[2], match[3], match[4]
+	m := listMarker{bullet: marker, content: content}
+	if last := marker[len(marker)-1]; last == '.' || last == ')' {
+		m.ordered = true
+		m.bullet = string(last)
+		m.number, _ = strconv.Atoi(marker[:len(marker)-1]) // matched as digits above
+	}
+	if spaces == "" || len(spaces) > 4 {
+		spaces = " "
+		m.content = strings.TrimSpace(content)
+	}
+	m.indent = len(indent) + len(marker) + len(spaces)
+	return m, true
+}
+
+// parseBlocks parses the lines of a Markdown document, or of a container such
+// as a list item, into its blocks.
+func parseBlocks(lines []string) []block {
+	var blocks []block
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		var b block
+		var n int
+		switch {
+		case strings.TrimSpace(line) == "":
+			i++
+			continue
+		case fenceLine.MatchString(line):
+			b, n = parseFence(lines[i:])
+		case headingLine.MatchString(line):
+			b, n = parseHeading(line), 1
+		case quoteLine.MatchString(line):
+			b, n = parseQuote(lines[i:])
+		default:
+			if m, ok := matchItem(line); ok {
+				b, n = parseList(lines[i:], m)
+			} else {
+				b, n = parseParagraph(lines[i:])
+			}
+		}
+		blocks = append(blocks, b)
+		i += n
+	}
+	return blocks
+}
+
+// interrupts reports whether line starts a block of its own rather than
+// continuing a paragraph.
+func interrupts(line string) bool {
+	if strings.TrimSpace(line) == "" || fenceLine.MatchString(line) || headingLine.MatchString(line) || quoteLine.MatchString(line) {
+		return true
+	}
+	m, ok := matchItem(line)
+	return ok && m.content != ""
+}
+
+func parseParagraph(lines []string) (block, int) {
+	n := 1
+	for n < len(lines) && !interrupts(lines[n]) {
+		n++
+	}
+	text := make([]string, n)
+	for i, line := range lines[:n] {
+		text[i] = strings.TrimSpace(line)
+	}
+	return paragraph{text: strings.Join(text, "\n")}, n
+}
+
+func parseHeading(line string) block {
+	match := headingLine.FindStringSubmatch(line)
+	return heading{level: len(match[1]), text: match[2]}
+}
+
+// parseFence parses a fenced code block, which runs until a closing fence of
+// the same character at least as long as the opening one, or to the end of
+// lines. The fence's own indentation is removed from each line.
+func parseFence(lines []string) (block, int) {
+	match := fenceLine.FindStringSubmatch(lines[0])
+	indent, fence := len(match[1]), match[2]
+	var code codeBlock
+	n := 1
+	for ; n < len(lines); n++ {
+		line := lines[n]
+		trimmed := strings.TrimLeft(line, " ")
+		if len(line)-len(trimmed) <= 3 && strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]+" ") == "" {
+			n++
+			break
+		}
+		strip := min(indent, len(line)-len(trimmed))
+		code.lines = append(code.lines, line[strip:])
+	}
+	return code, n
+}
+
+// parseQuote parses a block quote from its run of lines marked with '>'.
+func parseQuote(lines []string) (block, int) {
+	var inner []string
+	n := 0
+	for ; n < len(lines); n++ {
+		match := quoteLine.FindStringSubmatch(lines[n])
+		if match == nil {
+			break
+		}
+		inner = append(inner, match[1])
+	}
+	return quote{children: parseBlocks(inner)}, n
+}
+
+// parseList parses the run of items that share the bullet or number delimiter
+// of first, the marker of the opening line.
+func parseList(lines []string, first listMarker) (block, int) {
+	l := list{ordered: first.ordered, bullet: first.bullet, start: first.number}
+	n := 0
+	for n < len(lines) {
+		m, ok := matchItem(lines[n])
+		if !ok || !m.sameList(first) {
+			break
+		}
+		content, used, loose := parseItem(lines[n:], m)
+		l.items = append(l.items, parseBlocks(content))
+		l.loose = l.loose || loose
+		n += used
+
+		next := n
+		for next < len(lines) && strings.TrimSpace(lines[next]) == "" {
+			next++
+		}
+		if next == n {
+			continue
+		}
+		if m, ok := matchItem(get(lines, next)); !ok || !m.sameList(first) {
+			break
+		}
+		l.loose = true
+		n = next
+	}
+	return l, n
+}
+
+// parseItem gathers the content lines of the list item opened by m: those
+// indented to its content, and any unindented lines that continue its
+// paragraph. It reports how many lines the item spans, excluding trailing blank
+// lines, and whether blank lines separate its content.
+func parseItem(lines []string, m listMarker) (content []string, n int, loose bool) {
+	content = []string{m.content}
+	n = 1
+	blanks := 0
+	for i := 1; i < len(lines); i++ {
+		line := lines[i]
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		switch {
+		case strings.TrimSpace(line) == "":
+			blanks++
+			continue
+		case indent >= m.indent:
+			line = line[m.indent:]
+		case blanks == 0 && !interrupts(line) && continuesParagraph(content):
+			line = strings.TrimSpace(line)
+		default:
+			return content, n, loose
+		}
+		for ; blanks > 0; blanks-- {
+			content = append(content, "")
+			loose = true
+		}
+		content = append(content, line)
+		n = i + 1
+	}
+	return content, n, loose
+}
+
+// continuesParagraph reports whether the last of content is prose that an
+// unindented line may continue.
+func continuesParagraph(content []string) bool {
+	last := content[len(content)-1]
+	return strings.TrimSpace(last) != "" && !fenceLine.MatchString(last)
+}
+
+// get returns lines[i], or "" when i is out of range.
+func get(lines []string, i int) string {
+	if i < len(lines) {
+		return lines[i]
+	}
+	return ""
+}
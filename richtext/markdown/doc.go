@@ -0,0 +1,11 @@
+// Package markdown renders Markdown into richtext markup, so that prose written
+// as Markdown — such as the descriptions of a command specification — is shown
+// styled and wrapped to the terminal.
+//
+// It covers the subset of Markdown that reads well in a terminal: paragraphs,
+// headings, emphasis, inline code, fenced code blocks, bulleted, numbered and
+// nested lists, block quotes, and links. Anything else is shown as the text it
+// was written as. The result is markup for a
+// [github.com/bitwizeshift/go-cli/richtext.Writer], which styles it through the
+// theme roles "title", "heading", "code", "quote", and "url".
+package markdown
@@ -0,0 +1,354 @@
+package markdown
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// tagPair is a richtext tag together with the tag that closes it.
+type tagPair struct {
+	open, close string
+}
+
+var (
+	boldTag   = tagPair{open: "[attr:bold]", close: "[/attr]"}
+	italicTag = tagPair{open: "[attr:italic]", close: "[/attr]"}
+	codeTag   = themed("code")
+	quoteTag  = themed("quote")
+	urlTag    = themed("url")
+)
+
+// themed returns the tag styling its contents with the theme's role.
+func themed(role string) tagPair {
+	return tagPair{open: "[theme:" + role + "]", close: "[/theme]"}
+}
+
+// linkTag returns the tag linking its contents to url. Square brackets in url
+// are percent-encoded, so they cannot end the tag early.
+func linkTag(url string) tagPair {
+	url = strings.NewReplacer("[", "%5B", "]", "%5D").Replace(url)
+	return tagPair{open: "[link:" + url + "]", close: "[/link]"}
+}
+
+// span is a run of visible text, styled by the tags enclosing it, outermost
+// first. The text of a nobreak span is never split across lines.
+type span struct {
+	text    string
+	tags    []tagPair
+	nobreak bool
+}
+
+type inlineKind int
+
+const (
+	textInline inlineKind = iota
+	codeInline
+	delimInline
+	linkInline
+)
+
+// inline is one element of a paragraph's text, as tokenized before emphasis is
+// resolved.
+type inline struct {
+	kind inlineKind
+
+	// text is the text of a text or code element, or the character of a run of
+	// emphasis delimiters.
+	text string
+
+	// count is the number of delimiters of a run left unmatched, and length the
+	// number it began with. A delimiter run opens the tags in opens, outermost
+	// first, and closes those in closes, innermost first.
+	count, length     int
+	canOpen, canClose bool
+	opens, closes     []tagPair
+
+	// url is the target of a link, whose text is children.
+	url      string
+	children []*inline
+}
+
+var autolink = regexp.MustCompile(`^<([a-zA-Z][a-zA-Z0-9+.\-]{1,31}:[^\s<>]*)>`)
+
+// parseInline parses the inline Markdown of text into spans, each enclosed by
+// the tags of base.
+func parseInline(text string, base []tagPair) []span {
+	var spans []span
+	flatten(tokenize(text), base, false, &spans)
+	return spans
+}
+
+// tokenize splits s into its inline elements, and resolves which emphasis
+// delimiters among them match.
+func tokenize(s string) []*inline {
+	var toks []*inline
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			toks = append(toks, &inline{kind: textInline, text: text.String()})
+			text.Reset()
+		}
+	}
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && isASCIIPunct(s[i+1]):
+			text.WriteByte(s[i+1])
+			i += 2
+		case c == '`':
+			n := runLength(s, i)
+			end := closingCode(s, i+n, n)
+			if end < 0 {
+				text.WriteString(s[i : i+n])
+				i += n
+				continue
+			}
+			flush()
+			toks = append(toks, &inline{kind: codeInline, text: codeContent(s[i+n : end])})
+			i = end + n
+		case c == '*' || c == '_':
+			n := runLength(s, i)
+			flush()
+			toks = append(toks, delimiter(s, i, n))
+			i += n
+		case c == '[':
+			link, n, ok := parseLink(s[i:])
+			if !ok {
+				text.WriteByte(c)
+				i++
+				continue
+			}
+			flush()
+			toks = append(toks, link)
+			i += n
+		case c == '<':
+			match := autolink.FindStringSubmatch(s[i:])
+			if match == nil {
+				text.WriteByte(c)
+				i++
+				continue
+			}
+			flush()
+			toks = append(toks, &inline{
+				kind:     linkInline,
+				url:      match[1],
+				children: []*inline{{kind: textInline, text: match[1]}},
+			})
+			i += len(match[0])
+		default:
+			text.WriteByte(c)
+			i++
+		}
+	}
+	flush()
+	matchEmphasis(toks)
+	return toks
+}
+
+// runLength returns the length of the run of the character at s[i].
+func runLength(s string, i int) int {
+	n := 1
+	for i+n < len(s) && s[i+n] == s[i] {
+		n++
+	}
+	return n
+}
+
+// closingCode returns the index of the run of exactly n backticks closing a
+// code span from s[from:], or -1 when there is none.
+func closingCode(s string, from, n int) int {
+	for i := from; i < len(s); {
+		if s[i] != '`' {
+			i++
+			continue
+		}
+		run := runLength(s, i)
+		if run == n {
+			return i
+		}
+		i += run
+	}
+	return -1
+}
+
+// codeContent returns the text of a code span: its line breaks become spaces,
+// and a single space padding both ends is removed.
+func codeContent(s string) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	if len(s) >= 2 && s[0] == ' ' && s[len(s)-1] == ' ' && strings.Trim(s, " ") != "" {
+		s = s[1 : len(s)-1]
+	}
+	return s
+}
+
+// delimiter returns the run of n emphasis delimiters at s[i], judging whether
+// it can open or close emphasis from the characters either side of it.
+func delimiter(s string, i, n int) *inline {
+	before, after := ' ', ' '
+	if i > 0 {
+		before, _ = utf8.DecodeLastRuneInString(s[:i])
+	}
+	if i+n < len(s) {
+		after, _ = utf8.DecodeRuneInString(s[i+n:])
+	}
+	left := !unicode.IsSpace(after) && (!isPunct(after) || unicode.IsSpace(before) || isPunct(before))
+	right := !unicode.IsSpace(before) && (!isPunct(before) || unicode.IsSpace(after) || isPunct(after))
+	d := &inline{kind: delimInline, text: s[i : i+1], count: n, length: n, canOpen: left, canClose: right}
+	if s[i] == '_' {
+		d.canOpen = left && (!right || isPunct(before))
+		d.canClose = right && (!left || isPunct(after))
+	}
+	return d
+}
+
+// parseLink parses the link "[text](url)" that s begins with, returning it and
+// its length.
+func parseLink(s string) (*inline, int, bool) {
+	textEnd := closingBracket(s, '[', ']')
+	if textEnd < 0 || textEnd+1 >= len(s) || s[textEnd+1] != '(' {
+		return nil, 0, false
+	}
+	destEnd := closingBracket(s[textEnd+1:], '(', ')')
+	if destEnd < 0 {
+		return nil, 0, false
+	}
+	fields := strings.Fields(s[textEnd+2 : textEnd+1+destEnd])
+	if len(fields) == 0 {
+		return nil, 0, false
+	}
+	url := strings.TrimSuffix(strings.TrimPrefix(fields[0], "<"), ">")
+	link := &inline{kind: linkInline, url: unescape(url), children: tokenize(s[1:textEnd])}
+	return link, textEnd + destEnd + 2, true
+}
+
+// closingBracket returns the index of the close bracket matching the open
+// bracket s begins with, or -1 when it is never closed.
+func closingBracket(s string, open, close byte) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case open:
+			depth++
+		case close:
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// unescape removes the backslashes escaping punctuation in s.
+func unescape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && isASCIIPunct(s[i+1]) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// matchEmphasis pairs the emphasis delimiters of toks, matching each closing
+// run with the nearest open run of the same character. A pair of two
+// delimiters a side is bold, and a pair of one is italic; delimiters left
+// unmatched are shown as written.
+func matchEmphasis(toks []*inline) {
+	var openers []*inline
+	for _, closer := range toks {
+		if closer.kind != delimInline {
+			continue
+		}
+		for closer.canClose && closer.count > 0 {
+			i := len(openers) - 1
+			for i >= 0 && (openers[i].text != closer.text || oddMatch(openers[i], closer)) {
+				i--
+			}
+			if i < 0 {
+				break
+			}
+			opener := openers[i]
+			n, tag := 1, italicTag
+			if opener.count >= 2 && closer.count >= 2 {
+				n, tag = 2, boldTag
+			}
+			opener.count -= n
+			closer.count -= n
+			opener.opens = append([]tagPair{tag}, opener.opens...)
+			closer.closes = append(closer.closes, tag)
+			openers = openers[:i+1]
+			if opener.count == 0 {
+				openers = openers[:i]
+			}
+		}
+		if closer.canOpen && closer.count > 0 {
+			openers = append(openers, closer)
+		}
+	}
+}
+
+// oddMatch reports whether opener and closer may not match because one of them
+// could both open and close, and their lengths sum to a multiple of three that
+// they are not both multiples of, as "*foo**bar*" must not match "*" with
+// "**".
+func oddMatch(opener, closer *inline) bool {
+	if !opener.canClose && !closer.canOpen {
+		return false
+	}
+	sum := opener.length + closer.length
+	return sum%3 == 0 && (opener.length%3 != 0 || closer.length%3 != 0)
+}
+
+// flatten appends the text of toks to spans, enclosed by the tags of stack and
+// those opened among toks.
+func flatten(toks []*inline, stack []tagPair, nobreak bool, spans *[]span) {
+	for _, t := range toks {
+		switch t.kind {
+		case textInline:
+			appendSpan(spans, span{text: t.text, tags: stack, nobreak: nobreak})
+		case codeInline:
+			appendSpan(spans, span{text: t.text, tags: with(stack, codeTag), nobreak: nobreak})
+		case linkInline:
+			flatten(t.children, with(stack, linkTag(t.url), urlTag), true, spans)
+		case delimInline:
+			stack = stack[:len(stack)-len(t.closes)]
+			if t.count > 0 {
+				appendSpan(spans, span{text: strings.Repeat(t.text, t.count), tags: stack, nobreak: nobreak})
+			}
+			stack = with(stack, t.opens...)
+		}
+	}
+}
+
+// with returns a copy of stack with tags pushed onto it.
+func with(stack []tagPair, tags ...tagPair) []tagPair {
+	return append(slices.Clip(stack), tags...)
+}
+
+// appendSpan appends s to spans, merging it into the last span when both are
+// styled alike.
+func appendSpan(spans *[]span, s span) {
+	if n := len(*spans); n > 0 {
+		last := &(*spans)[n-1]
+		if last.nobreak == s.nobreak && slices.Equal(last.tags, s.tags) {
+			last.text += s.text
+			return
+		}
+	}
+	*spans = append(*spans, s)
+}
+
+func isASCIIPunct(c byte) bool {
+	return c < utf8.RuneSelf && isPunct(rune(c))
+}
+
+func isPunct(r rune) bool {
+	return unicode.IsPunct(r) || unicode.IsSymbol(r)
+}
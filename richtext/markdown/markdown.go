@@ -0,0 +1,59 @@
+package markdown
+
+import (
+	"strings"
+)
+
+// Render converts the Markdown in src into richtext markup. Paragraphs,
+// headings, list items and quotes are wrapped to fit within columns, breaking
+// at whitespace; a non-positive columns leaves each on a single line. Code
+// blocks are never wrapped.
+//
+// Text in src is not itself read as markup, so a bracketed sequence such as
+// "[fg:red]" is shown as written. Every line of the result opens and closes its
+// own tags, so the lines may be indented or rearranged independently.
+func Render(src string, columns int) string {
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	src = strings.ReplaceAll(src, "\t", "    ")
+	blocks := parseBlocks(strings.Split(strings.Trim(src, "\n"), "\n"))
+	return strings.Join(renderBlocks(blocks, columns, true), "\n")
+}
+
+// renderBlocks renders blocks in turn to fit within width, separating each from
+// the next with a blank line when loose.
+func renderBlocks(blocks []block, width int, loose bool) []string {
+	var lines []string
+	for i, b := range blocks {
+		if i > 0 && loose {
+			lines = append(lines, "")
+		}
+		lines = append(lines, b.render(width)...)
+	}
+	return lines
+}
+
+// narrow returns the width left after indenting by n columns, keeping a
+// non-positive width unwrapped and a positive width at least 1.
+func narrow(width, n int) int {
+	if width <= 0 {
+		return width
+	}
+	return max(width-n, 1)
+}
+
+// prefixLines prefixes the first of lines with first and the rest with rest,
+// trimming the trailing spaces a prefix leaves on an empty line.
+func prefixLines(lines []string, first, rest string) []string {
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		prefix := rest
+		if i == 0 {
+			prefix = first
+		}
+		if line == "" {
+			prefix = strings.TrimRight(prefix, " ")
+		}
+		out[i] = prefix + line
+	}
+	return out
+}
@@ -0,0 +1,248 @@
+package markdown_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/bitwizeshift/go-cli/richtext"
+	"github.com/bitwizeshift/go-cli/richtext/markdown"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		input   string
+		columns int
+		want    []string
+	}{
+		{
+			name:    "Empty",
+			input:   "",
+			columns: 80,
+			want:    []string{""},
+		}, {
+			name:    "ParagraphWraps",
+			input:   "one two\nthree four five",
+			columns: 9,
+			want:    []string{"one two", "three", "four five"},
+		}, {
+			name:    "NonPositiveColumnsUnwrapped",
+			input:   "one two\nthree four five",
+			columns: 0,
+			want:    []string{"one two three four five"},
+		}, {
+			name:    "WideCharactersCountTwoColumns",
+			input:   "日本語 の 説明",
+			columns: 8,
+			want:    []string{"日本語", "の 説明"},
+		}, {
+			name:    "WideTextBreaksBetweenCharacters",
+			input:   "設定ファイルを**読み込み**ます",
+			columns: 10,
+			want: []string{
+				"設定ファイ",
+				"ルを[attr:bold]読み込[/attr]",
+				"[attr:bold]み[/attr]ます",
+			},
+		}, {
+			name:    "ParagraphsSeparated",
+			input:   "one\n\n\ntwo\n",
+			columns: 80,
+			want:    []string{"one", "", "two"},
+		}, {
+			name:    "Headings",
+			input:   "# Title #\n## Section\n###### Deep\n#hashtag",
+			columns: 80,
+			want: []string{
+				"[theme:title]Title[/theme]",
+				"",
+				"[theme:heading]Section[/theme]",
+				"",
+				"[theme:heading]Deep[/theme]",
+				"",
+				"#hashtag",
+			},
+		}, {
+			name:    "Emphasis",
+			input:   "*a* _b_ **c** __d__ ***e***",
+			columns: 80,
+			want: []string{
+				"[attr:italic]a b[/attr] [attr:bold]c d[/attr] [attr:italic][attr:bold]e[/attr][/attr]",
+			},
+		}, {
+			name:    "NestedEmphasis",
+			input:   "*a **b** c*",
+			columns: 80,
+			want:    []string{"[attr:italic]a [attr:bold]b[/attr] c[/attr]"},
+		}, {
+			name:    "UnmatchedDelimitersLiteral",
+			input:   "a * b ** c snake_case_name *d",
+			columns: 80,
+			want:    []string{"a * b ** c snake_case_name *d"},
+		}, {
+			name:    "EscapedDelimiters",
+			input:   `\*not emphasis\*`,
+			columns: 80,
+			want:    []string{"*not emphasis*"},
+		}, {
+			name:    "EmphasisWrapsAcrossLines",
+			input:   "*one two*",
+			columns: 4,
+			want:    []string{"[attr:italic]one[/attr]", "[attr:italic]two[/attr]"},
+		}, {
+			name:    "InlineCode",
+			input:   "run `go test` or ``a ` b``",
+			columns: 80,
+			want:    []string{"run [theme:code]go test[/theme] or [theme:code]a ` b[/theme]"},
+		}, {
+			name:    "InlineCodeIsNotMarkdown",
+			input:   "`*a*`",
+			columns: 80,
+			want:    []string{"[theme:code]*a*[/theme]"},
+		}, {
+			name:    "BracketsAreNotMarkup",
+			input:   "see [fg:red] and `[x]`",
+			columns: 80,
+			want: []string{
+				"see [richtext:off][fg:red][/richtext] and [theme:code][richtext:off][x][/richtext][/theme]",
+			},
+		}, {
+			name:    "Link",
+			input:   "see [the docs](https://example.com \"Title\") now",
+			columns: 80,
+			want:    []string{"see [link:https://example.com][theme:url]the docs[/theme][/link] now"},
+		}, {
+			name:    "LinkTextIsNotBroken",
+			input:   "see [the docs](https://example.com)",
+			columns: 5,
+			want:    []string{"see", "[link:https://example.com][theme:url]the docs[/theme][/link]"},
+		}, {
+			name:    "LinkURLBracketsEncoded",
+			input:   "[x](https://example.com/?a[0]=1)",
+			columns: 80,
+			want:    []string{"[link:https://example.com/?a%5B0%5D=1][theme:url]x[/theme][/link]"},
+		}, {
+			name:    "Autolink",
+			input:   "<https://go.dev>",
+			columns: 80,
+			want:    []string{"[link:https://go.dev][theme:url]https://go.dev[/theme][/link]"},
+		}, {
+			name:    "BulletsWrapUnderTheirText",
+			input:   "- one two three\n* four",
+			columns: 9,
+			want:    []string{"- one two", "  three", "", "* four"},
+		}, {
+			name:    "BulletContinuesLazily",
+			input:   "- one\ntwo\n- three",
+			columns: 80,
+			want:    []string{"- one two", "- three"},
+		}, {
+			name:    "NumberedListAlignsMarkers",
+			input:   "9. nine\n10. ten words",
+			columns: 9,
+			want:    []string{"9.  nine", "10. ten", "    words"},
+		}, {
+			name:    "NumberedListCountsFromStart",
+			input:   "3) a\n3) b",
+			columns: 80,
+			want:    []string{"3) a", "4) b"},
+		}, {
+			name:    "NestedLists",
+			input:   "- a\n  1. b\n  2. c\n     - d\n- e",
+			columns: 80,
+			want:    []string{"- a", "  1. b", "  2. c", "     - d", "- e"},
+		}, {
+			name:    "LooseList",
+			input:   "- a\n\n- b",
+			columns: 80,
+			want:    []string{"- a", "", "- b"},
+		}, {
+			name:    "ListItemParagraphs",
+			input:   "- a\n\n  more\n- b",
+			columns: 80,
+			want:    []string{"- a", "", "  more", "", "- b"},
+		}, {
+			name:    "ListEndsAtParagraph",
+			input:   "- a\n\nafter",
+			columns: 80,
+			want:    []string{"- a", "", "after"},
+		}, {
+			name:    "BlockQuote",
+			input:   "> one *two*\n>\n> three",
+			columns: 80,
+			want: []string{
+				"[theme:quote]│ one [attr:italic]two[/attr][/theme]",
+				"[theme:quote]│[/theme]",
+				"[theme:quote]│ three[/theme]",
+			},
+		}, {
+			name:    "BlockQuoteWraps",
+			input:   "> one two",
+			columns: 6,
+			want:    []string{"[theme:quote]│ one[/theme]", "[theme:quote]│ two[/theme]"},
+		}, {
+			name:    "FencedCodeNotWrapped",
+			input:   "```go\nfmt.Println(\"a b c\")\n\n  x[0]\n```\nafter",
+			columns: 5,
+			want: []string{
+				"  [theme:code]fmt.Println(\"a b c\")[/theme]",
+				"",
+				"  [theme:code][richtext:off]  x[0][/richtext][/theme]",
+				"",
+				"after",
+			},
+		}, {
+			name:    "UnclosedFenceRunsToEnd",
+			input:   "~~~\n*a*",
+			columns: 80,
+			want:    []string{"  [theme:code]*a*[/theme]"},
+		}, {
+			name:    "FenceInListItem",
+			input:   "- a\n  ```\n  code\n  ```",
+			columns: 80,
+			want:    []string{"- a", "    [theme:code]code[/theme]"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			rendered := markdown.Render(tc.input, tc.columns)
+
+			// Assert
+			if got, want := rendered, strings.Join(tc.want, "\n"); !cmp.Equal(got, want) {
+				t.Errorf("Render() = mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestRender_LinesAreBalanced(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	input := "# *Title*\n\n> - *a long item [with a link](https://x.y) that wraps*\n> - **b `c`**\n\n***e** f*"
+
+	for _, line := range strings.Split(markdown.Render(input, 12), "\n") {
+		w := richtext.NewWriter(io.Discard, richtext.DefaultTheme)
+		w.ForceColour()
+
+		// Act
+		_, err := io.WriteString(w, line)
+		if err == nil {
+			err = w.Close()
+		}
+
+		// Assert
+		if got, want := err, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+			t.Errorf("Write(%q) = %v, want nil", line, got)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package markdown
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/bitwizeshift/go-cli/internal/format"
+	"github.com/bitwizeshift/go-cli/internal/template/tag"
+)
+
+// word is a run of text that is never broken across lines, made of the pieces
+// of its spans. A glued word follows the previous one without a space, as the
+// characters of text written without spaces between its words do.
+type word struct {
+	pieces []span
+	width  int
+	glued  bool
+}
+
+// add appends text styled by tags to w.
+func (w *word) add(text string, tags []tagPair) {
+	if text == "" {
+		return
+	}
+	w.width += format.Width(text)
+	if n := len(w.pieces); n > 0 && slices.Equal(w.pieces[n-1].tags, tags) {
+		w.pieces[n-1].text += text
+		return
+	}
+	w.pieces = append(w.pieces, span{text: text, tags: tags})
+}
+
+// splitWords splits spans into words at whitespace and beside wide characters,
+// except within nobreak spans, whose whitespace is collapsed into single spaces
+// instead.
+func splitWords(spans []span) []word {
+	var words []word
+	var current word
+	flush := func() {
+		if len(current.pieces) > 0 {
+			words = append(words, current)
+			current = word{}
+		}
+	}
+	for _, s := range spans {
+		if s.nobreak {
+			current.add(strings.Join(strings.Fields(s.text), " "), s.tags)
+			continue
+		}
+		for rest := s.text; rest != ""; {
+			end := strings.IndexFunc(rest, unicode.IsSpace)
+			switch {
+			case end == 0:
+				flush()
+				rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+				continue
+			case end < 0:
+				end = len(rest)
+			}
+			for i, piece := range format.Breaks(rest[:end]) {
+				if i > 0 {
+					flush()
+					current.glued = true
+				}
+				current.add(piece, s.tags)
+			}
+			rest = rest[end:]
+		}
+	}
+	flush()
+	return words
+}
+
+// gap returns the width of the space separating w from the previous word.
+func (w word) gap() int {
+	if w.glued {
+		return 0
+	}
+	return 1
+}
+
+// wrapSpans packs the words of spans into lines no wider than width, returning
+// the markup of each. A word wider than width occupies its own line, and a
+// non-positive width packs every word onto one line.
+func wrapSpans(spans []span, width int) []string {
+	words := splitWords(spans)
+	if len(words) == 0 {
+		return nil
+	}
+	var lines []string
+	start, used := 0, words[0].width
+	for i, w := range words[1:] {
+		if width <= 0 || used+w.gap()+w.width <= width {
+			used += w.gap() + w.width
+			continue
+		}
+		lines = append(lines, renderLine(words[start:i+1]))
+		start, used = i+1, w.width
+	}
+	return append(lines, renderLine(words[start:]))
+}
+
+// renderLine returns the markup of words separated by spaces, opening and
+// closing tags only as the style changes, and closing every tag by the end of
+// the line. A space takes the style its neighbours share.
+func renderLine(words []word) string {
+	var b strings.Builder
+	var open []tagPair
+	write := func(text string, tags []tagPair) {
+		common := commonPrefix(open, tags)
+		for i := len(open) - 1; i >= common; i-- {
+			b.WriteString(open[i].close)
+		}
+		for _, t := range tags[common:] {
+			b.WriteString(t.open)
+		}
+		open = tags
+		b.WriteString(escape(text))
+	}
+	for i, w := range words {
+		if i > 0 && !w.glued {
+			before := words[i-1].pieces[len(words[i-1].pieces)-1].tags
+			after := w.pieces[0].tags
+			write(" ", before[:commonPrefix(before, after)])
+		}
+		for _, p := range w.pieces {
+			write(p.text, p.tags)
+		}
+	}
+	write("", nil)
+	return b.String()
+}
+
+// commonPrefix returns the number of leading tags a and b share.
+func commonPrefix(a, b []tagPair) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return n
+}
+
+// escape returns text as markup that shows it verbatim.
+func escape(text string) string {
+	if strings.ContainsRune(text, '[') {
+		return tag.Raw(text)
+	}
+	return text
+}
@@ -0,0 +1,59 @@
+package richtext
+
+import (
+	"io"
+
+	"github.com/bitwizeshift/go-cli/richtext/internal/sgr"
+	"github.com/bitwizeshift/go-cli/richtext/style"
+)
+
+// output is the format a [Writer] renders onto. The Writer scans the markup
+// and tracks the open tags; the output only hears of the text in between and
+// of each change in the style and hyperlink it is shown in.
+type output interface {
+	// restyle moves the output to style s, within the hyperlink to url, or
+	// outside any hyperlink when url is empty. It is not called while colour is
+	// disabled.
+	restyle(s style.Style, url string) error
+
+	// text writes visible text in the current style.
+	text(s string) error
+
+	// close finishes the output once the markup is complete.
+	close() error
+}
+
+// ansiOutput renders onto a terminal as SGR and OSC 8 escapes, writing only
+// the escapes that change the terminal's state.
+type ansiOutput struct {
+	dst      io.Writer
+	lastBody string
+	lastLink string
+}
+
+func (o *ansiOutput) restyle(s style.Style, url string) error {
+	if url != o.lastLink {
+		o.lastLink = url
+		if err := o.text("\x1b]8;;" + url + "\x1b\\"); err != nil {
+			return err
+		}
+	}
+	body := s.String()
+	if body == o.lastBody {
+		return nil
+	}
+	o.lastBody = body
+	if body == "" {
+		return o.text(sgr.Reset)
+	}
+	return o.text(sgr.Reset + body)
+}
+
+func (o *ansiOutput) text(s string) error {
+	_, err := io.WriteString(o.dst, s)
+	return err
+}
+
+func (o *ansiOutput) close() error {
+	return nil
+}
@@ -0,0 +1,76 @@
+package richtext
+
+import (
+	"cmp"
+	"strings"
+
+	"github.com/bitwizeshift/go-cli/richtext/style"
+)
+
+// scheme is the set of colours a document rendered outside the terminal is
+// shown in: the values of the named colours, and the text and background
+// colours that apply where the markup sets none.
+type scheme struct {
+	palette    style.Palette
+	foreground style.Colour
+	background style.Colour
+}
+
+// darkScheme and lightScheme follow the default dark and light terminal themes
+// of common editors, whose named colours read well against their backgrounds.
+var (
+	darkScheme = scheme{
+		palette: style.Palette{
+			style.RGB(0, 0, 0), style.RGB(205, 49, 49), style.RGB(13, 188, 121), style.RGB(229, 229, 16),
+			style.RGB(36, 114, 200), style.RGB(188, 63, 188), style.RGB(17, 168, 205), style.RGB(229, 229, 229),
+			style.RGB(102, 102, 102), style.RGB(241, 76, 76), style.RGB(35, 209, 139), style.RGB(245, 245, 67),
+			style.RGB(59, 142, 234), style.RGB(214, 112, 214), style.RGB(41, 184, 219), style.RGB(255, 255, 255),
+		},
+		foreground: style.RGB(204, 204, 204),
+		background: style.RGB(30, 30, 30),
+	}
+	lightScheme = scheme{
+		palette: style.Palette{
+			style.RGB(0, 0, 0), style.RGB(205, 49, 49), style.RGB(0, 188, 0), style.RGB(148, 152, 0),
+			style.RGB(4, 81, 165), style.RGB(188, 5, 188), style.RGB(5, 152, 188), style.RGB(85, 85, 85),
+			style.RGB(102, 102, 102), style.RGB(205, 49, 49), style.RGB(20, 206, 20), style.RGB(181, 186, 0),
+			style.RGB(4, 81, 165), style.RGB(188, 5, 188), style.RGB(5, 152, 188), style.RGB(165, 165, 165),
+		},
+		foreground: style.RGB(51, 51, 51),
+		background: style.RGB(255, 255, 255),
+	}
+)
+
+// schemeFor returns the scheme for a document shown against bg, which is dark
+// unless bg is light.
+func schemeFor(bg Background) *scheme {
+	if bg == LightBackground {
+		return &lightScheme
+	}
+	return &darkScheme
+}
+
+// colours returns the text and background colours of s, as hex, that differ
+// from the scheme's own. A reversed style swaps the two, taking the scheme's
+// colour for whichever s leaves unset.
+func (sc *scheme) colours(s style.Style) (fg, bg string) {
+	fg = sc.palette.Resolve(s.Foreground).Hex()
+	bg = sc.palette.Resolve(s.Background).Hex()
+	if s.Attributes&style.Reverse != 0 {
+		fg, bg = cmp.Or(bg, sc.background.Hex()), cmp.Or(fg, sc.foreground.Hex())
+	}
+	return fg, bg
+}
+
+// decorations returns the CSS text-decoration lines of s, space-separated, or
+// the empty string when it has none.
+func decorations(s style.Style) string {
+	var lines []string
+	if s.Attributes&style.Underline != 0 {
+		lines = append(lines, "underline")
+	}
+	if s.Attributes&style.Strike != 0 {
+		lines = append(lines, "line-through")
+	}
+	return strings.Join(lines, " ")
+}
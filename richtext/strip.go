@@ -5,6 +5,7 @@ import (
 	"unicode/utf8"
 
 	"github.com/bitwizeshift/go-cli/richtext/internal/token"
+	"github.com/rivo/uniseg"
 )
 
 // Strip returns s with every valid tag removed, leaving only the visible text.
@@ -23,11 +24,21 @@ func Strip(s string) string {
 }
 
 // Len returns the number of runes in the visible text of s, ignoring valid
-// tags. It is intended for alignment and width calculations.
+// tags. A rune is not a terminal column, so alignment should use [Width]
+// instead.
 func Len(s string) int {
 	return utf8.RuneCountInString(Strip(s))
 }
 
+// Width returns the number of terminal columns the visible text of s occupies,
+// ignoring valid tags. East Asian wide and fullwidth characters and most emoji
+// occupy two columns, and combining marks, variation selectors, and the joiners
+// within an emoji sequence occupy none, so Width is the measure to align and
+// wrap text by.
+func Width(s string) int {
+	return uniseg.StringWidth(Strip(s))
+}
+
 func writeVisible(b *strings.Builder, tok token.Token) {
 	if tok.Kind == token.Text || !isKnownNamespace(tok.Namespace) {
 		b.WriteString(tok.Raw)
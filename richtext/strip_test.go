@@ -25,6 +25,11 @@ func TestStrip(t *testing.T) {
 			input: "[fg:red]a[attr:bold]b[/attr][/fg]",
 			want:  "ab",
 		},
+		{
+			name:  "LinkTagsRemoved",
+			input: "[link:https://example.com]docs[/link]",
+			want:  "docs",
+		},
 		{
 			name:  "UnknownNamespacePreserved",
 			input: "[foo:bar]kept[/foo]",
@@ -101,3 +106,58 @@ func TestLen(t *testing.T) {
 		})
 	}
 }
+
+func TestWidth(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		input string
+		want  int
+	}{
+		{
+			name:  "PlainASCII",
+			input: "hello",
+			want:  5,
+		},
+		{
+			name:  "TagsIgnored",
+			input: "[fg:red]hello[/fg]",
+			want:  5,
+		},
+		{
+			name:  "WideCharactersCountTwice",
+			input: "[theme:title]日本語[/theme]",
+			want:  6,
+		},
+		{
+			name:  "CombiningMarksCountNothing",
+			input: "été",
+			want:  3,
+		},
+		{
+			name:  "EmojiSequenceCountsOnce",
+			input: "[attr:bold]👩‍💻[/attr] ok",
+			want:  5,
+		},
+		{
+			name:  "RawRegionCountsVisibleContents",
+			input: "[richtext:off][漢][/richtext]",
+			want:  4,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			width := richtext.Width(tc.input)
+
+			// Assert
+			if got, want := width, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Width() = %d, want %d", got, want)
+			}
+		})
+	}
+}
@@ -19,17 +19,20 @@ type colourKind uint8
 const (
 	colourUnset colourKind = iota
 	colourNamed
+	colourIndexed
 	colourTrue
 )
 
 // Colour is a foreground or background colour. Its zero value is unset, meaning
 // it contributes no styling and is overridable by [Style.Merge].
 //
-// A Colour is either one of the sixteen named ANSI colours (see the package
-// preset variables) or a 24-bit value produced by [RGB].
+// A Colour is one of the sixteen named ANSI colours (see the package preset
+// variables), an entry of the 256-colour palette produced by [Indexed], or a
+// 24-bit value produced by [RGB].
 type Colour struct {
 	kind    colourKind
-	code    int // foreground SGR parameter when kind == colourNamed
+	code    int   // foreground SGR parameter when kind == colourNamed
+	index   uint8 // palette entry when kind == colourIndexed
 	r, g, b uint8
 }
 
@@ -58,6 +61,13 @@ func named(code int) Colour {
 	return Colour{kind: colourNamed, code: code}
 }
 
+// Indexed returns entry n of the 256-colour xterm palette: the sixteen named
+// colours (0-15), a 6×6×6 colour cube (16-231), and a greyscale ramp
+// (232-255).
+func Indexed(n uint8) Colour {
+	return Colour{kind: colourIndexed, index: n}
+}
+
 // RGB returns a 24-bit true-colour.
 func RGB(r, g, b uint8) Colour {
 	return Colour{kind: colourTrue, r: r, g: g, b: b}
@@ -89,14 +99,19 @@ func ColourByName(name string) (Colour, bool) {
 	return c, ok
 }
 
-// UnmarshalText resolves a named colour ("red", "brightred") or a true-colour
-// in "rgb(r,g,b)" form with each component in the range 0-255. It returns
-// [ErrUnknownColour] for any other input.
+// UnmarshalText resolves a named colour ("red", "brightred"), a 256-colour
+// palette index ("208"), or a true-colour in "rgb(r,g,b)" form, with each index
+// or component in the range 0-255. It returns [ErrUnknownColour] for any other
+// input.
 func (c *Colour) UnmarshalText(b []byte) error {
 	if named, ok := ColourByName(string(b)); ok {
 		*c = named
 		return nil
 	}
+	if indexed, ok := parseIndex(string(b)); ok {
+		*c = indexed
+		return nil
+	}
 	if rgb, ok := parseRGB(string(b)); ok {
 		*c = rgb
 		return nil
@@ -104,6 +119,17 @@ func (c *Colour) UnmarshalText(b []byte) error {
 	return fmt.Errorf("colour: %w %q", ErrUnknownColour, b)
 }
 
+func parseIndex(s string) (Colour, bool) {
+	if s == "" || strings.TrimLeft(s, "0123456789") != "" {
+		return Colour{}, false
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n > 255 {
+		return Colour{}, false
+	}
+	return Indexed(uint8(n)), true
+}
+
 func parseRGB(s string) (Colour, bool) {
 	inner, ok := strings.CutPrefix(s, "rgb(")
 	if !ok {
@@ -143,6 +169,8 @@ func (c Colour) params(background bool) []int {
 			code = sgr.Background(code)
 		}
 		return []int{code}
+	case colourIndexed:
+		return sgr.Indexed(background, c.index)
 	case colourTrue:
 		return sgr.TrueColour(background, c.r, c.g, c.b)
 	default:
@@ -150,4 +178,119 @@ func (c Colour) params(background bool) []int {
 	}
 }
 
+// To256 returns the entry of the 256-colour palette nearest to c, for
+// terminals that cannot render true-colour. Only a colour produced by [RGB] is
+// changed; it maps onto the colour cube or greyscale ramp, never onto the
+// sixteen named colours, whose values vary between terminals.
+func (c Colour) To256() Colour {
+	if c.kind != colourTrue {
+		return c
+	}
+	rgb := [3]uint8{c.r, c.g, c.b}
+	var cube [3]uint8
+	for i, v := range rgb {
+		cube[i] = nearestLevel(v)
+	}
+	n := 16 + 36*cube[0] + 6*cube[1] + cube[2]
+	grey := 232 + nearestGrey(rgb)
+	if distance(rgb, paletteRGB(grey)) < distance(rgb, paletteRGB(n)) {
+		n = grey
+	}
+	return Indexed(n)
+}
+
+// To16 returns the named colour nearest to c, for terminals that render only
+// the sixteen named colours. A named colour is returned unchanged.
+func (c Colour) To16() Colour {
+	var rgb [3]uint8
+	switch c.kind {
+	case colourIndexed:
+		if c.index < 16 {
+			return namedIndex(c.index)
+		}
+		rgb = paletteRGB(c.index)
+	case colourTrue:
+		rgb = [3]uint8{c.r, c.g, c.b}
+	default:
+		return c
+	}
+	var best uint8
+	for i := range namedRGB {
+		if distance(rgb, namedRGB[i]) < distance(rgb, namedRGB[best]) {
+			best = uint8(i)
+		}
+	}
+	return namedIndex(best)
+}
+
+// cubeLevels are the channel intensities of the 6×6×6 colour cube occupying
+// palette entries 16-231.
+var cubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+// namedRGB approximates the sixteen named colours, in palette order, by their
+// xterm defaults.
+var namedRGB = [16][3]uint8{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// namedIndex returns the named colour at palette entry n, which is below 16.
+func namedIndex(n uint8) Colour {
+	if n < 8 {
+		return named(30 + int(n))
+	}
+	return named(90 + int(n) - 8)
+}
+
+// paletteRGB returns the value of entry n of the 256-colour palette.
+func paletteRGB(n uint8) [3]uint8 {
+	switch {
+	case n < 16:
+		return namedRGB[n]
+	case n < 232:
+		n -= 16
+		return [3]uint8{cubeLevels[n/36], cubeLevels[n/6%6], cubeLevels[n%6]}
+	default:
+		v := 8 + 10*(n-232)
+		return [3]uint8{v, v, v}
+	}
+}
+
+// nearestLevel returns the index of the colour cube level nearest to v.
+func nearestLevel(v uint8) uint8 {
+	var best uint8
+	for i, level := range cubeLevels {
+		if absDiff(v, level) < absDiff(v, cubeLevels[best]) {
+			best = uint8(i)
+		}
+	}
+	return best
+}
+
+// nearestGrey returns the step of the 24-step greyscale ramp nearest to the
+// mean intensity of rgb.
+func nearestGrey(rgb [3]uint8) uint8 {
+	mean := (int(rgb[0]) + int(rgb[1]) + int(rgb[2])) / 3
+	return uint8(min(max((mean-3)/10, 0), 23))
+}
+
+// distance returns the squared Euclidean distance between two colours.
+func distance(a, b [3]uint8) int {
+	var sum int
+	for i := range a {
+		d := int(absDiff(a[i], b[i]))
+		sum += d * d
+	}
+	return sum
+}
+
+func absDiff(a, b uint8) uint8 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
 var _ encoding.TextUnmarshaler = (*Colour)(nil)
@@ -76,6 +76,24 @@ func TestColour_UnmarshalText(t *testing.T) {
 			want:    style.RGB(1, 2, 3),
 			wantErr: nil,
 		},
+		{
+			name:    "PaletteIndex",
+			input:   "208",
+			want:    style.Indexed(208),
+			wantErr: nil,
+		},
+		{
+			name:    "PaletteIndexOutOfRange",
+			input:   "256",
+			want:    style.Colour{},
+			wantErr: style.ErrUnknownColour,
+		},
+		{
+			name:    "SignedPaletteIndex",
+			input:   "+12",
+			want:    style.Colour{},
+			wantErr: style.ErrUnknownColour,
+		},
 		{
 			name:    "TrueColourWithSpaces",
 			input:   "rgb(10, 20, 30)",
@@ -134,3 +152,128 @@ func TestColour_UnmarshalText(t *testing.T) {
 		})
 	}
 }
+
+func TestColour_To256(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		colour style.Colour
+		want   style.Colour
+	}{
+		{
+			name:   "UnsetUnchanged",
+			colour: style.Colour{},
+			want:   style.Colour{},
+		},
+		{
+			name:   "NamedUnchanged",
+			colour: style.Red,
+			want:   style.Red,
+		},
+		{
+			name:   "IndexedUnchanged",
+			colour: style.Indexed(3),
+			want:   style.Indexed(3),
+		},
+		{
+			name:   "TrueColourOnCube",
+			colour: style.RGB(255, 135, 0),
+			want:   style.Indexed(208),
+		},
+		{
+			name:   "TrueColourNearCube",
+			colour: style.RGB(250, 140, 10),
+			want:   style.Indexed(208),
+		},
+		{
+			name:   "TrueColourGrey",
+			colour: style.RGB(128, 128, 128),
+			want:   style.Indexed(244),
+		},
+		{
+			name:   "Black",
+			colour: style.RGB(0, 0, 0),
+			want:   style.Indexed(16),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			colour := tc.colour.To256()
+
+			// Assert
+			if got, want := colour, tc.want; !cmp.Equal(got, want, cmpopts.EquateComparable(style.Colour{})) {
+				t.Errorf("To256() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestColour_To16(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		colour style.Colour
+		want   style.Colour
+	}{
+		{
+			name:   "UnsetUnchanged",
+			colour: style.Colour{},
+			want:   style.Colour{},
+		},
+		{
+			name:   "NamedUnchanged",
+			colour: style.BrightCyan,
+			want:   style.BrightCyan,
+		},
+		{
+			name:   "IndexedNamedEntry",
+			colour: style.Indexed(1),
+			want:   style.Red,
+		},
+		{
+			name:   "IndexedBrightEntry",
+			colour: style.Indexed(12),
+			want:   style.BrightBlue,
+		},
+		{
+			name:   "IndexedCubeEntry",
+			colour: style.Indexed(208),
+			want:   style.Yellow,
+		},
+		{
+			name:   "IndexedGreyEntry",
+			colour: style.Indexed(244),
+			want:   style.BrightBlack,
+		},
+		{
+			name:   "TrueColour",
+			colour: style.RGB(250, 10, 10),
+			want:   style.BrightRed,
+		},
+		{
+			name:   "TrueColourDark",
+			colour: style.RGB(0, 0, 120),
+			want:   style.Blue,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			colour := tc.colour.To16()
+
+			// Assert
+			if got, want := colour, tc.want; !cmp.Equal(got, want, cmpopts.EquateComparable(style.Colour{})) {
+				t.Errorf("To16() = %v, want %v", got, want)
+			}
+		})
+	}
+}
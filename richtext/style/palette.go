@@ -0,0 +1,74 @@
+package style
+
+import (
+	"fmt"
+)
+
+// Palette gives the values of the sixteen named colours, in palette order:
+// [Black] through [White], then [BrightBlack] through [BrightWhite]. Terminals
+// disagree on these values, so a renderer outside the terminal, such as an HTML
+// document, takes them from a Palette of its choosing.
+type Palette [16]Colour
+
+// XtermPalette is the xterm default value of each named colour, as used to
+// approximate a named colour when no other palette is given.
+var XtermPalette = func() Palette {
+	var p Palette
+	for i, rgb := range namedRGB {
+		p[i] = RGB(rgb[0], rgb[1], rgb[2])
+	}
+	return p
+}()
+
+// Resolve returns c as a 24-bit colour, taking a named colour, or an entry of
+// the 256-colour palette below 16, from p. Colours that are already 24-bit are
+// returned unchanged, as is an unset colour.
+func (p *Palette) Resolve(c Colour) Colour {
+	switch c.kind {
+	case colourNamed:
+		return p.entry(c.paletteIndex())
+	case colourIndexed:
+		if c.index < 16 {
+			return p.entry(c.index)
+		}
+		rgb := paletteRGB(c.index)
+		return RGB(rgb[0], rgb[1], rgb[2])
+	default:
+		return c
+	}
+}
+
+// entry returns entry n of p as a 24-bit colour, resolving an entry that is
+// itself named or indexed by its xterm default.
+func (p *Palette) entry(n uint8) Colour {
+	c := p[n]
+	switch c.kind {
+	case colourNamed:
+		c = Indexed(c.paletteIndex())
+		fallthrough
+	case colourIndexed:
+		rgb := paletteRGB(c.index)
+		return RGB(rgb[0], rgb[1], rgb[2])
+	default:
+		return c
+	}
+}
+
+// paletteIndex returns the palette entry of a named colour.
+func (c Colour) paletteIndex() uint8 {
+	if c.code >= 90 {
+		return uint8(c.code-90) + 8
+	}
+	return uint8(c.code - 30)
+}
+
+// Hex returns c in the "#rrggbb" notation of CSS and SVG, approximating a
+// named colour by its value in [XtermPalette]. It returns the empty string
+// when c is unset.
+func (c Colour) Hex() string {
+	if !c.isSet() {
+		return ""
+	}
+	c = XtermPalette.Resolve(c)
+	return fmt.Sprintf("#%02x%02x%02x", c.r, c.g, c.b)
+}
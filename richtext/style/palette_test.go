@@ -0,0 +1,126 @@
+package style_test
+
+import (
+	"testing"
+
+	"github.com/bitwizeshift/go-cli/richtext/style"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestPalette_Resolve(t *testing.T) {
+	t.Parallel()
+
+	palette := style.Palette{
+		style.RGB(1, 1, 1), style.RGB(2, 2, 2), style.RGB(3, 3, 3), style.RGB(4, 4, 4),
+		style.RGB(5, 5, 5), style.RGB(6, 6, 6), style.RGB(7, 7, 7), style.RGB(8, 8, 8),
+		style.RGB(9, 9, 9), style.RGB(10, 10, 10), style.RGB(11, 11, 11), style.RGB(12, 12, 12),
+		style.RGB(13, 13, 13), style.RGB(14, 14, 14), style.RGB(15, 15, 15), style.Blue,
+	}
+
+	testCases := []struct {
+		name  string
+		input style.Colour
+		want  style.Colour
+	}{
+		{
+			name:  "Unset",
+			input: style.Colour{},
+			want:  style.Colour{},
+		},
+		{
+			name:  "Named",
+			input: style.Red,
+			want:  style.RGB(2, 2, 2),
+		},
+		{
+			name:  "BrightNamed",
+			input: style.BrightRed,
+			want:  style.RGB(10, 10, 10),
+		},
+		{
+			name:  "LowIndexFromPalette",
+			input: style.Indexed(8),
+			want:  style.RGB(9, 9, 9),
+		},
+		{
+			name:  "CubeIndex",
+			input: style.Indexed(208),
+			want:  style.RGB(255, 135, 0),
+		},
+		{
+			name:  "GreyIndex",
+			input: style.Indexed(232),
+			want:  style.RGB(8, 8, 8),
+		},
+		{
+			name:  "TrueColourUnchanged",
+			input: style.RGB(1, 2, 3),
+			want:  style.RGB(1, 2, 3),
+		},
+		{
+			name:  "NamedEntryResolvedByXterm",
+			input: style.BrightWhite,
+			want:  style.RGB(0, 0, 238),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			resolved := palette.Resolve(tc.input)
+
+			// Assert
+			if got, want := resolved, tc.want; !cmp.Equal(got, want, cmpopts.EquateComparable(style.Colour{})) {
+				t.Errorf("Resolve() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestColour_Hex(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		input style.Colour
+		want  string
+	}{
+		{
+			name:  "Unset",
+			input: style.Colour{},
+			want:  "",
+		},
+		{
+			name:  "TrueColour",
+			input: style.RGB(255, 8, 171),
+			want:  "#ff08ab",
+		},
+		{
+			name:  "NamedByXterm",
+			input: style.Blue,
+			want:  "#0000ee",
+		},
+		{
+			name:  "Indexed",
+			input: style.Indexed(244),
+			want:  "#808080",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			hex := tc.input.Hex()
+
+			// Assert
+			if got, want := hex, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Hex() = %q, want %q", got, want)
+			}
+		})
+	}
+}
@@ -1,11 +1,19 @@
 package style
 
 import (
+	"encoding"
+	"errors"
 	"fmt"
+	"strings"
+	"unicode"
 
 	"github.com/bitwizeshift/go-cli/richtext/internal/sgr"
 )
 
+// ErrInvalidStyle reports a style term that is not of the form "fg:colour",
+// "bg:colour" or "attr:attribute".
+var ErrInvalidStyle = errors.New("invalid style")
+
 // Style is a complete set of terminal styling: foreground colour, background
 // colour, and text attributes. Its zero value applies no styling and renders as
 // an empty string.
@@ -37,4 +45,71 @@ func (s Style) String() string {
 	return sgr.Sequence(params...)
 }
 
-var _ fmt.Stringer = Style{}
+// UnmarshalText resolves a style written as whitespace-separated terms in the
+// richtext tag syntax, such as "fg:red bg:rgb(0, 0, 0) attr:bold attr:italic":
+// "fg" and "bg" terms take any colour [Colour.UnmarshalText] accepts, and
+// "attr" terms take any attribute [Attribute.UnmarshalText] accepts and
+// accumulate. Empty text is the zero Style. It returns [ErrInvalidStyle] for
+// a term in any other form, and the colour or attribute error for a value
+// that cannot be resolved.
+func (s *Style) UnmarshalText(b []byte) error {
+	var out Style
+	for _, term := range splitTerms(string(b)) {
+		namespace, value, ok := strings.Cut(term, ":")
+		if !ok {
+			return fmt.Errorf("style: %w %q", ErrInvalidStyle, term)
+		}
+		switch namespace {
+		case "fg":
+			if err := out.Foreground.UnmarshalText([]byte(value)); err != nil {
+				return err
+			}
+		case "bg":
+			if err := out.Background.UnmarshalText([]byte(value)); err != nil {
+				return err
+			}
+		case "attr":
+			var a Attribute
+			if err := a.UnmarshalText([]byte(value)); err != nil {
+				return err
+			}
+			out.Attributes |= a
+		default:
+			return fmt.Errorf("style: %w %q", ErrInvalidStyle, term)
+		}
+	}
+	*s = out
+	return nil
+}
+
+// splitTerms splits text into its whitespace-separated terms, keeping the
+// whitespace within parentheses, as in "rgb(1, 2, 3)", inside its term.
+func splitTerms(text string) []string {
+	var terms []string
+	var term strings.Builder
+	depth := 0
+	for _, r := range text {
+		switch {
+		case r == '(':
+			depth++
+		case r == ')' && depth > 0:
+			depth--
+		case unicode.IsSpace(r) && depth == 0:
+			if term.Len() > 0 {
+				terms = append(terms, term.String())
+				term.Reset()
+			}
+			continue
+		}
+		term.WriteRune(r)
+	}
+	if term.Len() > 0 {
+		terms = append(terms, term.String())
+	}
+	return terms
+}
+
+var (
+	_ fmt.Stringer             = Style{}
+	_ encoding.TextUnmarshaler = (*Style)(nil)
+)
@@ -75,6 +75,11 @@ func TestStyle_String(t *testing.T) {
 			style: style.Style{Background: style.Red},
 			want:  "\x1b[41m",
 		},
+		{
+			name:  "IndexedColours",
+			style: style.Style{Foreground: style.Indexed(208), Background: style.Indexed(17)},
+			want:  "\x1b[38;5;208;48;5;17m",
+		},
 		{
 			name:  "AttributesOnly",
 			style: style.Style{Attributes: style.Bold | style.Italic},
@@ -106,3 +111,95 @@ func TestStyle_String(t *testing.T) {
 		})
 	}
 }
+
+func TestStyle_UnmarshalText(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		input   string
+		want    style.Style
+		wantErr error
+	}{
+		{
+			name:    "Empty",
+			input:   "",
+			want:    style.Style{},
+			wantErr: nil,
+		},
+		{
+			name:    "Foreground",
+			input:   "fg:red",
+			want:    style.Style{Foreground: style.Red},
+			wantErr: nil,
+		},
+		{
+			name:    "EveryTerm",
+			input:   "fg:208 bg:rgb(1,2,3) attr:bold",
+			want:    style.Style{Foreground: style.Indexed(208), Background: style.RGB(1, 2, 3), Attributes: style.Bold},
+			wantErr: nil,
+		},
+		{
+			name:    "AttributesAccumulate",
+			input:   "attr:bold attr:italic",
+			want:    style.Style{Attributes: style.Bold | style.Italic},
+			wantErr: nil,
+		},
+		{
+			name:    "SpacesInsideRGB",
+			input:   "  fg:rgb(10, 20, 30)\tattr:underline ",
+			want:    style.Style{Foreground: style.RGB(10, 20, 30), Attributes: style.Underline},
+			wantErr: nil,
+		},
+		{
+			name:    "LaterColourWins",
+			input:   "fg:red fg:blue",
+			want:    style.Style{Foreground: style.Blue},
+			wantErr: nil,
+		},
+		{
+			name:    "UnknownColour",
+			input:   "fg:octarine",
+			want:    style.Style{},
+			wantErr: style.ErrUnknownColour,
+		},
+		{
+			name:    "UnknownAttribute",
+			input:   "attr:loud",
+			want:    style.Style{},
+			wantErr: style.ErrUnknownAttribute,
+		},
+		{
+			name:    "UnknownNamespace",
+			input:   "theme:error",
+			want:    style.Style{},
+			wantErr: style.ErrInvalidStyle,
+		},
+		{
+			name:    "MissingNamespace",
+			input:   "red",
+			want:    style.Style{},
+			wantErr: style.ErrInvalidStyle,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			var s style.Style
+
+			// Act
+			err := s.UnmarshalText([]byte(tc.input))
+
+			// Assert
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("UnmarshalText() = %v, want %v", got, want)
+			}
+			if got, want := s, tc.want; !cmp.Equal(got, want, cmpopts.EquateComparable(style.Style{}, style.Colour{})) {
+				t.Errorf("UnmarshalText() style = %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package richtext
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/bitwizeshift/go-cli/internal/format"
+	"github.com/bitwizeshift/go-cli/richtext/style"
+)
+
+// The layout of an SVG terminal window, in pixels.
+const (
+	svgFontSize   = 14
+	svgCellWidth  = 0.6 * svgFontSize // the advance of a monospace character
+	svgLineHeight = 20
+	svgBaseline   = 15 // the baseline's offset within a line
+	svgPadding    = 16
+	svgTitleBar   = 36
+	svgMinColumns = 20
+	svgTabWidth   = 8
+)
+
+const svgFontFamily = "ui-monospace, SFMono-Regular, Menlo, Consolas, monospace"
+
+// svgButtons are the colours of the close, minimize, and zoom buttons drawn in
+// the window's title bar.
+var svgButtons = []string{"#ff5f57", "#febc2e", "#28c840"}
+
+// NewSVGWriter returns a Writer that renders markup as an SVG image of a
+// terminal window showing it, rather than as ANSI escapes, resolving
+// [theme:name] tags against theme as [NewWriter] does. The window is sized to
+// fit the text, measuring each line by its [Width], and a link's URL is spelled
+// out after its text, as in a terminal that cannot render hyperlinks.
+//
+// The window and its named colours follow the background; see
+// [Writer.SetBackground], which defaults to a [DarkBackground]. Nothing is
+// written until [Writer.Close], which writes the whole image.
+func NewSVGWriter(dst io.Writer, theme *Theme) *Writer {
+	w := NewWriter(dst, theme)
+	w.SetFormat(SVG)
+	return w
+}
+
+// svgRun is a run of text in one style, starting at a column of its line.
+type svgRun struct {
+	text   string
+	style  style.Style
+	column int
+}
+
+// svgOutput renders as an SVG image. It lays the text out into lines of runs as
+// it arrives, and draws them when closed, once the size of the window is known.
+type svgOutput struct {
+	dst        io.Writer
+	background func() Background
+	style      style.Style
+	lines      [][]svgRun
+	widths     []int
+}
+
+func (o *svgOutput) restyle(s style.Style, _ string) error {
+	o.style = s
+	return nil
+}
+
+func (o *svgOutput) text(s string) error {
+	if len(o.lines) == 0 {
+		o.newLine()
+	}
+	s = strings.Map(printable, s)
+	for i, line := range strings.Split(s, "\n") {
+		if i > 0 {
+			o.newLine()
+		}
+		o.add(line)
+	}
+	return nil
+}
+
+// printable drops the control characters an SVG document cannot hold, and
+// those such as carriage returns that do not show.
+func printable(r rune) rune {
+	if (r < 0x20 && r != '\t' && r != '\n') || r == 0x7f {
+		return -1
+	}
+	return r
+}
+
+// newLine starts a new, empty line.
+func (o *svgOutput) newLine() {
+	o.lines = append(o.lines, nil)
+	o.widths = append(o.widths, 0)
+}
+
+// add appends text to the last line in the current style, expanding tabs to
+// the next tab stop.
+func (o *svgOutput) add(text string) {
+	n := len(o.lines) - 1
+	for text != "" {
+		chunk, rest, tab := strings.Cut(text, "\t")
+		if tab {
+			column := o.widths[n] + format.Width(chunk)
+			chunk += strings.Repeat(" ", svgTabWidth-column%svgTabWidth)
+		}
+		o.addRun(n, chunk)
+		text = rest
+	}
+}
+
+// addRun appends text to line n in the current style, extending its last run
+// when that is in the same style.
+func (o *svgOutput) addRun(n int, text string) {
+	if text == "" {
+		return
+	}
+	line := o.lines[n]
+	if last := len(line) - 1; last >= 0 && line[last].style == o.style {
+		line[last].text += text
+	} else {
+		o.lines[n] = append(line, svgRun{text: text, style: o.style, column: o.widths[n]})
+	}
+	o.widths[n] += format.Width(text)
+}
+
+func (o *svgOutput) close() error {
+	lines := o.lines
+	if n := len(lines); n > 0 && len(lines[n-1]) == 0 {
+		lines = lines[:n-1]
+	}
+	columns := svgMinColumns
+	for _, w := range o.widths {
+		columns = max(columns, w)
+	}
+	rows := max(len(lines), 1)
+	width := 2*svgPadding + float64(columns)*svgCellWidth
+	height := svgTitleBar + rows*svgLineHeight + svgPadding
+	sc := schemeFor(o.background())
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%[1]s" height="%[2]d" viewBox="0 0 %[1]s %[2]d">`+"\n", px(width), height)
+	fmt.Fprintf(&b, `<rect width="%s" height="%d" rx="6" fill="%s"/>`+"\n", px(width), height, sc.background.Hex())
+	for i, colour := range svgButtons {
+		fmt.Fprintf(&b, `<circle cx="%d" cy="%d" r="6" fill="%s"/>`+"\n", svgPadding+2+20*i, svgTitleBar/2, colour)
+	}
+	fmt.Fprintf(&b, `<g font-family="%s" font-size="%d" fill="%s">`+"\n", svgFontFamily, svgFontSize, sc.foreground.Hex())
+	for i, line := range lines {
+		top := svgTitleBar + i*svgLineHeight
+		for _, run := range line {
+			if _, bg := sc.colours(run.style); bg != "" {
+				fmt.Fprintf(&b, `<rect x="%s" y="%d" width="%s" height="%d" fill="%s"/>`+"\n",
+					px(column(run.column)), top, px(float64(format.Width(run.text))*svgCellWidth), svgLineHeight, bg)
+			}
+		}
+		if len(line) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, `<text y="%d" xml:space="preserve">`, top+svgBaseline)
+		for _, run := range line {
+			fmt.Fprintf(&b, `<tspan x="%s"%s>%s</tspan>`, px(column(run.column)), svgAttributes(sc, run.style), html.EscapeString(run.text))
+		}
+		b.WriteString("</text>\n")
+	}
+	b.WriteString("</g>\n</svg>\n")
+	_, err := io.WriteString(o.dst, b.String())
+	return err
+}
+
+// svgAttributes returns the presentation attributes that show text in s,
+// each preceded by a space.
+func svgAttributes(sc *scheme, s style.Style) string {
+	var b strings.Builder
+	if fg, _ := sc.colours(s); fg != "" {
+		fmt.Fprintf(&b, ` fill="%s"`, fg)
+	}
+	if s.Attributes&style.Bold != 0 {
+		b.WriteString(` font-weight="bold"`)
+	}
+	if s.Attributes&style.Faint != 0 {
+		b.WriteString(` opacity="0.6"`)
+	}
+	if s.Attributes&style.Italic != 0 {
+		b.WriteString(` font-style="italic"`)
+	}
+	if lines := decorations(s); lines != "" {
+		fmt.Fprintf(&b, ` text-decoration="%s"`, lines)
+	}
+	return b.String()
+}
+
+// column returns the x coordinate of the left edge of column n.
+func column(n int) float64 {
+	return svgPadding + float64(n)*svgCellWidth
+}
+
+// px formats a coordinate to at most two decimal places.
+func px(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
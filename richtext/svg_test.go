@@ -0,0 +1,205 @@
+package richtext_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bitwizeshift/go-cli/richtext"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSVGWriter_Close(t *testing.T) {
+	t.Parallel()
+
+	theme := newTheme(t)
+
+	testCases := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "Empty",
+			input: "",
+			want:  nil,
+		},
+		{
+			name:  "TrailingNewlineAddsNoLine",
+			input: "a & b\n",
+			want: []string{
+				`<text y="51" xml:space="preserve"><tspan x="16">a &amp; b</tspan></text>`,
+			},
+		},
+		{
+			name:  "RunsPositionedByDisplayWidth",
+			input: "[fg:red]日本[/fg]x\n\n[theme:title]y[/theme]",
+			want: []string{
+				`<text y="51" xml:space="preserve"><tspan x="16" fill="#cd3131">日本</tspan><tspan x="49.6">x</tspan></text>`,
+				`<text y="91" xml:space="preserve"><tspan x="16" fill="#11a8cd" font-weight="bold">y</tspan></text>`,
+			},
+		},
+		{
+			name:  "BackgroundDrawnBehindRun",
+			input: "a[bg:blue]bc[/bg]",
+			want: []string{
+				`<rect x="24.4" y="36" width="16.8" height="20" fill="#2472c8"/>`,
+				`<text y="51" xml:space="preserve"><tspan x="16">a</tspan><tspan x="24.4">bc</tspan></text>`,
+			},
+		},
+		{
+			name:  "AttributesAsPresentation",
+			input: "[attr:italic][attr:faint][attr:underline]x[/attr][/attr][/attr]",
+			want: []string{
+				`<text y="51" xml:space="preserve"><tspan x="16" opacity="0.6" font-style="italic" text-decoration="underline">x</tspan></text>`,
+			},
+		},
+		{
+			name:  "ControlCharactersDropped",
+			input: "a\r\x1b[0mb\x07",
+			want: []string{
+				`<text y="51" xml:space="preserve"><tspan x="16">a[0mb</tspan></text>`,
+			},
+		},
+		{
+			name:  "TabsExpandToStops",
+			input: "ab\tc",
+			want: []string{
+				`<text y="51" xml:space="preserve"><tspan x="16">ab      c</tspan></text>`,
+			},
+		},
+		{
+			name:  "LinkURLSpelledOut",
+			input: "[link:https://example.com]docs[/link]",
+			want: []string{
+				`<text y="51" xml:space="preserve"><tspan x="16">docs (https://example.com)</tspan></text>`,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			var buf strings.Builder
+			sut := richtext.NewSVGWriter(&buf, theme)
+			_, _ = sut.Write([]byte(tc.input))
+
+			// Act
+			err := sut.Close()
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Close() = %v, want nil", err)
+			}
+			if got, want := svgContent(t, buf.String()), tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Close() content mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestSVGWriter_Close_Window(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		background richtext.Background
+		input      string
+		want       string
+	}{
+		{
+			name:       "DarkFitsMinimumColumns",
+			background: richtext.DarkBackground,
+			input:      "hi",
+			want: `<svg xmlns="http://www.w3.org/2000/svg" width="200" height="72" viewBox="0 0 200 72">
+<rect width="200" height="72" rx="6" fill="#1e1e1e"/>
+<circle cx="18" cy="18" r="6" fill="#ff5f57"/>
+<circle cx="38" cy="18" r="6" fill="#febc2e"/>
+<circle cx="58" cy="18" r="6" fill="#28c840"/>
+<g font-family="ui-monospace, SFMono-Regular, Menlo, Consolas, monospace" font-size="14" fill="#cccccc">
+<text y="51" xml:space="preserve"><tspan x="16">hi</tspan></text>
+</g>
+</svg>
+`,
+		},
+		{
+			name:       "LightWidensToLongestLine",
+			background: richtext.LightBackground,
+			input:      strings.Repeat("x", 30) + "\ny",
+			want: `<svg xmlns="http://www.w3.org/2000/svg" width="284" height="92" viewBox="0 0 284 92">
+<rect width="284" height="92" rx="6" fill="#ffffff"/>
+<circle cx="18" cy="18" r="6" fill="#ff5f57"/>
+<circle cx="38" cy="18" r="6" fill="#febc2e"/>
+<circle cx="58" cy="18" r="6" fill="#28c840"/>
+<g font-family="ui-monospace, SFMono-Regular, Menlo, Consolas, monospace" font-size="14" fill="#333333">
+<text y="51" xml:space="preserve"><tspan x="16">xxxxxxxxxxxxxxxxxxxxxxxxxxxxxx</tspan></text>
+<text y="71" xml:space="preserve"><tspan x="16">y</tspan></text>
+</g>
+</svg>
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			var buf strings.Builder
+			sut := richtext.NewSVGWriter(&buf, nil)
+			sut.SetBackground(tc.background)
+			_, _ = sut.Write([]byte(tc.input))
+
+			// Act
+			err := sut.Close()
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Close() = %v, want nil", err)
+			}
+			if got, want := buf.String(), tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Close() mismatch (-want +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
+func TestSVGWriter_Write_WritesNothingUntilClose(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	var buf strings.Builder
+	sut := richtext.NewSVGWriter(&buf, nil)
+
+	// Act
+	_, err := sut.Write([]byte("[fg:red]x[/fg]\n"))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Write() = %v, want nil", err)
+	}
+	if got, want := buf.String(), ""; !cmp.Equal(got, want) {
+		t.Errorf("Write() output = %q, want %q", got, want)
+	}
+}
+
+// svgContent returns the lines of an SVG document drawn within its text group,
+// between the window's frame and its end.
+func svgContent(t *testing.T, doc string) []string {
+	t.Helper()
+
+	lines := strings.Split(strings.TrimSuffix(doc, "\n"), "\n")
+	start := 0
+	for start < len(lines) && !strings.HasPrefix(lines[start], "<g ") {
+		start++
+	}
+	if start == len(lines) || len(lines) < start+3 {
+		t.Fatalf("svgContent(%q): no text group", doc)
+	}
+	content := lines[start+1 : len(lines)-2]
+	if len(content) == 0 {
+		return nil
+	}
+	return content
+}
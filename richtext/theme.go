@@ -1,25 +1,38 @@
 package richtext
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/bitwizeshift/go-cli/richtext/style"
+	"go.yaml.in/yaml/v4"
 )
 
 // Theme maps theme names to their styles. It is consulted by a [Writer] to
 // resolve [theme:name] tags and is safe for concurrent reads. A Theme may
 // derive from a parent (see [Theme.New]), falling back to it for names it does
-// not define itself.
+// not define itself, or adapt to the terminal's background (see
+// [AdaptiveTheme]).
 type Theme struct {
 	parent *Theme
 	styles map[string]style.Style
+	light  *Theme
+	dark   *Theme
 }
 
-// DefaultTheme is the standard colour scheme used by the go-cli project.
-// Users can derive themes from this to override basic stylings by using
-// [Theme.New].
-var DefaultTheme = &Theme{
+// DefaultTheme is the standard colour scheme used by the go-cli project,
+// adapting [DefaultDarkTheme] and [DefaultLightTheme] to the terminal's
+// background. Users can derive themes from this to override basic stylings by
+// using [Theme.New].
+var DefaultTheme = AdaptiveTheme(DefaultLightTheme, DefaultDarkTheme)
+
+// DefaultDarkTheme is the variant of [DefaultTheme] used on dark backgrounds,
+// and on backgrounds that cannot be detected.
+var DefaultDarkTheme = &Theme{
 	styles: map[string]style.Style{
 		"title":    {Foreground: style.Green},
 		"heading":  {Foreground: style.Yellow},
@@ -33,6 +46,32 @@ var DefaultTheme = &Theme{
 		"quote":    {Foreground: style.BrightBlack},
 		"gutter":   {Foreground: style.White},
 		"url":      {Foreground: style.BrightWhite, Attributes: style.Underline},
+		"code":     {Foreground: style.Magenta},
+		"selected": {Foreground: style.Cyan, Attributes: style.Bold},
+		"match":    {Foreground: style.Yellow, Attributes: style.Underline},
+	},
+}
+
+// DefaultLightTheme is the variant of [DefaultTheme] used on light
+// backgrounds. It trades the white and yellow of [DefaultDarkTheme], which are
+// barely legible on white, for darker colours.
+var DefaultLightTheme = &Theme{
+	styles: map[string]style.Style{
+		"title":    {Foreground: style.Green},
+		"heading":  {Foreground: style.Magenta},
+		"label":    {Foreground: style.Blue},
+		"value":    {Foreground: style.Black},
+		"emphasis": {Foreground: style.Black, Attributes: style.Bold},
+		"error":    {Foreground: style.Red},
+		"warning":  {Foreground: style.Magenta},
+		"info":     {Foreground: style.Green},
+		"debug":    {Foreground: style.Blue},
+		"quote":    {Foreground: style.BrightBlack},
+		"gutter":   {Foreground: style.BrightBlack},
+		"url":      {Foreground: style.Blue, Attributes: style.Underline},
+		"code":     {Foreground: style.Magenta},
+		"selected": {Foreground: style.Blue, Attributes: style.Bold},
+		"match":    {Foreground: style.Magenta, Attributes: style.Underline},
 	},
 }
 
@@ -50,6 +89,63 @@ func (t *Theme) New(styles map[string]style.Style) *Theme {
 	return &Theme{parent: t, styles: copyStyles(styles)}
 }
 
+// AdaptiveTheme builds a Theme that resolves names against light when the
+// terminal's background is light, and against dark otherwise, including when
+// the background cannot be detected. Either may be nil, in which case no names
+// resolve on that background. The result may itself be derived from with
+// [Theme.New], overriding names on both backgrounds at once.
+func AdaptiveTheme(light, dark *Theme) *Theme {
+	return &Theme{light: light, dark: dark}
+}
+
+// ParseTheme builds a Theme from a YAML or JSON mapping of theme name to style,
+// read from r. Each style is written in the richtext tag syntax accepted by
+// [style.Style.UnmarshalText]:
+//
+//	error: "fg:red attr:bold"
+//	url: "fg:rgb(120, 160, 255) attr:underline"
+//
+// It returns an error wrapping [ErrInvalidThemeName] for a name [NewTheme]
+// would reject, or the error of the first style that cannot be resolved.
+// An empty document is an empty Theme.
+func ParseTheme(r io.Reader) (*Theme, error) {
+	styles, err := parseStyles(r)
+	if err != nil {
+		return nil, err
+	}
+	return &Theme{styles: styles}, nil
+}
+
+// Parse derives a Theme that overrides the receiver with the styles read from
+// r, as [Theme.New] does with a map. It reads r as [ParseTheme] does.
+func (t *Theme) Parse(r io.Reader) (*Theme, error) {
+	styles, err := parseStyles(r)
+	if err != nil {
+		return nil, err
+	}
+	return &Theme{parent: t, styles: styles}, nil
+}
+
+// parseStyles decodes the mapping of theme name to style read from r.
+func parseStyles(r io.Reader) (map[string]style.Style, error) {
+	var raw map[string]string
+	if err := yaml.NewDecoder(r).Decode(&raw); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("richtext: theme: %w", err)
+	}
+	styles := make(map[string]style.Style, len(raw))
+	for _, name := range slices.Sorted(maps.Keys(raw)) {
+		if !validThemeName(name) {
+			return nil, fmt.Errorf("richtext: %w %q", ErrInvalidThemeName, name)
+		}
+		var s style.Style
+		if err := s.UnmarshalText([]byte(raw[name])); err != nil {
+			return nil, fmt.Errorf("richtext: theme %q: %w", name, err)
+		}
+		styles[name] = s
+	}
+	return styles, nil
+}
+
 // copyStyles returns a private copy of styles, panicking on any name that could
 // never be referenced by a [theme:name] tag.
 func copyStyles(styles map[string]style.Style) map[string]style.Style {
@@ -69,13 +165,39 @@ func validThemeName(name string) bool {
 	return name != "" && !strings.ContainsRune(name, ']')
 }
 
-// lookup resolves a theme by name, searching this theme before its ancestors.
-func (t *Theme) lookup(name string) (style.Style, bool) {
+// lookup resolves a theme by name on the background bg, searching this theme
+// before the variant for bg and then its ancestors.
+func (t *Theme) lookup(name string, bg Background) (style.Style, bool) {
 	if s, ok := t.styles[name]; ok {
 		return s, true
 	}
+	if variant := t.variant(bg); variant != nil {
+		if s, ok := variant.lookup(name, bg); ok {
+			return s, true
+		}
+	}
 	if t.parent != nil {
-		return t.parent.lookup(name)
+		return t.parent.lookup(name, bg)
 	}
 	return style.Style{}, false
 }
+
+// variant returns the theme an adaptive theme defers to on the background bg,
+// or nil for a theme that does not adapt.
+func (t *Theme) variant(bg Background) *Theme {
+	if bg == LightBackground {
+		return t.light
+	}
+	return t.dark
+}
+
+// adaptive reports whether resolving a name against t may depend on the
+// terminal's background.
+func (t *Theme) adaptive() bool {
+	for ; t != nil; t = t.parent {
+		if t.light != nil || t.dark != nil {
+			return true
+		}
+	}
+	return false
+}
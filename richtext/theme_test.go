@@ -2,6 +2,7 @@ package richtext_test
 
 import (
 	"fmt"
+	"io"
 	"strings"
 	"testing"
 
@@ -189,3 +190,270 @@ func TestTagError_Error_ContainsNamespace(t *testing.T) {
 		t.Errorf("Error() = %q, want it to contain %q", msg, "fg")
 	}
 }
+
+func TestParseTheme(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		input   string
+		markup  string
+		want    string
+		wantErr error
+	}{
+		{
+			name:   "YAML",
+			input:  "title: fg:red\nnote: \"fg:blue attr:bold\"\n",
+			markup: "[theme:title]a[/theme][theme:note]b[/theme]",
+			want:   reset + red + "a" + reset + reset + "\x1b[1;34mb" + reset,
+		},
+		{
+			name:   "JSON",
+			input:  `{"title": "fg:green"}`,
+			markup: "[theme:title]a[/theme]",
+			want:   reset + greenFg + "a" + reset,
+		},
+		{
+			name:   "EmptyDocument",
+			input:  "",
+			markup: "[theme:title]a[/theme]",
+			want:   "a",
+		},
+		{
+			name:    "InvalidStyle",
+			input:   "title: fg:octarine\n",
+			wantErr: style.ErrUnknownColour,
+		},
+		{
+			name:    "InvalidName",
+			input:   "\"a]b\": fg:red\n",
+			wantErr: richtext.ErrInvalidThemeName,
+		},
+		{
+			name:    "NotAMapping",
+			input:   "- fg:red\n",
+			wantErr: cmpopts.AnyError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			theme, err := richtext.ParseTheme(strings.NewReader(tc.input))
+
+			// Assert
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("ParseTheme() = %v, want %v", got, want)
+			}
+			if err != nil {
+				return
+			}
+			if got, want := render(t, theme, tc.markup), tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Write() output = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestTheme_Parse(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		input  string
+		markup string
+		want   string
+	}{
+		{
+			name:   "OverridesParent",
+			input:  "title: fg:red\n",
+			markup: "[theme:title]x[/theme]",
+			want:   reset + red + "x" + reset,
+		},
+		{
+			name:   "FallsBackToParent",
+			input:  "note: fg:blue\n",
+			markup: "[theme:title]x[/theme]",
+			want:   reset + greenFg + "x" + reset,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			parent := richtext.NewTheme(map[string]style.Style{
+				"title": {Foreground: style.Green},
+			})
+
+			// Act
+			theme, err := parent.Parse(strings.NewReader(tc.input))
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Parse() = %v, want nil", err)
+			}
+			if got, want := render(t, theme, tc.markup), tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Write() output = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestAdaptiveTheme(t *testing.T) {
+	t.Parallel()
+
+	light := richtext.NewTheme(map[string]style.Style{
+		"title": {Foreground: style.Blue},
+		"light": {Foreground: style.Green},
+	})
+	dark := richtext.NewTheme(map[string]style.Style{
+		"title": {Foreground: style.Red},
+	})
+
+	testCases := []struct {
+		name       string
+		theme      *richtext.Theme
+		background richtext.Background
+		markup     string
+		want       string
+	}{
+		{
+			name:       "LightBackground",
+			theme:      richtext.AdaptiveTheme(light, dark),
+			background: richtext.LightBackground,
+			markup:     "[theme:title]x[/theme]",
+			want:       reset + blueFg + "x" + reset,
+		},
+		{
+			name:       "DarkBackground",
+			theme:      richtext.AdaptiveTheme(light, dark),
+			background: richtext.DarkBackground,
+			markup:     "[theme:title]x[/theme]",
+			want:       reset + red + "x" + reset,
+		},
+		{
+			name:       "UnknownBackgroundIsDark",
+			theme:      richtext.AdaptiveTheme(light, dark),
+			background: richtext.UnknownBackground,
+			markup:     "[theme:title]x[/theme]",
+			want:       reset + red + "x" + reset,
+		},
+		{
+			name:       "NameMissingFromVariant",
+			theme:      richtext.AdaptiveTheme(light, dark),
+			background: richtext.DarkBackground,
+			markup:     "[theme:light]x[/theme]",
+			want:       "x",
+		},
+		{
+			name:       "NilVariant",
+			theme:      richtext.AdaptiveTheme(nil, dark),
+			background: richtext.LightBackground,
+			markup:     "[theme:title]x[/theme]",
+			want:       "x",
+		},
+		{
+			name: "DerivedOverridesBoth",
+			theme: richtext.AdaptiveTheme(light, dark).New(map[string]style.Style{
+				"title": {Foreground: style.Green},
+			}),
+			background: richtext.LightBackground,
+			markup:     "[theme:title]x[/theme]",
+			want:       reset + greenFg + "x" + reset,
+		},
+		{
+			name: "DerivedFallsBackToVariant",
+			theme: richtext.AdaptiveTheme(light, dark).New(map[string]style.Style{
+				"note": {Foreground: style.Green},
+			}),
+			background: richtext.LightBackground,
+			markup:     "[theme:title]x[/theme]",
+			want:       reset + blueFg + "x" + reset,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			var buf strings.Builder
+			sut := richtext.NewWriter(&buf, tc.theme)
+			sut.ForceColour()
+			sut.SetBackground(tc.background)
+
+			// Act
+			_, err := io.WriteString(sut, tc.markup)
+
+			// Assert
+			if got, want := err, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("Write() = %v, want nil", got)
+			}
+			if got, want := buf.String(), tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Write() output = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestDefaultTheme_LegibleOnBothBackgrounds(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		background richtext.Background
+		want       string
+	}{
+		{
+			name:       "Dark",
+			background: richtext.DarkBackground,
+			want:       reset + "\x1b[37mx" + reset,
+		},
+		{
+			name:       "Light",
+			background: richtext.LightBackground,
+			want:       reset + "\x1b[30mx" + reset,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			var buf strings.Builder
+			sut := richtext.NewWriter(&buf, richtext.DefaultTheme)
+			sut.ForceColour()
+			sut.SetBackground(tc.background)
+
+			// Act
+			_, err := io.WriteString(sut, "[theme:value]x[/theme]")
+
+			// Assert
+			if got, want := err, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("Write() = %v, want nil", got)
+			}
+			if got, want := buf.String(), tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Write() output = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+// render writes markup through a colour-forced Writer resolving theme tags
+// against theme, and returns the output.
+func render(t *testing.T, theme *richtext.Theme, markup string) string {
+	t.Helper()
+
+	var buf strings.Builder
+	w := richtext.NewWriter(&buf, theme)
+	w.ForceColour()
+	if _, err := io.WriteString(w, markup); err != nil {
+		t.Fatalf("Write() = %v, want nil", err)
+	}
+	return buf.String()
+}
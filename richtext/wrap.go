@@ -0,0 +1,193 @@
+package richtext
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/bitwizeshift/go-cli/internal/format"
+	"github.com/bitwizeshift/go-cli/richtext/internal/token"
+	"github.com/rivo/uniseg"
+)
+
+// Wrap breaks each line of the markup s that is wider than width columns so
+// that it fits, measuring the visible text as [Width] does. A line breaks at
+// its last space that fits, or between grapheme clusters when it has none, and
+// the spaces at a break are dropped. Spaces elsewhere, such as indentation, are
+// kept.
+//
+// Every line of the result closes the tags still open at its end and reopens
+// them at the start of the next, so each line can be indented or set inside a
+// border on its own. A non-positive width breaks no lines.
+func Wrap(s string, width int) string {
+	var lines []string
+	for _, line := range parseLines(s) {
+		for _, part := range breakLine(line, width) {
+			lines = append(lines, renderCells(part))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Truncate shortens each line of the markup s that is wider than width columns
+// to fit, measuring the visible text as [Width] does, and ends it with tail,
+// shown in the style of the text it replaces. It cuts only between grapheme
+// clusters. Like [Wrap], every line of the result closes the tags it opens. A
+// non-positive width shortens no lines.
+func Truncate(s string, width int, tail string) string {
+	var lines []string
+	for _, line := range parseLines(s) {
+		if width <= 0 || cellsWidth(line) <= width {
+			lines = append(lines, renderCells(line))
+			continue
+		}
+		room, used, keep := width-format.Width(tail), 0, 0
+		for keep < len(line) && used+line[keep].width <= room {
+			used += line[keep].width
+			keep++
+		}
+		cut := append(slices.Clip(line[:keep]), cell{text: tail, tags: line[keep].tags})
+		lines = append(lines, renderCells(cut))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// openTag is a tag open around text, with the tag that closes it.
+type openTag struct {
+	open, close string
+}
+
+// cell is a grapheme cluster of visible text, with its width in columns and
+// the tags open around it, outermost first.
+type cell struct {
+	text  string
+	width int
+	tags  []openTag
+}
+
+// parseLines splits the markup s into lines of cells. The tags of a
+// passthrough region are dropped, leaving its contents as literal text, and a
+// closing tag that does not match the innermost open tag is ignored.
+func parseLines(s string) [][]cell {
+	var scanner token.Scanner
+	tokens := scanner.Scan([]byte(s))
+	if tok, ok := scanner.Flush(); ok {
+		tokens = append(tokens, tok)
+	}
+	lines := [][]cell{nil}
+	var open []openTag
+	for _, tok := range tokens {
+		closing := "[/" + tok.Namespace + "]"
+		switch {
+		case tok.Kind == token.Text || !isKnownNamespace(tok.Namespace):
+			state := -1
+			for rest := tok.Raw; rest != ""; {
+				var cluster string
+				var width int
+				cluster, rest, width, state = uniseg.FirstGraphemeClusterInString(rest, state)
+				if cluster == "\n" || cluster == "\r\n" {
+					lines = append(lines, nil)
+					continue
+				}
+				n := len(lines) - 1
+				lines[n] = append(lines[n], cell{text: cluster, width: width, tags: open})
+			}
+		case tok.Namespace == nsRichText:
+		case tok.Kind == token.Open:
+			// Clipping first leaves the tags of earlier cells untouched.
+			open = append(slices.Clip(open), openTag{open: tok.Raw, close: closing})
+		case len(open) > 0 && open[len(open)-1].close == closing:
+			open = open[:len(open)-1]
+		}
+	}
+	return lines
+}
+
+// breakLine breaks line into parts no wider than width, as [Wrap] describes.
+func breakLine(line []cell, width int) [][]cell {
+	if width <= 0 || cellsWidth(line) <= width {
+		return [][]cell{line}
+	}
+	var parts [][]cell
+	for len(line) > 0 {
+		used, fit, space, text := 0, 0, -1, false
+		for fit < len(line) && used+line[fit].width <= width {
+			if line[fit].text == " " && text {
+				space = fit
+			}
+			text = text || line[fit].text != " "
+			used += line[fit].width
+			fit++
+		}
+		if fit == len(line) {
+			parts = append(parts, line)
+			break
+		}
+		if line[fit].text == " " && text {
+			space = fit
+		}
+		end := fit
+		if space > 0 {
+			end = space
+		}
+		// A cluster wider than width takes a line of its own.
+		end = max(end, 1)
+		parts = append(parts, trimSpaces(line[:end], false))
+		line = trimSpaces(line[end:], true)
+	}
+	return parts
+}
+
+// trimSpaces returns cells without their leading spaces when leading is true,
+// or without their trailing spaces otherwise.
+func trimSpaces(cells []cell, leading bool) []cell {
+	if leading {
+		for len(cells) > 0 && cells[0].text == " " {
+			cells = cells[1:]
+		}
+		return cells
+	}
+	for len(cells) > 0 && cells[len(cells)-1].text == " " {
+		cells = cells[:len(cells)-1]
+	}
+	return cells
+}
+
+// cellsWidth returns the number of columns cells occupy.
+func cellsWidth(cells []cell) int {
+	width := 0
+	for _, c := range cells {
+		width += c.width
+	}
+	return width
+}
+
+// renderCells returns the markup of cells, opening and closing tags only as
+// they change, escaping the text, and closing every tag by the end.
+func renderCells(cells []cell) string {
+	var b strings.Builder
+	var open []openTag
+	var text strings.Builder
+	restyle := func(tags []openTag) {
+		b.WriteString(Escape(text.String()))
+		text.Reset()
+		common := 0
+		for common < len(open) && common < len(tags) && open[common] == tags[common] {
+			common++
+		}
+		for i := len(open) - 1; i >= common; i-- {
+			b.WriteString(open[i].close)
+		}
+		for _, t := range tags[common:] {
+			b.WriteString(t.open)
+		}
+		open = tags
+	}
+	for _, c := range cells {
+		if !slices.Equal(c.tags, open) {
+			restyle(c.tags)
+		}
+		text.WriteString(c.text)
+	}
+	restyle(nil)
+	return b.String()
+}
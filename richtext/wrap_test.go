@@ -0,0 +1,156 @@
+package richtext_test
+
+import (
+	"testing"
+
+	"github.com/bitwizeshift/go-cli/richtext"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWrap(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		input string
+		width int
+		want  string
+	}{
+		{
+			name:  "FitsUnchanged",
+			input: "hello world",
+			width: 20,
+			want:  "hello world",
+		},
+		{
+			name:  "BreaksAtLastSpace",
+			input: "the quick brown fox",
+			width: 10,
+			want:  "the quick\nbrown fox",
+		},
+		{
+			name:  "TagsNotCounted",
+			input: "[fg:red]the quick[/fg] brown",
+			width: 9,
+			want:  "[fg:red]the quick[/fg]\nbrown",
+		},
+		{
+			name:  "OpenTagsClosedAndReopened",
+			input: "[fg:red]the [attr:bold]quick brown[/attr] fox[/fg]",
+			width: 9,
+			want:  "[fg:red]the [attr:bold]quick[/attr][/fg]\n[fg:red][attr:bold]brown[/attr] fox[/fg]",
+		},
+		{
+			name:  "ExistingLinesRebalanced",
+			input: "[fg:red]a\nb[/fg]",
+			width: 0,
+			want:  "[fg:red]a[/fg]\n[fg:red]b[/fg]",
+		},
+		{
+			name:  "IndentationKept",
+			input: "  indented text",
+			width: 10,
+			want:  "  indented\ntext",
+		},
+		{
+			name:  "LongWordBrokenBetweenClusters",
+			input: "abcdefgh",
+			width: 3,
+			want:  "abc\ndef\ngh",
+		},
+		{
+			name:  "WideCharactersNotSplit",
+			input: "日本語",
+			width: 5,
+			want:  "日本\n語",
+		},
+		{
+			name:  "PassthroughRecreatedOnEachLine",
+			input: "[richtext:off][a] [b][/richtext]",
+			width: 3,
+			want:  "[richtext:off][a][/richtext]\n[richtext:off][b][/richtext]",
+		},
+		{
+			name:  "UnknownNamespaceIsText",
+			input: "[foo:bar] baz",
+			width: 9,
+			want:  "[richtext:off][foo:bar][/richtext]\nbaz",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			wrapped := richtext.Wrap(tc.input, tc.width)
+
+			// Assert
+			if got, want := wrapped, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Wrap(%q, %d) = %q, want %q", tc.input, tc.width, got, want)
+			}
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		input string
+		width int
+		tail  string
+		want  string
+	}{
+		{
+			name:  "FitsUnchanged",
+			input: "[fg:red]short[/fg]",
+			width: 10,
+			tail:  "…",
+			want:  "[fg:red]short[/fg]",
+		},
+		{
+			name:  "TailInStyleOfReplacedText",
+			input: "plain [fg:red]styled text[/fg]",
+			width: 10,
+			tail:  "…",
+			want:  "plain [fg:red]sty…[/fg]",
+		},
+		{
+			name:  "WideTail",
+			input: "abcdefgh",
+			width: 6,
+			tail:  "...",
+			want:  "abc...",
+		},
+		{
+			name:  "WideCharacterDroppedWhole",
+			input: "日本語",
+			width: 4,
+			tail:  "…",
+			want:  "日…",
+		},
+		{
+			name:  "EachLineShortened",
+			input: "abcdef\nab",
+			width: 4,
+			tail:  "…",
+			want:  "abc…\nab",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			truncated := richtext.Truncate(tc.input, tc.width, tc.tail)
+
+			// Assert
+			if got, want := truncated, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Truncate(%q, %d) = %q, want %q", tc.input, tc.width, got, want)
+			}
+		})
+	}
+}
@@ -2,9 +2,9 @@ package richtext
 
 import (
 	"io"
+	"strings"
 
 	"github.com/bitwizeshift/go-cli/internal/term"
-	"github.com/bitwizeshift/go-cli/richtext/internal/sgr"
 	"github.com/bitwizeshift/go-cli/richtext/internal/token"
 	"github.com/bitwizeshift/go-cli/richtext/style"
 )
@@ -16,17 +16,20 @@ const (
 	nsBackground = "bg"
 	nsAttribute  = "attr"
 	nsTheme      = "theme"
+	nsLink       = "link"
 	nsRichText   = "richtext"
 )
 
 // Writer renders bracketed tag markup to an underlying writer as ANSI escapes.
 //
 // Tags take the form [ns:field] and close with [/ns]. The namespaces are:
-//   - fg / bg: a foreground or background colour, either one of the sixteen
-//     named ANSI colours or rgb(r,g,b);
+//   - fg / bg: a foreground or background colour: one of the sixteen named
+//     ANSI colours, an index into the 256-colour palette, or rgb(r,g,b);
 //   - attr: a text attribute such as bold or italic, which accumulates while
 //     nested;
 //   - theme: a named style registered in the [Theme] passed to [NewWriter];
+//   - link: a hyperlink to the URL given as its field, such as
+//     [link:https://example.com]text[/link];
 //   - richtext: with the field "off", opens a passthrough region whose contents
 //     are written verbatim without being parsed as tags, closed by [/richtext].
 //
@@ -35,15 +38,55 @@ const (
 // is not a tag: it and its closing tag are emitted verbatim.
 //
 // Colour output is governed by a [term.ColourEnabler], [term.DefaultEnabler] by
-// default; see [Writer.EnableColour] and [Writer.ForceColour]. When colour is
-// disabled, text is written through unchanged and no escapes are emitted.
+// default; see [Writer.EnableColour], [Writer.ForceColour] and
+// [Writer.SetColourDepth]. When colour is disabled, text is written through
+// unchanged and no escapes are emitted. Colours the destination cannot render
+// are replaced by the nearest it can: true-colour by the nearest entry of the
+// 256-colour palette, and either by the nearest named colour.
+//
+// Hyperlinks are emitted as OSC 8 escapes while colour is emitted and the
+// terminal is known to render them; see [Writer.EnableLinks] and
+// [Writer.ForceLinks]. Otherwise a link's URL follows its text in parentheses,
+// unless the text is the URL itself or the URL names a local file, which its
+// text is taken to name already.
+//
+// Whether the destination can show characters beyond ASCII is decided by a
+// [term.UnicodeEnabler], [term.LocaleUnicodeEnabler] by default; see
+// [Writer.Unicode]. The Writer never changes the text it is given on that
+// account, but components drawn with box-drawing characters consult it to fall
+// back to ASCII.
+//
+// An adaptive theme (see [AdaptiveTheme]) is resolved against the background
+// of the destination terminal, detected by [term.DefaultBackgroundDetector]
+// the first time it is needed while colour is emitted; see
+// [Writer.SetBackground].
+//
+// The same markup can instead be rendered as an HTML fragment or as an SVG
+// image, for documentation or bug reports; see [Writer.SetFormat],
+// [NewHTMLWriter], and [NewSVGWriter].
 type Writer struct {
 	dst      io.Writer
+	out      output
+	format   Format
+	written  bool // whether anything has been written in format
 	enabler  term.ColourEnabler
+	links    term.LinkEnabler
+	unicode  term.UnicodeEnabler
+	terminal terminalPolicies
+	detector term.BackgroundDetector
+	bg       Background
+	detected bool
 	theme    *Theme
 	scanner  token.Scanner
 	stack    []frame
-	lastBody string
+}
+
+// terminalPolicies are the colour, hyperlink, and Unicode policies a [Writer]
+// renders [ANSI] under, kept while it renders another format.
+type terminalPolicies struct {
+	enabler term.ColourEnabler
+	links   term.LinkEnabler
+	unicode term.UnicodeEnabler
 }
 
 // frame is one open tag on the render stack.
@@ -53,17 +96,110 @@ type frame struct {
 	colour    style.Colour
 	attribute style.Attribute
 	themed    style.Style
+	url       string // the target of a link
+	text      string // the text written inside a link, up to the length of url
 }
 
+// ColourDepth is the range of colours a [Writer] renders.
+type ColourDepth = term.ColourDepth
+
+// The colour depths a [Writer] renders at.
+const (
+	NoColour   = term.NoColour
+	Colour16   = term.Colour16
+	Colour256  = term.Colour256
+	TrueColour = term.TrueColour
+)
+
+// Background is the brightness of a terminal's background, against which an
+// adaptive [Theme] is resolved.
+type Background = term.Background
+
+// The backgrounds a [Writer] resolves themes against.
+const (
+	UnknownBackground = term.UnknownBackground
+	DarkBackground    = term.DarkBackground
+	LightBackground   = term.LightBackground
+)
+
 // NewWriter returns a Writer that renders to dst, resolving [theme:name] tags
 // against theme. theme may be nil, in which case every theme tag renders as a
 // reset. The default colour policy is [term.DefaultEnabler].
 func NewWriter(dst io.Writer, theme *Theme) *Writer {
 	return &Writer{
-		dst:     dst,
-		enabler: term.DefaultEnabler,
-		theme:   theme,
+		dst:      dst,
+		out:      &ansiOutput{dst: dst},
+		enabler:  term.DefaultEnabler,
+		links:    term.TermLinkEnabler{},
+		unicode:  term.LocaleUnicodeEnabler{},
+		detector: term.DefaultBackgroundDetector,
+		theme:    theme,
+	}
+}
+
+// Format is the format a [Writer] renders markup in.
+type Format int
+
+// The formats a [Writer] renders markup in.
+const (
+	// ANSI renders for a terminal, as SGR and OSC 8 escapes.
+	ANSI Format = iota
+
+	// HTML renders an HTML fragment; see [NewHTMLWriter].
+	HTML
+
+	// SVG renders an SVG image of a terminal window; see [NewSVGWriter].
+	SVG
+)
+
+// SetFormat renders markup in format f from now on. Whatever was written in
+// the previous format is finished first, so it is best called before anything
+// is written, or between documents.
+//
+// [HTML] and [SVG] replace the colour, hyperlink, and Unicode policies with
+// their own: both always emit true colour and show Unicode, [HTML] always emits
+// hyperlinks, and [SVG] spells them out. Switching back to [ANSI] restores the
+// policies the Writer had before it left [ANSI]. Unless [Writer.SetBackground]
+// has been called, [HTML] and [SVG] resolve themes against a [DarkBackground].
+func (w *Writer) SetFormat(f Format) {
+	if f == w.format {
+		return
 	}
+	if w.written {
+		// The previous document is finished on a best-effort basis, since
+		// [Writer.Flush] leaves it open.
+		_ = w.Flush()
+		_ = w.out.close()
+		w.written = false
+	}
+	if w.format == ANSI {
+		w.terminal = terminalPolicies{enabler: w.enabler, links: w.links, unicode: w.unicode}
+	}
+	switch f {
+	case HTML:
+		w.out = &htmlOutput{dst: w.dst, background: w.Background}
+		w.enabler, w.links = term.FixedDepth(TrueColour), term.FixedEnabler(true)
+		w.unicode = term.FixedEnabler(true)
+	case SVG:
+		w.out = &svgOutput{dst: w.dst, background: w.Background}
+		w.enabler, w.links = term.FixedDepth(TrueColour), term.FixedEnabler(false)
+		w.unicode = term.FixedEnabler(true)
+	default:
+		w.out = &ansiOutput{dst: w.dst}
+		w.enabler, w.links, w.unicode = w.terminal.enabler, w.terminal.links, w.terminal.unicode
+	}
+	w.format = f
+	if !w.detected {
+		w.detector = term.DefaultBackgroundDetector
+		if f != ANSI {
+			w.detector = term.FixedBackground(DarkBackground)
+		}
+	}
+}
+
+// Format reports the format w renders markup in.
+func (w *Writer) Format() Format {
+	return w.format
 }
 
 // EnableColour selects the default colour policy when b is true, or disables
@@ -81,9 +217,91 @@ func (w *Writer) ForceColour() {
 	w.enabler = term.FixedEnabler(true)
 }
 
+// SetColourDepth emits colour at depth d regardless of the destination, or
+// disables colour entirely when d is [NoColour].
+func (w *Writer) SetColourDepth(d ColourDepth) {
+	w.enabler = term.FixedDepth(d)
+}
+
+// EnableLinks selects the default hyperlink policy when b is true, or spells
+// out every link as text when b is false.
+func (w *Writer) EnableLinks(b bool) {
+	if b {
+		w.links = term.TermLinkEnabler{}
+		return
+	}
+	w.links = term.FixedEnabler(false)
+}
+
+// ForceLinks emits hyperlinks as OSC 8 escapes whenever colour is emitted,
+// regardless of the terminal.
+func (w *Writer) ForceLinks() {
+	w.links = term.FixedEnabler(true)
+}
+
+// EnableUnicode selects the default Unicode policy when b is true, or reports
+// the destination as showing only ASCII when b is false.
+func (w *Writer) EnableUnicode(b bool) {
+	if b {
+		w.unicode = term.LocaleUnicodeEnabler{}
+		return
+	}
+	w.unicode = term.FixedEnabler(false)
+}
+
+// ForceUnicode reports the destination as showing Unicode, regardless of the
+// locale.
+func (w *Writer) ForceUnicode() {
+	w.unicode = term.FixedEnabler(true)
+}
+
+// Unicode reports whether w's destination shows characters beyond ASCII under
+// its Unicode policy.
+func (w *Writer) Unicode() bool {
+	return w.unicode.EnableUnicode(w.dst)
+}
+
+// SetBackground resolves adaptive themes against bg regardless of the
+// destination, without detecting its background. An [UnknownBackground]
+// resolves as a [DarkBackground] does.
+func (w *Writer) SetBackground(bg Background) {
+	w.detector = term.FixedBackground(bg)
+	w.bg, w.detected = bg, true
+}
+
+// Background reports the background adaptive themes are resolved against,
+// detecting the destination's background on first use. It reports
+// [UnknownBackground] when the background cannot be detected, against which
+// themes resolve as they do on a [DarkBackground].
+func (w *Writer) Background() Background {
+	if !w.detected {
+		w.bg, w.detected = w.detector.Background(w.dst), true
+	}
+	return w.bg
+}
+
+// Theme returns the theme [theme:name] tags are resolved against, which may be
+// nil.
+func (w *Writer) Theme() *Theme {
+	return w.theme
+}
+
+// ColourEnabled reports whether w emits colour to its destination under its
+// colour policy.
+func (w *Writer) ColourEnabled() bool {
+	return w.ColourDepth() != NoColour
+}
+
+// ColourDepth reports the depth w emits colour to its destination at under its
+// colour policy.
+func (w *Writer) ColourDepth() ColourDepth {
+	return w.enabler.ColourDepth(w.dst)
+}
+
 // Write implements [io.Writer]. It returns a [*TagError] wrapping
 // [ErrUnbalancedTag] when a closing tag does not match the open tag.
 func (w *Writer) Write(p []byte) (int, error) {
+	w.written = w.written || len(p) > 0
 	for _, tok := range w.scanner.Scan(p) {
 		if err := w.handle(tok); err != nil {
 			return len(p), err
@@ -92,13 +310,16 @@ func (w *Writer) Write(p []byte) (int, error) {
 	return len(p), nil
 }
 
-// Close flushes any trailing partial tag as literal text and reports whether
-// the markup was balanced. It returns a [*TagError] wrapping [ErrUnclosedTag]
-// if any tags remain open.
+// Close flushes any trailing partial tag as literal text, finishes the
+// rendered document, and reports whether the markup was balanced. It returns a
+// [*TagError] wrapping [ErrUnclosedTag] if any tags remain open.
 func (w *Writer) Close() error {
 	if err := w.Flush(); err != nil {
 		return err
 	}
+	if err := w.out.close(); err != nil {
+		return err
+	}
 	if len(w.stack) > 0 {
 		top := w.stack[len(w.stack)-1]
 		return &TagError{Namespace: top.namespace, Err: ErrUnclosedTag}
@@ -111,7 +332,7 @@ func (w *Writer) Close() error {
 // [Writer.Close] it never reports an unclosed tag.
 func (w *Writer) Flush() error {
 	if tok, ok := w.scanner.Flush(); ok {
-		return w.writeString(tok.Raw)
+		return w.writeText(tok.Raw)
 	}
 	return nil
 }
@@ -120,21 +341,24 @@ func (w *Writer) handle(tok token.Token) error {
 	switch tok.Kind {
 	case token.Open:
 		if !isKnownNamespace(tok.Namespace) {
-			return w.writeString(tok.Raw)
+			return w.writeText(tok.Raw)
 		}
 		w.stack = append(w.stack, w.openFrame(tok.Namespace, tok.Field))
 		return w.emit()
 	case token.Close:
 		if !isKnownNamespace(tok.Namespace) {
-			return w.writeString(tok.Raw)
+			return w.writeText(tok.Raw)
 		}
 		if len(w.stack) == 0 || w.stack[len(w.stack)-1].namespace != tok.Namespace {
 			return &TagError{Namespace: tok.Namespace, Err: ErrUnbalancedTag}
 		}
+		if err := w.spellLink(w.stack[len(w.stack)-1]); err != nil {
+			return err
+		}
 		w.stack = w.stack[:len(w.stack)-1]
 		return w.emit()
 	default:
-		return w.writeString(tok.Raw)
+		return w.writeText(tok.Raw)
 	}
 }
 
@@ -163,6 +387,12 @@ func (w *Writer) openFrame(namespace, field string) frame {
 		} else {
 			f.reset = true
 		}
+	case nsLink:
+		if validURL(field) {
+			f.url = field
+		} else {
+			f.reset = true
+		}
 	case nsRichText:
 		if field != token.RawField {
 			f.reset = true
@@ -175,7 +405,11 @@ func (w *Writer) lookupTheme(name string) (style.Style, bool) {
 	if w.theme == nil {
 		return style.Style{}, false
 	}
-	return w.theme.lookup(name)
+	bg := UnknownBackground
+	if w.theme.adaptive() && w.ColourEnabled() {
+		bg = w.Background()
+	}
+	return w.theme.lookup(name, bg)
 }
 
 // resolve collapses the render stack into the currently active style.
@@ -198,26 +432,76 @@ func (w *Writer) resolve() style.Style {
 	return s
 }
 
-// emit writes the escape needed to move the terminal to the active style,
-// skipping output when colour is disabled or the style is unchanged.
+// emit moves the output to the active style and hyperlink, skipping output
+// when colour is disabled.
 func (w *Writer) emit() error {
-	if !w.enabler.EnableColour(w.dst) {
+	depth := w.ColourDepth()
+	if depth == NoColour {
 		return nil
 	}
-	body := w.resolve().String()
-	if body == w.lastBody {
+	return w.out.restyle(downsample(w.resolve(), depth), w.link())
+}
+
+// linksEnabled reports whether hyperlinks are emitted as OSC 8 escapes.
+func (w *Writer) linksEnabled() bool {
+	return w.ColourEnabled() && w.links.EnableLinks(w.dst)
+}
+
+// link returns the URL of the innermost open link, or the empty string when
+// no link is open or hyperlinks are not emitted.
+func (w *Writer) link() string {
+	if !w.linksEnabled() {
+		return ""
+	}
+	var url string
+	for _, f := range w.stack {
+		if f.url != "" {
+			url = f.url
+		}
+	}
+	return url
+}
+
+// spellLink writes the URL of the link f closes after its text, when
+// hyperlinks are not emitted and the URL tells the reader something its text
+// does not.
+func (w *Writer) spellLink(f frame) error {
+	if f.url == "" || f.text == f.url || strings.HasPrefix(f.url, "file:") || w.linksEnabled() {
 		return nil
 	}
-	w.lastBody = body
-	if body == "" {
-		return w.writeString(sgr.Reset)
+	return w.out.text(" (" + f.url + ")")
+}
+
+// validURL reports whether url can be carried in an OSC 8 escape: it must not
+// contain control characters, which would end the escape early.
+func validURL(url string) bool {
+	return !strings.ContainsFunc(url, func(r rune) bool {
+		return r < 0x20 || r == 0x7f
+	})
+}
+
+// downsample replaces the colours of s that cannot be rendered at depth with
+// the nearest that can.
+func downsample(s style.Style, depth ColourDepth) style.Style {
+	switch depth {
+	case Colour256:
+		s.Foreground = s.Foreground.To256()
+		s.Background = s.Background.To256()
+	case Colour16:
+		s.Foreground = s.Foreground.To16()
+		s.Background = s.Background.To16()
 	}
-	return w.writeString(sgr.Reset + body)
+	return s
 }
 
-func (w *Writer) writeString(s string) error {
-	_, err := io.WriteString(w.dst, s)
-	return err
+// writeText writes literal text, recording it as the text of each open link.
+func (w *Writer) writeText(s string) error {
+	for i := range w.stack {
+		if f := &w.stack[i]; f.url != "" && len(f.text) <= len(f.url) {
+			f.text += s[:min(len(s), len(f.url)+1-len(f.text))]
+		}
+	}
+	return w.out.text(s)
 }
 
 // Writer returns an [io.Writer] that writes verbatim to the destination without
@@ -243,7 +527,10 @@ func (p *passthroughWriter) Write(b []byte) (int, error) {
 	if err := p.w.Flush(); err != nil {
 		return 0, err
 	}
-	return p.w.dst.Write(b)
+	if err := p.w.writeText(string(b)); err != nil {
+		return 0, err
+	}
+	return len(b), nil
 }
 
 // Writer returns the destination the parent [Writer] renders onto.
@@ -255,7 +542,7 @@ var _ io.Writer = (*passthroughWriter)(nil)
 
 func isKnownNamespace(namespace string) bool {
 	switch namespace {
-	case nsForeground, nsBackground, nsAttribute, nsTheme, nsRichText:
+	case nsForeground, nsBackground, nsAttribute, nsTheme, nsLink, nsRichText:
 		return true
 	default:
 		return false
@@ -211,6 +211,308 @@ func TestWriter_EnableColour(t *testing.T) {
 	}
 }
 
+func TestWriter_SetColourDepth(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		depth richtext.ColourDepth
+		input string
+		want  string
+	}{
+		{
+			name:  "NoColour",
+			depth: richtext.NoColour,
+			input: "[fg:rgb(255,135,0)]x[/fg]",
+			want:  "x",
+		},
+		{
+			name:  "TrueColourKeepsRGB",
+			depth: richtext.TrueColour,
+			input: "[fg:rgb(255,135,0)]x[/fg]",
+			want:  "\x1b[0m\x1b[38;2;255;135;0mx\x1b[0m",
+		},
+		{
+			name:  "Colour256DownsamplesRGB",
+			depth: richtext.Colour256,
+			input: "[fg:rgb(255,135,0)]x[/fg]",
+			want:  "\x1b[0m\x1b[38;5;208mx\x1b[0m",
+		},
+		{
+			name:  "Colour256KeepsIndexed",
+			depth: richtext.Colour256,
+			input: "[bg:17]x[/bg]",
+			want:  "\x1b[0m\x1b[48;5;17mx\x1b[0m",
+		},
+		{
+			name:  "Colour16DownsamplesRGB",
+			depth: richtext.Colour16,
+			input: "[fg:rgb(250,10,10)]x[/fg]",
+			want:  "\x1b[0m\x1b[91mx\x1b[0m",
+		},
+		{
+			name:  "Colour16DownsamplesIndexed",
+			depth: richtext.Colour16,
+			input: "[bg:208]x[/bg]",
+			want:  "\x1b[0m\x1b[43mx\x1b[0m",
+		},
+		{
+			name:  "Colour16KeepsAttributes",
+			depth: richtext.Colour16,
+			input: "[attr:bold]x[/attr]",
+			want:  "\x1b[0m\x1b[1mx\x1b[0m",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			var buf strings.Builder
+			sut := richtext.NewWriter(&buf, nil)
+			sut.SetColourDepth(tc.depth)
+
+			// Act
+			_, err := sut.Write([]byte(tc.input))
+
+			// Assert
+			if got, want := err, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("Write() = %v, want nil", got)
+			}
+			if got, want := buf.String(), tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Write() output = %q, want %q", got, want)
+			}
+			if got, want := sut.ColourDepth(), tc.depth; got != want {
+				t.Errorf("ColourDepth() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestWriter_Write_Link(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		configure func(*richtext.Writer)
+		input     string
+		want      string
+	}{
+		{
+			name:      "ColourDisabledSpellsOutURL",
+			configure: func(w *richtext.Writer) { w.EnableColour(false) },
+			input:     "see [link:https://example.com]the docs[/link].",
+			want:      "see the docs (https://example.com).",
+		},
+		{
+			name:      "ColourDisabledTextIsURL",
+			configure: func(w *richtext.Writer) { w.EnableColour(false) },
+			input:     "[link:https://example.com]https://example.com[/link]",
+			want:      "https://example.com",
+		},
+		{
+			name:      "ColourDisabledFileLink",
+			configure: func(w *richtext.Writer) { w.EnableColour(false) },
+			input:     "[link:file:///src/main.go]main.go:10[/link]",
+			want:      "main.go:10",
+		},
+		{
+			name: "LinksDisabledSpellsOutURL",
+			configure: func(w *richtext.Writer) {
+				w.ForceColour()
+				w.EnableLinks(false)
+			},
+			input: "[link:https://example.com]docs[/link]",
+			want:  "docs (https://example.com)",
+		},
+		{
+			name: "ForcedLinksEmitOSC8",
+			configure: func(w *richtext.Writer) {
+				w.ForceColour()
+				w.ForceLinks()
+			},
+			input: "[link:https://example.com]docs[/link]",
+			want:  "\x1b]8;;https://example.com\x1b\\docs\x1b]8;;\x1b\\",
+		},
+		{
+			name: "ForcedLinksNeedColour",
+			configure: func(w *richtext.Writer) {
+				w.EnableColour(false)
+				w.ForceLinks()
+			},
+			input: "[link:https://example.com]docs[/link]",
+			want:  "docs (https://example.com)",
+		},
+		{
+			name: "NestedLinkRestoresOuter",
+			configure: func(w *richtext.Writer) {
+				w.ForceColour()
+				w.ForceLinks()
+			},
+			input: "[link:https://a.example]a[link:https://b.example]b[/link]c[/link]",
+			want: "\x1b]8;;https://a.example\x1b\\a" +
+				"\x1b]8;;https://b.example\x1b\\b" +
+				"\x1b]8;;https://a.example\x1b\\c" +
+				"\x1b]8;;\x1b\\",
+		},
+		{
+			name: "LinkInsideStyle",
+			configure: func(w *richtext.Writer) {
+				w.ForceColour()
+				w.ForceLinks()
+			},
+			input: "[fg:red][link:https://example.com]x[/link][/fg]",
+			want:  "\x1b[0m\x1b[31m\x1b]8;;https://example.com\x1b\\x\x1b]8;;\x1b\\\x1b[0m",
+		},
+		{
+			name: "ControlCharacterInURLRendersAsReset",
+			configure: func(w *richtext.Writer) {
+				w.EnableColour(false)
+			},
+			input: "[link:https://example.com\x07]x[/link]",
+			want:  "x",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			var buf strings.Builder
+			sut := richtext.NewWriter(&buf, nil)
+			tc.configure(sut)
+
+			// Act
+			_, err := sut.Write([]byte(tc.input))
+
+			// Assert
+			if got, want := err, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("Write() = %v, want nil", got)
+			}
+			if got, want := buf.String(), tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Write() output = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestWriter_Writer_RecordsLinkText(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	var buf strings.Builder
+	sut := richtext.NewWriter(&buf, nil)
+	sut.EnableColour(false)
+
+	// Act
+	_, _ = io.WriteString(sut, "[link:https://example.com]")
+	_, _ = io.WriteString(sut.Writer(), "https://example.com")
+	_, err := io.WriteString(sut, "[/link]")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Write() = %v, want nil", err)
+	}
+	if got, want := buf.String(), "https://example.com"; got != want {
+		t.Errorf("Write() output = %q, want %q", got, want)
+	}
+}
+
+func TestWriter_ColourEnabled(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		configure func(*richtext.Writer)
+		want      bool
+	}{
+		{
+			name:      "DefaultOnNonTTY",
+			configure: func(*richtext.Writer) {},
+			want:      false,
+		},
+		{
+			name:      "Disabled",
+			configure: func(w *richtext.Writer) { w.EnableColour(false) },
+			want:      false,
+		},
+		{
+			name:      "Forced",
+			configure: (*richtext.Writer).ForceColour,
+			want:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			sut := richtext.NewWriter(&strings.Builder{}, richtext.DefaultTheme)
+			tc.configure(sut)
+
+			// Act
+			got := sut.ColourEnabled()
+
+			// Assert
+			if want := tc.want; got != want {
+				t.Errorf("ColourEnabled() = %t, want %t", got, want)
+			}
+			if got, want := sut.Theme(), richtext.DefaultTheme; got != want {
+				t.Errorf("Theme() = %p, want %p", got, want)
+			}
+		})
+	}
+}
+
+func TestWriter_Background(t *testing.T) {
+	testCases := []struct {
+		name      string
+		env       string
+		configure func(*richtext.Writer)
+		want      richtext.Background
+	}{
+		{
+			name:      "DetectedFromEnvironment",
+			env:       "light",
+			configure: func(*richtext.Writer) {},
+			want:      richtext.LightBackground,
+		},
+		{
+			name:      "Undetectable",
+			env:       "",
+			configure: func(*richtext.Writer) {},
+			want:      richtext.UnknownBackground,
+		},
+		{
+			name:      "SetOverridesEnvironment",
+			env:       "light",
+			configure: func(w *richtext.Writer) { w.SetBackground(richtext.DarkBackground) },
+			want:      richtext.DarkBackground,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			t.Setenv("TERM_BACKGROUND", tc.env)
+			t.Setenv("COLORFGBG", "")
+			sut := richtext.NewWriter(&strings.Builder{}, richtext.DefaultTheme)
+			tc.configure(sut)
+
+			// Act
+			got := sut.Background()
+
+			// Assert
+			if want := tc.want; got != want {
+				t.Errorf("Background() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
 func TestWriter_Close(t *testing.T) {
 	t.Parallel()
 
@@ -409,3 +711,192 @@ func TestWriter_Close_FlushWriteFails_ReturnsError(t *testing.T) {
 		t.Fatalf("Flush() = %v, want %v", got, want)
 	}
 }
+
+func TestWriter_SetFormat(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		format richtext.Format
+		want   string
+	}{
+		{
+			name:   "HTML",
+			format: richtext.HTML,
+			want:   `<span style="color:#cd3131">x</span> <a href="https://example.com">y</a>`,
+		},
+		{
+			name:   "SVG",
+			format: richtext.SVG,
+			want:   `<tspan x="16" fill="#cd3131">x</tspan><tspan x="24.4"> y (https://example.com)</tspan>`,
+		},
+		{
+			name:   "BackToANSI",
+			format: richtext.ANSI,
+			want:   reset + red + "x" + reset + " \x1b]8;;https://example.com\x1b\\y\x1b]8;;\x1b\\",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			var buf strings.Builder
+			sut := richtext.NewHTMLWriter(&buf, nil)
+			sut.SetFormat(tc.format)
+			if tc.format == richtext.ANSI {
+				sut.ForceColour()
+				sut.ForceLinks()
+			}
+
+			// Act
+			_, _ = sut.Write([]byte("[fg:red]x[/fg] [link:https://example.com]y[/link]"))
+			err := sut.Close()
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Close() = %v, want nil", err)
+			}
+			if got, want := buf.String(), tc.want; !strings.Contains(got, want) {
+				t.Errorf("Close() output = %q, want it to contain %q", got, want)
+			}
+		})
+	}
+}
+
+func TestWriter_SetFormat_RestoresTerminalPolicies(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		configure   func(*richtext.Writer)
+		wantColour  bool
+		wantUnicode bool
+	}{
+		{
+			name:        "Forced",
+			configure:   func(w *richtext.Writer) { w.ForceColour(); w.ForceUnicode() },
+			wantColour:  true,
+			wantUnicode: true,
+		},
+		{
+			name:        "Disabled",
+			configure:   func(w *richtext.Writer) { w.EnableColour(false); w.EnableUnicode(false) },
+			wantColour:  false,
+			wantUnicode: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			sut := richtext.NewWriter(&strings.Builder{}, nil)
+			tc.configure(sut)
+
+			// Act
+			sut.SetFormat(richtext.HTML)
+			sut.SetFormat(richtext.ANSI)
+
+			// Assert
+			if got, want := sut.Format(), richtext.ANSI; !cmp.Equal(got, want) {
+				t.Errorf("Format() = %v, want %v", got, want)
+			}
+			if got, want := sut.ColourEnabled(), tc.wantColour; !cmp.Equal(got, want) {
+				t.Errorf("ColourEnabled() = %v, want %v", got, want)
+			}
+			if got, want := sut.Unicode(), tc.wantUnicode; !cmp.Equal(got, want) {
+				t.Errorf("Unicode() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestWriter_SetFormat_FinishesPreviousDocument(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	var buf strings.Builder
+	sut := richtext.NewHTMLWriter(&buf, nil)
+	_, _ = sut.Write([]byte("[fg:red]x[/fg]"))
+
+	// Act
+	sut.SetFormat(richtext.ANSI)
+	sut.ForceColour()
+	_, _ = sut.Write([]byte("[fg:red]y[/fg]"))
+	err := sut.Close()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Close() = %v, want nil", err)
+	}
+	want := `<span style="color:#cd3131">x</span>` + reset + red + "y" + reset
+	if got := buf.String(); !cmp.Equal(got, want) {
+		t.Errorf("Close() output = %q, want %q", got, want)
+	}
+}
+
+func TestWriter_SetFormat_KeepsBackground(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	sut := richtext.NewWriter(&strings.Builder{}, nil)
+	sut.SetBackground(richtext.LightBackground)
+
+	// Act
+	sut.SetFormat(richtext.HTML)
+
+	// Assert
+	if got, want := sut.Background(), richtext.LightBackground; !cmp.Equal(got, want) {
+		t.Errorf("Background() = %v, want %v", got, want)
+	}
+}
+
+func TestWriter_Unicode(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		configure func(*richtext.Writer)
+		want      bool
+	}{
+		{
+			name:      "Disabled",
+			configure: func(w *richtext.Writer) { w.EnableUnicode(false) },
+			want:      false,
+		},
+		{
+			name:      "Forced",
+			configure: func(w *richtext.Writer) { w.ForceUnicode() },
+			want:      true,
+		},
+		{
+			name: "HTMLFormat",
+			configure: func(w *richtext.Writer) {
+				w.EnableUnicode(false)
+				w.SetFormat(richtext.HTML)
+			},
+			want: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			sut := richtext.NewWriter(&strings.Builder{}, nil)
+			tc.configure(sut)
+
+			// Act
+			unicode := sut.Unicode()
+
+			// Assert
+			if got, want := unicode, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Unicode() = %v, want %v", got, want)
+			}
+		})
+	}
+}
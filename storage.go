@@ -69,6 +69,13 @@ func (s *Storage) RemoveAll(name string) error {
 	return s.impl.RemoveAll(name)
 }
 
+// Rename moves oldname to newname, creating the parent directories of newname
+// as needed and replacing any file already present there. Writing to a
+// temporary name and renaming it into place publishes a file atomically.
+func (s *Storage) Rename(oldname, newname string) error {
+	return s.impl.Rename(oldname, newname)
+}
+
 // Sub returns a [Storage] rooted at dir beneath s.
 func (s *Storage) Sub(dir string) (*Storage, error) {
 	sub, err := s.impl.Sub(dir)
@@ -36,4 +36,12 @@ func (p *BrewProvider) LatestVersion(ctx context.Context) (string, error) {
 	return updatecheck.CanonicalVersion(formula.Versions.Stable)
 }
 
-var _ Provider = (*BrewProvider)(nil)
+// ReleaseURL returns the formula's page on the Homebrew formulae host.
+func (p *BrewProvider) ReleaseURL() string {
+	return fmt.Sprintf("%s/formula/%s", baseURL(p.BaseURL, brewBaseURL), p.Name)
+}
+
+var (
+	_ Provider      = (*BrewProvider)(nil)
+	_ ReleaseLinker = (*BrewProvider)(nil)
+)
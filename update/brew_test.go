@@ -80,3 +80,18 @@ func TestBrewProvider_LatestVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestBrewProvider_ReleaseURL(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	sut := &update.BrewProvider{Name: "go-cli"}
+
+	// Act
+	url := sut.ReleaseURL()
+
+	// Assert
+	if got, want := url, "https://formulae.brew.sh/formula/go-cli"; !cmp.Equal(got, want) {
+		t.Errorf("BrewProvider.ReleaseURL() = %q, want %q", got, want)
+	}
+}
@@ -35,4 +35,13 @@ func (p *GitHubProvider) LatestVersion(ctx context.Context) (string, error) {
 	return updatecheck.CanonicalVersion(release.TagName)
 }
 
-var _ Provider = (*GitHubProvider)(nil)
+// ReleaseURL returns the page of the repository's latest release on
+// github.com.
+func (p *GitHubProvider) ReleaseURL() string {
+	return fmt.Sprintf("https://github.com/%s/%s/releases/latest", p.Owner, p.Repo)
+}
+
+var (
+	_ Provider      = (*GitHubProvider)(nil)
+	_ ReleaseLinker = (*GitHubProvider)(nil)
+)
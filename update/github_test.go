@@ -137,3 +137,18 @@ func TestGitHubProvider_LatestVersion_WithCanceledContext_ReturnsError(t *testin
 		t.Errorf("GitHubProvider.LatestVersion() = %q, want %q", got, want)
 	}
 }
+
+func TestGitHubProvider_ReleaseURL(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	sut := &update.GitHubProvider{Owner: "bitwizeshift", Repo: "go-cli"}
+
+	// Act
+	url := sut.ReleaseURL()
+
+	// Assert
+	if got, want := url, "https://github.com/bitwizeshift/go-cli/releases/latest"; !cmp.Equal(got, want) {
+		t.Errorf("GitHubProvider.ReleaseURL() = %q, want %q", got, want)
+	}
+}
@@ -3,6 +3,8 @@ package update
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"strings"
 
 	"github.com/bitwizeshift/go-cli/internal/updatecheck"
 )
@@ -40,4 +42,17 @@ func (p *GitLabProvider) LatestVersion(ctx context.Context) (string, error) {
 	return updatecheck.CanonicalVersion(releases[0].TagName)
 }
 
-var _ Provider = (*GitLabProvider)(nil)
+// ReleaseURL returns the page of the project's latest release, or "" when
+// Project is a numeric ID rather than a path.
+func (p *GitLabProvider) ReleaseURL() string {
+	path, err := url.PathUnescape(p.Project)
+	if err != nil || !strings.Contains(path, "/") {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s/-/releases/permalink/latest", baseURL(p.BaseURL, gitlabBaseURL), path)
+}
+
+var (
+	_ Provider      = (*GitLabProvider)(nil)
+	_ ReleaseLinker = (*GitLabProvider)(nil)
+)
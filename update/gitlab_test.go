@@ -80,3 +80,44 @@ func TestGitLabProvider_LatestVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestGitLabProvider_ReleaseURL(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		project string
+		want    string
+	}{
+		{
+			name:    "ProjectPath",
+			project: "group/project",
+			want:    "https://gitlab.com/group/project/-/releases/permalink/latest",
+		}, {
+			name:    "EncodedProjectPath",
+			project: "group%2Fsub%2Fproject",
+			want:    "https://gitlab.com/group/sub/project/-/releases/permalink/latest",
+		}, {
+			name:    "NumericProjectID",
+			project: "12345",
+			want:    "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			sut := &update.GitLabProvider{Project: tc.project}
+
+			// Act
+			url := sut.ReleaseURL()
+
+			// Assert
+			if got, want := url, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("GitLabProvider.ReleaseURL() = %q, want %q", got, want)
+			}
+		})
+	}
+}
@@ -34,4 +34,12 @@ func (p *GoProxyProvider) LatestVersion(ctx context.Context) (string, error) {
 	return updatecheck.CanonicalVersion(info.Version)
 }
 
-var _ Provider = (*GoProxyProvider)(nil)
+// ReleaseURL returns the module's page on pkg.go.dev.
+func (p *GoProxyProvider) ReleaseURL() string {
+	return "https://pkg.go.dev/" + p.Module
+}
+
+var (
+	_ Provider      = (*GoProxyProvider)(nil)
+	_ ReleaseLinker = (*GoProxyProvider)(nil)
+)
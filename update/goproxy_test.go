@@ -75,3 +75,18 @@ func TestGoProxyProvider_LatestVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestGoProxyProvider_ReleaseURL(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	sut := &update.GoProxyProvider{Module: "github.com/bitwizeshift/go-cli"}
+
+	// Act
+	url := sut.ReleaseURL()
+
+	// Assert
+	if got, want := url, "https://pkg.go.dev/github.com/bitwizeshift/go-cli"; !cmp.Equal(got, want) {
+		t.Errorf("GoProxyProvider.ReleaseURL() = %q, want %q", got, want)
+	}
+}
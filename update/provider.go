@@ -9,3 +9,12 @@ type Provider interface {
 	// semver (such as "v1.4.0"), or an error when the channel cannot be queried.
 	LatestVersion(ctx context.Context) (string, error)
 }
+
+// ReleaseLinker is implemented by a [Provider] that can direct users to a web
+// page describing the latest release on its channel. The help output's update
+// notice links to that page when the provider implements it.
+type ReleaseLinker interface {
+	// ReleaseURL returns the URL of the page describing the latest release, or
+	// "" when the channel has no such page.
+	ReleaseURL() string
+}
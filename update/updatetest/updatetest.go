@@ -21,6 +21,29 @@ func ErrProvider(err error) update.Provider {
 	})
 }
 
+// LinkedProvider returns an [update.Provider] that always reports version as the
+// latest available version, and implements [update.ReleaseLinker] by reporting
+// url as the page describing it.
+func LinkedProvider(version, url string) update.Provider {
+	return linkedProvider{
+		Provider: Provider(version),
+		url:      url,
+	}
+}
+
+// linkedProvider adds a release page to an [update.Provider].
+type linkedProvider struct {
+	update.Provider
+	url string
+}
+
+// ReleaseURL implements [update.ReleaseLinker].
+func (p linkedProvider) ReleaseURL() string {
+	return p.url
+}
+
+var _ update.ReleaseLinker = linkedProvider{}
+
 // providerFunc adapts a function into an [update.Provider].
 type providerFunc func(ctx context.Context) (string, error)
 
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/bitwizeshift/go-cli/update"
 	"github.com/bitwizeshift/go-cli/update/updatetest"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -48,3 +49,29 @@ func TestErrProvider_LatestVersion(t *testing.T) {
 		t.Errorf("ErrProvider.LatestVersion() = %q, want %q", got, want)
 	}
 }
+
+func TestLinkedProvider(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	sut := updatetest.LinkedProvider("v1.2.3", "https://example.com/releases")
+	ctx := context.Background()
+
+	// Act
+	version, err := sut.LatestVersion(ctx)
+	linker, ok := sut.(update.ReleaseLinker)
+
+	// Assert
+	if got, want := err, error(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Fatalf("LinkedProvider.LatestVersion() error = %v, want %v", got, want)
+	}
+	if got, want := version, "v1.2.3"; !cmp.Equal(got, want) {
+		t.Errorf("LinkedProvider.LatestVersion() = %q, want %q", got, want)
+	}
+	if !ok {
+		t.Fatalf("LinkedProvider() does not implement update.ReleaseLinker")
+	}
+	if got, want := linker.ReleaseURL(), "https://example.com/releases"; !cmp.Equal(got, want) {
+		t.Errorf("LinkedProvider.ReleaseURL() = %q, want %q", got, want)
+	}
+}